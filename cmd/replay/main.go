@@ -0,0 +1,112 @@
+// Command replay re-publishes stored storm reports to a Kafka topic so new
+// downstream consumers can be bootstrapped from the historical store.
+//
+//	replay -topic storm-reports-backfill -from 2024-04-01T00:00:00Z -to 2024-05-01T00:00:00Z -rate 200
+//
+// Database and broker settings come from the same environment variables as
+// the server (DATABASE_URL, KAFKA_BROKERS).
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/config"
+	"github.com/couchcryptid/storm-data-api/internal/database"
+	"github.com/couchcryptid/storm-data-api/internal/kafka"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-api/internal/store"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func main() {
+	topic := flag.String("topic", "", "target Kafka topic (required)")
+	fromFlag := flag.String("from", "", "start of event_time range, RFC 3339 (required)")
+	toFlag := flag.String("to", "", "end of event_time range, RFC 3339 (required)")
+	rate := flag.Int("rate", 100, "maximum messages per second (0 = unthrottled)")
+	flag.Parse()
+
+	if err := run(*topic, *fromFlag, *toFlag, *rate); err != nil {
+		slog.Error("replay failed", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(topic, fromFlag, toFlag string, rate int) error {
+	if topic == "" {
+		return fmt.Errorf("-topic is required")
+	}
+	from, err := time.Parse(time.RFC3339, fromFlag)
+	if err != nil {
+		return fmt.Errorf("invalid -from: %w", err)
+	}
+	to, err := time.Parse(time.RFC3339, toFlag)
+	if err != nil {
+		return fmt.Errorf("invalid -to: %w", err)
+	}
+	if !to.After(from) {
+		return fmt.Errorf("-to must be after -from")
+	}
+	if rate < 0 {
+		return fmt.Errorf("-rate must be >= 0")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	// config only requires brokers when RUN_MODE includes the consumer.
+	if len(cfg.KafkaBrokers) == 0 {
+		return fmt.Errorf("KAFKA_BROKERS is required")
+	}
+	logger := observability.NewLogger(cfg)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	pool, err := database.NewPool(ctx, cfg.DatabaseURL)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	s := store.New(pool, observability.NewMetrics(cfg))
+
+	// Replay writes one message per call, so flush each immediately rather
+	// than waiting out the default 1s batch timeout.
+	writer := &kafkago.Writer{
+		Addr:         kafkago.TCP(cfg.KafkaBrokers...),
+		Topic:        topic,
+		Balancer:     &kafkago.Hash{},
+		BatchSize:    1,
+		RequiredAcks: kafkago.RequireAll,
+	}
+	defer func() {
+		if err := writer.Close(); err != nil {
+			logger.Error("kafka writer close", "error", err)
+		}
+	}()
+
+	// One trace ID per run, sent on every message, ties downstream logs back
+	// to this replay.
+	traceID := newTraceID()
+	logger.Info("replay started", "topic", topic, "from", from, "to", to, "rate", rate, "trace_id", traceID)
+	start := time.Now()
+	n, err := kafka.Replay(ctx, s, writer, from, to, rate, traceID)
+	logger.Info("replay finished", "written", n, "duration", time.Since(start))
+	return err
+}
+
+// newTraceID returns a random 128-bit trace ID in the W3C hex form.
+func newTraceID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
@@ -9,11 +9,10 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/99designs/gqlgen/graphql/handler"
-	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/couchcryptid/storm-data-api/internal/config"
 	"github.com/couchcryptid/storm-data-api/internal/database"
+	"github.com/couchcryptid/storm-data-api/internal/export"
 	"github.com/couchcryptid/storm-data-api/internal/graph"
 	"github.com/couchcryptid/storm-data-api/internal/kafka"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
@@ -21,9 +20,16 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
+// Fixed GraphQL protections; see newQueryHandler for how they were sized.
+const (
+	queryDepthLimit       = 7
+	queryConcurrencyLimit = 2
+)
+
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
@@ -32,7 +38,7 @@ func main() {
 	}
 
 	logger := observability.NewLogger(cfg)
-	metrics := observability.NewMetrics()
+	metrics := observability.NewMetrics(cfg)
 
 	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
@@ -50,8 +56,26 @@ func main() {
 	}
 	defer pool.Close()
 
+	if cfg.DBWarmUpConns > 0 {
+		// Runs before the HTTP server starts, so /readyz can't report ready
+		// until the pool is primed. A failed warm-up only costs latency.
+		warmed, err := database.WarmUp(ctx, pool, cfg.DBWarmUpConns)
+		if err != nil {
+			logger.Warn("warm up database pool", "warmed", warmed, "error", err)
+		} else {
+			logger.Info("database pool warmed", "connections", warmed)
+		}
+	}
+
 	s := store.New(pool, metrics)
-	readiness := database.NewPoolReadiness(pool)
+	if cfg.EventTypeUnits != nil {
+		s.SetUnits(cfg.EventTypeUnits)
+	}
+	s.SetAggregationSample(cfg.AggregationSamplePercent)
+	if cfg.QueryBreakerCooldown > 0 {
+		s.SetQueryBreaker(cfg.QueryBreakerThreshold, cfg.QueryBreakerCooldown)
+	}
+	logEffectiveLimits(ctx, logger, cfg, int(pool.Config().MaxConns))
 
 	// DB pool stats collector
 	go func() {
@@ -66,75 +90,341 @@ func main() {
 				metrics.DBPoolConnections.WithLabelValues("idle").Set(float64(stat.IdleConns()))
 				metrics.DBPoolConnections.WithLabelValues("active").Set(float64(stat.AcquiredConns()))
 				metrics.DBPoolConnections.WithLabelValues("total").Set(float64(stat.TotalConns()))
+				// Cumulative acquisition stats; rate() over these shows pool
+				// exhaustion before the concurrency limit starts rejecting.
+				metrics.DBPoolAcquireWait.Set(stat.AcquireDuration().Seconds())
+				metrics.DBPoolEmptyAcquires.Set(float64(stat.EmptyAcquireCount()))
+				metrics.DBPoolCanceledAcquires.Set(float64(stat.CanceledAcquireCount()))
 			}
 		}
 	}()
 
-	// Kafka consumer
-	consumer := kafka.NewBatchConsumer(
-		cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaGroupID,
-		cfg.BatchSize, cfg.BatchFlushInterval,
-		s, metrics, logger,
-	)
-	defer func() {
-		if err := consumer.Close(); err != nil {
-			logger.Error("kafka consumer close", "error", err)
+	// Kafka consumer.
+	if cfg.RunsConsumer() && cfg.KafkaAutoCreateTopic {
+		// Development only (config refuses it elsewhere): a fresh local
+		// broker has no topic, and the reader would back off forever.
+		// Bounded so an unreachable broker can't stall startup.
+		topicCtx, cancelTopic := context.WithTimeout(ctx, 10*time.Second)
+		err = kafka.EnsureTopic(topicCtx, cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaTopicPartitions)
+		cancelTopic()
+		if err != nil {
+			logger.Warn("auto-create kafka topic", "topic", cfg.KafkaTopic, "error", err)
+		} else {
+			logger.Info("kafka topic ready", "topic", cfg.KafkaTopic, "partitions", cfg.KafkaTopicPartitions)
 		}
-	}()
-	go func() {
-		if err := consumer.Run(ctx); err != nil {
-			logger.Error("kafka consumer", "error", err)
-		}
-	}()
-
-	// GraphQL server with three layers of query protection:
-	//  1. Complexity limit (600): caps total field cost to prevent wide/expensive queries
-	//  2. Depth limit (7): caps nesting depth to prevent deeply recursive queries
-	//  3. Concurrency limit (2): caps parallel queries to prevent pgx pool exhaustion
-	//     (4 pool connections − 1 reserved for Kafka − 1 buffer = 2 for GraphQL)
-	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{
-		Resolvers:  &graph.Resolver{Store: s},
-		Complexity: graph.NewComplexityRoot(),
-	}))
-	srv.Use(extension.FixedComplexityLimit(600))
-	srv.Use(graph.DepthLimit{MaxDepth: 7})
-
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
-	r.Use(cors.AllowAll().Handler)
-	r.Use(observability.MetricsMiddleware(metrics))
-	r.Use(graph.ConcurrencyLimit(2)) // see comment above for pool math
-	r.Handle("/", playground.Handler("Storm Data API", "/query"))
-	r.Handle("/query", srv)
-	r.Get("/healthz", observability.LivenessHandler())
-	r.Get("/readyz", observability.ReadinessHandler(readiness))
-	r.Handle("/metrics", promhttp.Handler())
+	}
+	// The consumer runs under its own context so shutdown can stop it at its
+	// turn in SHUTDOWN_ORDER.
+	consumerCtx, stopConsumer := context.WithCancel(context.WithoutCancel(ctx))
+	defer stopConsumer()
+	consumerDone := make(chan struct{})
+	consumer, consumerChecks, readinessComponents := newConsumer(cfg, s, int(pool.Config().MaxConns), metrics, logger)
+	readiness := observability.AllReady(append([]observability.ReadinessChecker{database.NewPoolReadiness(pool)}, consumerChecks...)...)
+	if consumer != nil {
+		defer func() {
+			if err := consumer.Close(); err != nil {
+				logger.Error("kafka consumer close", "error", err)
+			}
+		}()
+		go func() {
+			defer close(consumerDone)
+			if err := consumer.Run(consumerCtx); err != nil {
+				logger.Error("kafka consumer", "error", err)
+			}
+		}()
+	} else {
+		close(consumerDone)
+	}
 
 	server := &http.Server{
 		Addr:              ":" + cfg.Port,
-		Handler:           http.TimeoutHandler(r, 25*time.Second, `{"errors":[{"message":"request timeout"}]}`),
+		Handler:           newHandler(cfg, s, readiness, readinessComponents, metrics, logger),
 		ReadTimeout:       10 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
 		WriteTimeout:      30 * time.Second,
 		IdleTimeout:       120 * time.Second,
 	}
 
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
-		logger.Info("shutting down")
-		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
-		defer shutdownCancel()
-		if err := server.Shutdown(shutdownCtx); err != nil {
-			logger.Error("server shutdown", "error", err)
-		}
+		logger.Info("shutting down", "order", cfg.ShutdownOrder)
+		shutdown(logger, cfg.ShutdownOrder, map[string]shutdownStage{
+			config.ShutdownStageHTTP: {timeout: cfg.ShutdownHTTPTimeout, stop: server.Shutdown},
+			config.ShutdownStageConsumer: {timeout: cfg.ShutdownConsumerTimeout, stop: func(ctx context.Context) error {
+				stopConsumer()
+				select {
+				case <-consumerDone:
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			}},
+		})
 	}()
 
-	logger.Info("server started", "port", cfg.Port)
+	logger.Info("server started", "port", cfg.Port, "run_mode", cfg.RunMode)
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
 		logger.Error("server error", "error", err)
 		os.Exit(1)
 	}
+	// ListenAndServe returns as soon as the HTTP stage begins; wait for every
+	// stage before the deferred closes release the consumer and pool.
+	<-shutdownDone
 
 	logger.Info("shutdown complete")
 }
+
+// newConsumer builds the Kafka batch consumer, or returns nil for RUN_MODE=api
+// so query replicas hold no Kafka reader and can scale without joining the
+// consumer group. The readiness checks and /readyz components it adds are
+// returned alongside it.
+func newConsumer(cfg *config.Config, s *store.Store, poolMaxConns int, metrics *observability.Metrics, logger *slog.Logger) (*kafka.BatchConsumer, []observability.ReadinessChecker, []observability.Component) {
+	if !cfg.RunsConsumer() {
+		return nil, nil, nil
+	}
+	consumer := kafka.NewBatchConsumer(
+		cfg.KafkaBrokers, cfg.KafkaTopic, cfg.KafkaGroupID,
+		kafka.FetchOptions{MinBytes: cfg.KafkaMinBytes, MaxBytes: cfg.KafkaMaxBytes, MaxWait: cfg.KafkaMaxWait},
+		cfg.BatchSize, cfg.BatchFlushInterval,
+		cfg.BatchMinSize, cfg.BatchMaxWait,
+		s, metrics, logger,
+	)
+	consumer.SetInsertTimeout(cfg.IngestQueryTimeout)
+	consumer.SetDrainTimeout(cfg.ShutdownConsumerTimeout)
+	// Shared by every reader, so adding partitions doesn't add pool pressure.
+	consumer.SetInsertLimiter(kafka.NewInsertLimiter(ingestConcurrency(cfg, poolMaxConns)))
+	if cfg.ExactlyOnce {
+		consumer.EnableExactlyOnce(s)
+	}
+
+	var checks []observability.ReadinessChecker
+	var components []observability.Component
+	if cfg.InsertDegradedAfter > 0 {
+		insertHealth := kafka.NewInsertHealth(cfg.InsertDegradedAfter, cfg.InsertDegradedMinFailures)
+		consumer.SetInsertHealth(insertHealth)
+		checks = append(checks, insertHealth)
+	}
+	// Opt-in: hold readiness until the consumer has reached Kafka, and/or
+	// fail it once Kafka has been unreachable for the grace period, for
+	// deployments that shouldn't serve from an instance whose write path is
+	// unverified. Others keep the query path ready independent of Kafka.
+	if cfg.ReadinessRequireKafka || cfg.KafkaReadinessGrace > 0 {
+		fetchReadiness := kafka.NewFetchReadiness(cfg.KafkaBrokers, cfg.KafkaTopic)
+		if cfg.KafkaReadinessGrace > 0 {
+			fetchReadiness.SetGracePeriod(cfg.KafkaReadinessGrace, cfg.ReadinessRequireKafka)
+		}
+		consumer.SetFetchReadiness(fetchReadiness)
+		components = append(components, observability.Component{Name: "kafka", Checker: fetchReadiness})
+	}
+	return consumer, checks, components
+}
+
+// newHandler builds the HTTP handler: middleware, the routes RUN_MODE
+// selects, and the request timeout.
+func newHandler(cfg *config.Config, s *store.Store, readiness observability.ReadinessChecker, readinessComponents []observability.Component, metrics *observability.Metrics, logger *slog.Logger) http.Handler {
+	r := chi.NewRouter()
+	r.Use(observability.ClientIP(cfg.TrustedProxies)) // before Logger so logs show the client
+	r.Use(middleware.Logger)
+	r.Use(middleware.Recoverer)
+	r.Use(cors.AllowAll().Handler)
+	r.Use(observability.TraceContext)
+	r.Use(observability.MetricsMiddleware(metrics, metricsExclusions(cfg)))
+	r.Use(graph.ConcurrencyLimit(queryConcurrencyLimit)) // see newQueryHandler for pool math
+	r.Use(graph.WithAPIKey(cfg.KnownAPIKeys()))
+	// Exemplars are only exposed in the OpenMetrics format, which Prometheus
+	// negotiates via Accept when exemplar storage is enabled.
+	metricsHandler := promhttp.Handler()
+	if cfg.MetricsExemplars {
+		metricsHandler = promhttp.InstrumentMetricHandler(prometheus.DefaultRegisterer,
+			promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}))
+	}
+
+	routes := func(r chi.Router) {
+		// RUN_MODE=consumer keeps only the health and metrics endpoints.
+		if cfg.RunsAPI() {
+			r.Handle(cfg.PlaygroundPath, playground.Handler(cfg.PlaygroundTitle, cfg.RoutePrefix+"/query"))
+			r.Handle("/query", newQueryHandler(cfg, s, metrics, logger))
+			r.Get("/export", export.Handler(s, graph.FieldMask(cfg.FieldMasks).Redact, logger))
+		}
+		r.Get("/healthz", observability.LivenessHandler())
+		r.Get("/readyz", observability.ReadinessHandler(readiness, readinessComponents...))
+		r.Handle("/metrics", metricsHandler)
+	}
+	if cfg.RoutePrefix != "" {
+		r.Route(cfg.RoutePrefix, routes)
+	} else {
+		routes(r)
+	}
+
+	// TimeoutHandler buffers the whole response, so /export, which streams,
+	// bypasses it and bounds itself with export.Timeout instead.
+	timeoutHandler := http.TimeoutHandler(r, 25*time.Second, `{"errors":[{"message":"request timeout"}]}`)
+	exportPath := cfg.RoutePrefix + "/export"
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == exportPath {
+			r.ServeHTTP(w, req)
+			return
+		}
+		timeoutHandler.ServeHTTP(w, req)
+	})
+}
+
+// shutdownStage stops one part of the service within timeout.
+type shutdownStage struct {
+	timeout time.Duration
+	stop    func(context.Context) error
+}
+
+// shutdown runs stages in order, each under its own deadline, so a slow drain
+// in one stage doesn't eat into the next one's budget.
+func shutdown(logger *slog.Logger, order []string, stages map[string]shutdownStage) {
+	for _, name := range order {
+		stage := stages[name]
+		start := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), stage.timeout)
+		err := stage.stop(ctx)
+		cancel()
+		if err != nil {
+			logger.Error("shutdown stage", "stage", name, "error", err)
+			continue
+		}
+		logger.Info("shutdown stage complete", "stage", name, "duration", time.Since(start))
+	}
+}
+
+// ingestConcurrency returns INGEST_CONCURRENCY, or when it is 0 the limit
+// derived from the pool size.
+func ingestConcurrency(cfg *config.Config, poolMaxConns int) int {
+	if cfg.IngestConcurrency > 0 {
+		return cfg.IngestConcurrency
+	}
+	return kafka.IngestConcurrencyFor(poolMaxConns)
+}
+
+// logEffectiveLimits logs one line with the limits this instance enforces,
+// after defaults are applied, so operators can confirm a deploy's settings
+// from its first log lines. Only the halves selected by RUN_MODE are
+// included; the finer-grained filter and fetch limits are added at debug.
+func logEffectiveLimits(ctx context.Context, logger *slog.Logger, cfg *config.Config, poolMaxConns int) {
+	debug := logger.Enabled(ctx, slog.LevelDebug)
+	attrs := []any{"run_mode", cfg.RunMode, "pool_max_conns", poolMaxConns}
+	if cfg.RunsAPI() {
+		attrs = append(attrs,
+			"query_complexity", cfg.QueryComplexity,
+			"internal_query_complexity", cfg.InternalQueryComplexity,
+			"query_depth", queryDepthLimit,
+			"query_concurrency", queryConcurrencyLimit,
+			"request_query_concurrency", cfg.RequestQueryConcurrency,
+			"max_page_size", graph.MaxPageSize,
+			"max_radius_miles", graph.MaxRadiusMiles,
+			"operation_timeout", cfg.OperationTimeout,
+		)
+		if debug {
+			attrs = append(attrs,
+				"max_radius_by_type", cfg.MaxRadiusByType,
+				"default_radius_by_type", cfg.DefaultRadiusByType,
+				"max_filter_cost", cfg.MaxFilterCost,
+				"max_query_params", cfg.MaxQueryParams,
+				"max_event_type_filters", cfg.MaxEventTypeFilters,
+				"max_aggregation_dimensions", cfg.MaxAggregationDimensions,
+				"max_unfiltered_time_range", cfg.MaxUnfilteredTimeRange,
+				"default_time_range", cfg.DefaultTimeRange,
+			)
+		}
+	}
+	if cfg.RunsConsumer() {
+		delivery := "at_least_once"
+		if cfg.ExactlyOnce {
+			delivery = "exactly_once"
+		}
+		attrs = append(attrs,
+			"consumer_delivery", delivery,
+			"batch_size", cfg.BatchSize,
+			"batch_flush_interval", cfg.BatchFlushInterval,
+			"ingest_concurrency", ingestConcurrency(cfg, poolMaxConns),
+		)
+		if debug {
+			attrs = append(attrs,
+				"batch_min_size", cfg.BatchMinSize,
+				"batch_max_wait", cfg.BatchMaxWait,
+				"ingest_query_timeout", cfg.IngestQueryTimeout,
+				"kafka_min_bytes", cfg.KafkaMinBytes,
+				"kafka_max_bytes", cfg.KafkaMaxBytes,
+				"kafka_max_wait", cfg.KafkaMaxWait,
+			)
+		}
+	}
+	logger.Info("effective limits", attrs...)
+}
+
+// metricsExclusions returns the requests left out of the HTTP metrics, with
+// paths resolved against ROUTE_PREFIX to match chi's route patterns.
+func metricsExclusions(cfg *config.Config) observability.MetricsExclusions {
+	paths := make([]string, len(cfg.MetricsExcludePaths))
+	for i, p := range cfg.MetricsExcludePaths {
+		paths[i] = cfg.RoutePrefix + p
+	}
+	return observability.MetricsExclusions{Methods: cfg.MetricsExcludeMethods, Paths: paths}
+}
+
+// newQueryHandler builds the GraphQL handler served at /query.
+func newQueryHandler(cfg *config.Config, s *store.Store, metrics *observability.Metrics, logger *slog.Logger) http.Handler {
+	// GraphQL server with three layers of query protection:
+	//  1. Complexity limit (QUERY_COMPLEXITY, 600 by default; INTERNAL_QUERY_COMPLEXITY
+	//     for INTERNAL_API_KEYS): caps total field cost to prevent wide/expensive queries
+	//  2. Depth limit (7): caps nesting depth to prevent deeply recursive queries
+	//  3. Concurrency limit (2): caps parallel requests to prevent pgx pool exhaustion
+	//     (4 pool connections − 1 reserved for Kafka − 1 buffer = 2 for GraphQL).
+	//     Each stormReports call can fan out to several queries, so the pool
+	//     holds 2 × REQUEST_QUERY_CONCURRENCY GraphQL connections at most; the
+	//     default of 1 keeps that within the 2 available. Raising it trades
+	//     per-request latency for waits on the pool.
+	srv := graph.NewServer(graph.NewExecutableSchema(graph.Config{
+		Resolvers: &graph.Resolver{
+			Store: s,
+			Limits: graph.Limits{
+				MaxRadiusByType:     cfg.MaxRadiusByType,
+				DefaultRadiusByType: cfg.DefaultRadiusByType,
+				MaxFilterCost:       cfg.MaxFilterCost,
+				MaxQueryParams:      cfg.MaxQueryParams,
+
+				MaxEventTypeFilters:      cfg.MaxEventTypeFilters,
+				MaxAggregationDimensions: cfg.MaxAggregationDimensions,
+				MaxFutureSkew:            cfg.MaxFutureSkew,
+				MaxUnfilteredTimeRange:   cfg.MaxUnfilteredTimeRange,
+
+				SortFields:              cfg.SortFields,
+				AuthenticatedSortFields: cfg.AuthenticatedSortFields,
+			},
+			Precision: graph.Precision{
+				CoordinateDecimals: cfg.CoordinateDecimals,
+				MagnitudeDecimals:  cfg.MagnitudeDecimals,
+			},
+			Metrics:   metrics,
+			Logger:    logger,
+			FieldMask: graph.FieldMask(cfg.FieldMasks),
+
+			DefaultTimeRange: cfg.DefaultTimeRange,
+			QueryConcurrency: cfg.RequestQueryConcurrency,
+		},
+		Complexity: graph.NewComplexityRoot(),
+	}))
+	srv.Use(graph.ComplexityBudget(cfg.QueryComplexity, cfg.InternalQueryComplexity, cfg.InternalAPIKeys))
+	srv.Use(graph.DepthLimit{MaxDepth: queryDepthLimit})
+	if cfg.OperationTimeout > 0 {
+		// Shorter than the 25s TimeoutHandler in main so resolvers are cancelled,
+		// and their database queries aborted, before the handler gives up.
+		srv.Use(graph.OperationTimeout{Timeout: cfg.OperationTimeout})
+	}
+	if len(cfg.FieldMasks) > 0 {
+		srv.AroundFields(graph.FieldMask(cfg.FieldMasks).Middleware())
+	}
+
+	if cfg.CacheMaxAge > 0 {
+		srv.Use(graph.CacheTracking{})
+		return graph.CacheHeaders(cfg.CacheMaxAge)(srv)
+	}
+	return srv
+}
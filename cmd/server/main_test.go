@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/config"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alwaysReady struct{}
+
+func (alwaysReady) CheckReadiness(context.Context) error { return nil }
+
+func loadRunMode(t *testing.T, mode string) *config.Config {
+	t.Helper()
+	t.Setenv("RUN_MODE", mode)
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	return cfg
+}
+
+func TestNewConsumer_RunMode(t *testing.T) {
+	tests := []struct {
+		mode     string
+		consumer bool
+	}{
+		{config.RunModeAll, true},
+		{config.RunModeAPI, false},
+		{config.RunModeConsumer, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := loadRunMode(t, tt.mode)
+			metrics := observability.NewTestMetrics()
+			consumer, _, _ := newConsumer(cfg, store.New(nil, metrics), 4, metrics, observability.NewLogger(cfg))
+			if !tt.consumer {
+				assert.Nil(t, consumer, "no Kafka reader")
+				return
+			}
+			require.NotNil(t, consumer)
+			assert.NoError(t, consumer.Close())
+		})
+	}
+}
+
+func TestNewHandler_RunMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		api  bool
+	}{
+		{config.RunModeAll, true},
+		{config.RunModeAPI, true},
+		{config.RunModeConsumer, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			cfg := loadRunMode(t, tt.mode)
+			metrics := observability.NewTestMetrics()
+			srv := httptest.NewServer(newHandler(cfg, store.New(nil, metrics), alwaysReady{}, nil, metrics, observability.NewLogger(cfg)))
+			defer srv.Close()
+
+			for _, path := range []string{"/healthz", "/readyz", "/metrics"} {
+				resp, err := http.Get(srv.URL + path)
+				require.NoError(t, err)
+				_ = resp.Body.Close()
+				assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+			}
+
+			resp, err := http.Post(srv.URL+"/query", "application/json", strings.NewReader(`{"query":"{ __typename }"}`))
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+			// Without a window, /export is rejected before touching the store.
+			export, err := http.Get(srv.URL + "/export")
+			require.NoError(t, err)
+			_ = export.Body.Close()
+			playground, err := http.Get(srv.URL + cfg.PlaygroundPath)
+			require.NoError(t, err)
+			_ = playground.Body.Close()
+
+			if tt.api {
+				assert.Equal(t, http.StatusOK, resp.StatusCode, "/query")
+				assert.Equal(t, http.StatusBadRequest, export.StatusCode, "/export")
+				assert.Equal(t, http.StatusOK, playground.StatusCode, "playground")
+			} else {
+				assert.Equal(t, http.StatusNotFound, resp.StatusCode, "/query")
+				assert.Equal(t, http.StatusNotFound, export.StatusCode, "/export")
+				assert.Equal(t, http.StatusNotFound, playground.StatusCode, "playground")
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake_After(t *testing.T) {
+	start := time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	short := f.After(time.Second)
+	long := f.After(time.Minute)
+	assert.Equal(t, 2, f.Waiters())
+
+	f.Advance(500 * time.Millisecond)
+	assert.Empty(t, short, "not yet due")
+
+	f.Advance(500 * time.Millisecond)
+	assert.Equal(t, start.Add(time.Second), <-short)
+	assert.Empty(t, long)
+	assert.Equal(t, 1, f.Waiters())
+
+	f.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour+time.Second), <-long)
+	assert.Zero(t, f.Waiters())
+	assert.Equal(t, start.Add(time.Hour+time.Second), f.Now())
+}
+
+func TestFake_AfterNonPositive(t *testing.T) {
+	start := time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	assert.Equal(t, start, <-f.After(0))
+	assert.Zero(t, f.Waiters())
+}
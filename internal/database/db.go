@@ -5,6 +5,7 @@ import (
 	"embed"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres" // register postgres driver for migrate
@@ -15,18 +16,59 @@ import (
 //go:embed migrations/*.sql
 var migrationsFS embed.FS
 
+// pingTimeout bounds the startup ping in NewPool so an unreachable database
+// fails fast with a clear error instead of waiting on the caller's context.
+const pingTimeout = 5 * time.Second
+
 // NewPool creates a pgx connection pool and verifies connectivity with a ping.
 func NewPool(ctx context.Context, databaseURL string) (*pgxpool.Pool, error) {
 	pool, err := pgxpool.New(ctx, databaseURL)
 	if err != nil {
 		return nil, fmt.Errorf("connect to database: %w", err)
 	}
-	if err := pool.Ping(ctx); err != nil {
-		return nil, fmt.Errorf("ping database: %w", err)
+	pingCtx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	if err := pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("ping database (timeout %s): %w", pingTimeout, err)
 	}
 	return pool, nil
 }
 
+// warmUpTimeout bounds WarmUp so a slow database delays startup by at most
+// this long instead of hanging the rollout.
+const warmUpTimeout = 10 * time.Second
+
+// WarmUp opens up to n pool connections and runs a trivial query on each
+// before releasing them, so the first requests after startup don't pay for
+// connection setup. The connections are held together while warming;
+// acquiring them one at a time would just reuse the same connection. n is
+// capped at the pool's MaxConns. Returns the number of connections warmed.
+func WarmUp(ctx context.Context, pool *pgxpool.Pool, n int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, warmUpTimeout)
+	defer cancel()
+
+	n = min(n, int(pool.Config().MaxConns))
+	conns := make([]*pgxpool.Conn, 0, n)
+	defer func() {
+		for _, c := range conns {
+			c.Release()
+		}
+	}()
+
+	for range n {
+		c, err := pool.Acquire(ctx)
+		if err != nil {
+			return len(conns), fmt.Errorf("warm up connection: %w", err)
+		}
+		conns = append(conns, c)
+		if _, err := c.Exec(ctx, "SELECT 1"); err != nil {
+			return len(conns), fmt.Errorf("warm up connection: %w", err)
+		}
+	}
+	return len(conns), nil
+}
+
 // RunMigrations applies all pending SQL migrations embedded in the binary.
 func RunMigrations(databaseURL string) error {
 	source, err := iofs.New(migrationsFS, "migrations")
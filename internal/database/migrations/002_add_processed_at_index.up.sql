@@ -0,0 +1,3 @@
+-- Serves the PROCESSED_AT sort and the ingestedWithinMinutes filter, both of
+-- which read recently ingested reports by processed_at.
+CREATE INDEX IF NOT EXISTS idx_processed_at_id ON storm_reports (processed_at, id);
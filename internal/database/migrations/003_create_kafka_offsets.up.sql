@@ -0,0 +1,9 @@
+-- Highest Kafka offset whose report has been written, per topic partition.
+-- Updated in the same transaction as the inserts when EXACTLY_ONCE is enabled.
+CREATE TABLE IF NOT EXISTS kafka_offsets (
+    topic       TEXT NOT NULL,
+    partition   INTEGER NOT NULL,
+    last_offset BIGINT NOT NULL,
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (topic, partition)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_severity_ordinal;
+ALTER TABLE storm_reports DROP COLUMN IF EXISTS severity_ordinal;
@@ -0,0 +1,14 @@
+-- Severity rank for comparison filters: minor=1, moderate=2, severe=3, extreme=4.
+-- Unclassified reports stay NULL. As a stored generated column it is computed
+-- for every existing row when added, and kept in sync on insert by Postgres.
+ALTER TABLE storm_reports
+    ADD COLUMN IF NOT EXISTS severity_ordinal SMALLINT GENERATED ALWAYS AS (
+        CASE measurement_severity
+            WHEN 'minor'    THEN 1
+            WHEN 'moderate' THEN 2
+            WHEN 'severe'   THEN 3
+            WHEN 'extreme'  THEN 4
+        END
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_severity_ordinal ON storm_reports (severity_ordinal);
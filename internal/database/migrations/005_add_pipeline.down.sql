@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_pipeline_time;
+ALTER TABLE storm_reports DROP COLUMN IF EXISTS pipeline;
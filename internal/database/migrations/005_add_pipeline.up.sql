@@ -0,0 +1,6 @@
+-- Upstream ETL pipeline that produced the report, so data from several
+-- producers sharing one topic can be told apart. Reports ingested before the
+-- column existed, or from untagged producers, are ''.
+ALTER TABLE storm_reports ADD COLUMN IF NOT EXISTS pipeline TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_pipeline_time ON storm_reports (pipeline, event_time);
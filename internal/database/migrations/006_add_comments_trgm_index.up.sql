@@ -0,0 +1,5 @@
+-- Trigram index so impactKeywords (comments ILIKE '%keyword%') can avoid a
+-- sequential scan. Patterns need at least three characters to use it.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_comments_trgm ON storm_reports USING gin (comments gin_trgm_ops);
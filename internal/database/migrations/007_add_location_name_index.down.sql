@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_location_name_lower;
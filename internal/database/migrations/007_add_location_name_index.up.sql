@@ -0,0 +1,3 @@
+-- Serves nearPlace lookups, which match location_name case-insensitively and
+-- pick the most recent report per state.
+CREATE INDEX IF NOT EXISTS idx_location_name_lower ON storm_reports (lower(location_name), location_state, event_time);
@@ -2,21 +2,36 @@ package database
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultReadinessTimeout bounds a single readiness ping. It is shorter than
+// the probe handler's own timeout so a wedged database fails the check, and
+// pgx closes the stuck connection, before the handler gives up on it.
+const DefaultReadinessTimeout = time.Second
+
+// pinger is the subset of pgxpool.Pool used by PoolReadiness.
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
 // PoolReadiness wraps a pgxpool.Pool and implements observability.ReadinessChecker.
 type PoolReadiness struct {
-	pool *pgxpool.Pool
+	pool    pinger
+	timeout time.Duration
 }
 
 // NewPoolReadiness returns a readiness checker backed by the given pool.
 func NewPoolReadiness(pool *pgxpool.Pool) *PoolReadiness {
-	return &PoolReadiness{pool: pool}
+	return &PoolReadiness{pool: pool, timeout: DefaultReadinessTimeout}
 }
 
-// CheckReadiness pings the database to verify connectivity.
+// CheckReadiness pings the database to verify connectivity, giving up after
+// the readiness timeout even if the caller's context allows longer.
 func (p *PoolReadiness) CheckReadiness(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
 	return p.pool.Ping(ctx)
 }
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockedPinger simulates a connection that never answers until its context ends.
+type blockedPinger struct{}
+
+func (blockedPinger) Ping(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+type okPinger struct{}
+
+func (okPinger) Ping(context.Context) error { return nil }
+
+func TestCheckReadiness_Healthy(t *testing.T) {
+	p := &PoolReadiness{pool: okPinger{}, timeout: time.Second}
+	assert.NoError(t, p.CheckReadiness(context.Background()))
+}
+
+func TestCheckReadiness_BlockedConnectionTimesOut(t *testing.T) {
+	p := &PoolReadiness{pool: blockedPinger{}, timeout: 20 * time.Millisecond}
+
+	start := time.Now()
+	err := p.CheckReadiness(context.Background())
+
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Less(t, time.Since(start), time.Second, "check should not wait on the caller's context")
+}
@@ -0,0 +1,144 @@
+// Package export serves bulk storm report downloads over plain HTTP, for
+// clients that want a time window of reports without building a GraphQL
+// query.
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	sharedobs "github.com/couchcryptid/storm-data-shared/observability"
+)
+
+// MaxSpan bounds the from/to window of a single export, so one request can't
+// hold a database cursor and connection open indefinitely.
+const MaxSpan = 7 * 24 * time.Hour
+
+// Timeout bounds a whole export, including writing the body. Export is served
+// outside the server's TimeoutHandler, which would buffer the response.
+const Timeout = 25 * time.Second
+
+// ContentTypeMsgpack is the media type that selects MessagePack output.
+// application/x-msgpack is accepted as an alias.
+const ContentTypeMsgpack = "application/msgpack"
+
+// ReportStreamer abstracts the store's streaming read for export. start is
+// called with the number of reports before the first call to fn.
+type ReportStreamer interface {
+	StreamCountedStormReports(ctx context.Context, from, to time.Time, start func(total int) error, fn func(*model.StormReport) error) error
+}
+
+// Redactor clears the fields of a report that the caller in ctx may not see.
+type Redactor func(ctx context.Context, r *model.StormReport)
+
+// Handler returns GET /export?from=&to=, which responds with every report
+// whose event_time falls in [from, to] (RFC 3339), in event_time order.
+// The body is a JSON array by default, or a MessagePack array of the same
+// report maps when the Accept header asks for application/msgpack. Reports
+// pass through redact before encoding and are written as they are read, so
+// the window is never held in memory.
+func Handler(src ReportStreamer, redact Redactor, logger *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		from, to, err := parseWindow(r)
+		if err != nil {
+			sharedobs.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		ctx, cancel := context.WithTimeout(r.Context(), Timeout)
+		defer cancel()
+
+		msgpack := wantsMsgpack(r.Header.Get("Accept"))
+		bw := bufio.NewWriter(w)
+		var buf []byte
+		started, n := false, 0
+		err = src.StreamCountedStormReports(ctx, from, to, func(total int) error {
+			started = true
+			w.Header().Add("Vary", "Accept")
+			if msgpack {
+				w.Header().Set("Content-Type", ContentTypeMsgpack)
+				w.WriteHeader(http.StatusOK)
+				_, err := bw.Write(appendArrayHeader(buf[:0], total))
+				return err
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			return bw.WriteByte('[')
+		}, func(rep *model.StormReport) error {
+			redact(ctx, rep)
+			if msgpack {
+				buf = appendReport(buf[:0], rep)
+			} else {
+				b, err := json.Marshal(rep)
+				if err != nil {
+					return err
+				}
+				if n > 0 {
+					buf = append(buf[:0], ',')
+				} else {
+					buf = buf[:0]
+				}
+				buf = append(buf, b...)
+			}
+			n++
+			_, err := bw.Write(buf)
+			return err
+		})
+		if err != nil {
+			logger.Error("export reports", "from", from, "to", to, "error", err)
+			if !started {
+				sharedobs.WriteJSON(w, http.StatusInternalServerError, map[string]string{"error": "export failed"})
+			}
+			// Once the status is sent the body is left truncated, which
+			// neither decoder accepts as a complete array.
+			return
+		}
+		if !msgpack {
+			_ = bw.WriteByte(']')
+		}
+		_ = bw.Flush()
+	}
+}
+
+func parseWindow(r *http.Request) (from, to time.Time, err error) {
+	q := r.URL.Query()
+	if from, err = time.Parse(time.RFC3339, q.Get("from")); err != nil {
+		return from, to, fmt.Errorf("from must be an RFC 3339 timestamp")
+	}
+	if to, err = time.Parse(time.RFC3339, q.Get("to")); err != nil {
+		return from, to, fmt.Errorf("to must be an RFC 3339 timestamp")
+	}
+	if to.Before(from) {
+		return from, to, fmt.Errorf("to must not be before from")
+	}
+	if to.Sub(from) > MaxSpan {
+		return from, to, fmt.Errorf("export window exceeds maximum of %s", MaxSpan)
+	}
+	return from, to, nil
+}
+
+// wantsMsgpack reports whether accept lists a MessagePack media type with a
+// non-zero quality. Anything else, including a missing header, gets JSON.
+func wantsMsgpack(accept string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mediaType != ContentTypeMsgpack && mediaType != "application/x-msgpack" {
+			continue
+		}
+		if q, ok := params["q"]; ok && strings.Trim(q, "0.") == "" {
+			continue
+		}
+		return true
+	}
+	return false
+}
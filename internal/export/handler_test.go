@@ -0,0 +1,126 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStreamer struct {
+	reports  []*model.StormReport
+	err      error
+	from, to time.Time
+}
+
+func (m *mockStreamer) StreamCountedStormReports(_ context.Context, from, to time.Time, start func(int) error, fn func(*model.StormReport) error) error {
+	m.from, m.to = from, to
+	if m.err != nil {
+		return m.err
+	}
+	if err := start(len(m.reports)); err != nil {
+		return err
+	}
+	for _, r := range m.reports {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func doExport(t *testing.T, src ReportStreamer, query, accept string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/export?"+query, http.NoBody)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	Handler(src, func(context.Context, *model.StormReport) {}, slog.New(slog.DiscardHandler))(rec, req)
+	return rec
+}
+
+const window = "from=2024-04-26T00:00:00Z&to=2024-04-27T00:00:00Z"
+
+func TestHandler_JSONByDefault(t *testing.T) {
+	src := &mockStreamer{reports: []*model.StormReport{{ID: "a"}, {ID: "b"}}}
+	rec := doExport(t, src, window, "")
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+	assert.Equal(t, "Accept", rec.Header().Get("Vary"))
+
+	var got []model.StormReport
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, "a", got[0].ID)
+	assert.Equal(t, time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC), src.from)
+	assert.Equal(t, time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC), src.to)
+}
+
+func TestHandler_EmptyJSONArray(t *testing.T) {
+	rec := doExport(t, &mockStreamer{}, window, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, "[]", rec.Body.String())
+}
+
+func TestHandler_Msgpack(t *testing.T) {
+	src := &mockStreamer{reports: []*model.StormReport{{ID: "a"}, {ID: "b"}}}
+
+	for _, accept := range []string{"application/msgpack", "application/x-msgpack", "application/json;q=0.5, application/msgpack"} {
+		rec := doExport(t, src, window, accept)
+		require.Equal(t, http.StatusOK, rec.Code, accept)
+		assert.Equal(t, ContentTypeMsgpack, rec.Header().Get("Content-Type"), accept)
+
+		want := appendReport(appendReport(appendArrayHeader(nil, 2), src.reports[0]), src.reports[1])
+		assert.Equal(t, want, rec.Body.Bytes(), accept)
+	}
+}
+
+func TestHandler_MsgpackRefused(t *testing.T) {
+	rec := doExport(t, &mockStreamer{}, window, "application/msgpack;q=0")
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+}
+
+func TestHandler_InvalidWindow(t *testing.T) {
+	tests := map[string]string{
+		"missing from": "to=2024-04-27T00:00:00Z",
+		"bad to":       "from=2024-04-26T00:00:00Z&to=tomorrow",
+		"inverted":     "from=2024-04-27T00:00:00Z&to=2024-04-26T00:00:00Z",
+		"too long":     "from=2024-04-01T00:00:00Z&to=2024-04-26T00:00:00Z",
+	}
+	for name, query := range tests {
+		t.Run(name, func(t *testing.T) {
+			src := &mockStreamer{}
+			rec := doExport(t, src, query, "")
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+			assert.Contains(t, rec.Body.String(), "error")
+			assert.True(t, src.from.IsZero(), "store must not be queried")
+		})
+	}
+}
+
+func TestHandler_StoreError(t *testing.T) {
+	rec := doExport(t, &mockStreamer{err: errors.New("db down")}, window, "application/msgpack")
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "db down")
+}
+
+func TestHandler_RedactsReports(t *testing.T) {
+	src := &mockStreamer{reports: []*model.StormReport{{ID: "a", Comments: "secret"}}}
+	req := httptest.NewRequest(http.MethodGet, "/export?"+window, http.NoBody)
+	rec := httptest.NewRecorder()
+	redact := func(_ context.Context, r *model.StormReport) { r.Comments = "" }
+	Handler(src, redact, slog.New(slog.DiscardHandler))(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "secret")
+}
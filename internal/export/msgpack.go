@@ -0,0 +1,139 @@
+package export
+
+import (
+	"encoding/binary"
+	"math"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// MessagePack encoding of storm reports. Only the handful of formats the
+// report structs need are implemented, so no external codec is required.
+// Keys match the model's JSON tags, and optional fields are omitted when nil,
+// so decoded reports have the same shape as the JSON export.
+
+// msgpackTimestamp is the extension type MessagePack reserves for timestamps.
+const msgpackTimestamp = 0xff // -1 as a signed byte
+
+func appendFloat(b []byte, f float64) []byte {
+	b = append(b, 0xcb)
+	return binary.BigEndian.AppendUint64(b, math.Float64bits(f))
+}
+
+func appendString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xda)
+		b = binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, 0xdb)
+		b = binary.BigEndian.AppendUint32(b, uint32(n)) //nolint:gosec // reports are far below 4 GiB
+	}
+	return append(b, s...)
+}
+
+func appendMapHeader(b []byte, n int) []byte {
+	if n < 16 {
+		return append(b, 0x80|byte(n))
+	}
+	b = append(b, 0xde)
+	return binary.BigEndian.AppendUint16(b, uint16(n)) //nolint:gosec // maps here have a fixed, small size
+}
+
+func appendArrayHeader(b []byte, n int) []byte {
+	switch {
+	case n < 16:
+		return append(b, 0x90|byte(n))
+	case n <= math.MaxUint16:
+		b = append(b, 0xdc)
+		return binary.BigEndian.AppendUint16(b, uint16(n))
+	default:
+		b = append(b, 0xdd)
+		return binary.BigEndian.AppendUint32(b, uint32(n)) //nolint:gosec // bounded by the export span
+	}
+}
+
+// appendTime encodes t with the timestamp extension, using the smallest of
+// the 32, 64 and 96-bit forms that holds it.
+func appendTime(b []byte, t time.Time) []byte {
+	sec, nsec := t.Unix(), uint64(t.Nanosecond()) //nolint:gosec // nanoseconds are always < 1e9
+	switch {
+	case sec >= 0 && sec>>34 == 0 && nsec == 0 && sec <= math.MaxUint32:
+		b = append(b, 0xd6, msgpackTimestamp)
+		return binary.BigEndian.AppendUint32(b, uint32(sec))
+	case sec >= 0 && sec>>34 == 0:
+		b = append(b, 0xd7, msgpackTimestamp)
+		return binary.BigEndian.AppendUint64(b, nsec<<34|uint64(sec))
+	default:
+		b = append(b, 0xc7, 12, msgpackTimestamp)
+		b = binary.BigEndian.AppendUint32(b, uint32(nsec))
+		return binary.BigEndian.AppendUint64(b, uint64(sec)) //nolint:gosec // two's complement is the wire format
+	}
+}
+
+func appendOptionalFloat(b []byte, key string, f *float64) []byte {
+	if f == nil {
+		return b
+	}
+	return appendFloat(appendString(b, key), *f)
+}
+
+func appendOptionalString(b []byte, key string, s *string) []byte {
+	if s == nil {
+		return b
+	}
+	return appendString(appendString(b, key), *s)
+}
+
+// appendReport encodes r as a MessagePack map.
+func appendReport(b []byte, r *model.StormReport) []byte {
+	b = appendMapHeader(b, 10+countSet(r.Pipeline != ""))
+	b = appendString(appendString(b, "id"), r.ID)
+	b = appendString(appendString(b, "event_type"), r.EventType)
+
+	b = appendMapHeader(appendString(b, "geo"), 2)
+	b = appendFloat(appendString(b, "lat"), r.Geo.Lat)
+	b = appendFloat(appendString(b, "lon"), r.Geo.Lon)
+
+	m := r.Measurement
+	b = appendMapHeader(appendString(b, "measurement"), 2+countSet(m.Severity != nil))
+	b = appendFloat(appendString(b, "magnitude"), m.Magnitude)
+	b = appendString(appendString(b, "unit"), m.Unit)
+	b = appendOptionalString(b, "severity", m.Severity)
+
+	b = appendTime(appendString(b, "event_time"), r.EventTime)
+
+	l := r.Location
+	b = appendMapHeader(appendString(b, "location"), 4+countSet(l.Distance != nil, l.Direction != nil))
+	b = appendString(appendString(b, "raw"), l.Raw)
+	b = appendString(appendString(b, "name"), l.Name)
+	b = appendOptionalFloat(b, "distance", l.Distance)
+	b = appendOptionalString(b, "direction", l.Direction)
+	b = appendString(appendString(b, "state"), l.State)
+	b = appendString(appendString(b, "county"), l.County)
+
+	b = appendString(appendString(b, "comments"), r.Comments)
+	b = appendString(appendString(b, "source_office"), r.SourceOffice)
+	b = appendTime(appendString(b, "time_bucket"), r.TimeBucket)
+	b = appendTime(appendString(b, "processed_at"), r.ProcessedAt)
+	if r.Pipeline != "" {
+		b = appendString(appendString(b, "pipeline"), r.Pipeline)
+	}
+	return b
+}
+
+// countSet returns how many of the given optional fields are present.
+func countSet(present ...bool) int {
+	n := 0
+	for _, p := range present {
+		if p {
+			n++
+		}
+	}
+	return n
+}
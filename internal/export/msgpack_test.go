@@ -0,0 +1,224 @@
+package export
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendString_Formats(t *testing.T) {
+	assert.Equal(t, []byte{0xa2, 'i', 'd'}, appendString(nil, "id"))
+
+	b := appendString(nil, string(make([]byte, 40)))
+	assert.Equal(t, []byte{0xd9, 40}, b[:2])
+	assert.Len(t, b, 42)
+
+	b = appendString(nil, string(make([]byte, 300)))
+	assert.Equal(t, []byte{0xda, 0x01, 0x2c}, b[:3])
+}
+
+func TestAppendFloat(t *testing.T) {
+	assert.Equal(t, []byte{0xcb, 0x3f, 0xf8, 0, 0, 0, 0, 0, 0}, appendFloat(nil, 1.5))
+}
+
+func TestAppendArrayHeader(t *testing.T) {
+	assert.Equal(t, []byte{0x90}, appendArrayHeader(nil, 0))
+	assert.Equal(t, []byte{0xdc, 0x01, 0x00}, appendArrayHeader(nil, 256))
+	assert.Equal(t, []byte{0xdd, 0x00, 0x01, 0x00, 0x00}, appendArrayHeader(nil, 1<<16))
+}
+
+func TestAppendTime_Forms(t *testing.T) {
+	// Whole seconds fit the 32-bit form.
+	assert.Equal(t, []byte{0xd6, 0xff, 0x66, 0x2b, 0x6e, 0x80},
+		appendTime(nil, time.Unix(1714122368, 0)))
+
+	// Sub-second precision needs the 64-bit form.
+	b := appendTime(nil, time.Unix(1, 1))
+	assert.Equal(t, []byte{0xd7, 0xff, 0, 0, 0, 0x04, 0, 0, 0, 0x01}, b)
+
+	// Pre-epoch times need the 96-bit form.
+	b = appendTime(nil, time.Unix(-1, 0))
+	assert.Equal(t, []byte{0xc7, 12, 0xff, 0, 0, 0, 0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, b)
+}
+
+func TestAppendReport_OmitsNilOptionals(t *testing.T) {
+	r := &model.StormReport{ID: "r1", EventType: "hail"}
+	b := appendReport(nil, r)
+
+	assert.Equal(t, byte(0x8a), b[0], "ten top-level keys")
+	assert.Contains(t, string(b), "measurement")
+	assert.NotContains(t, string(b), "severity")
+	assert.NotContains(t, string(b), "distance")
+	assert.NotContains(t, string(b), "direction")
+
+	sev, dist := "moderate", 2.5
+	r.Measurement.Severity = &sev
+	r.Location.Distance = &dist
+	b = appendReport(nil, r)
+	assert.Contains(t, string(b), "severity")
+	assert.Contains(t, string(b), "distance")
+}
+
+func TestAppendReport_Pipeline(t *testing.T) {
+	r := &model.StormReport{ID: "r1", Pipeline: "nws-lsr"}
+	b := appendReport(nil, r)
+
+	assert.Equal(t, byte(0x8b), b[0], "pipeline adds an eleventh key")
+	assert.Contains(t, string(b), "nws-lsr")
+}
+
+// TestAppendReport_MatchesJSON decodes the MessagePack encoding and checks it
+// has exactly the keys and values of the JSON encoding, so the two export
+// formats can't drift apart as fields are added.
+func TestAppendReport_MatchesJSON(t *testing.T) {
+	sev, dist, dir := "moderate", 2.5, "NW"
+	for name, r := range map[string]*model.StormReport{
+		"minimal": {ID: "r1", EventType: "hail"},
+		"full": {
+			ID:          "r2",
+			EventType:   "tornado",
+			Geo:         model.Geo{Lat: 32.78, Lon: -96.8},
+			Measurement: model.Measurement{Magnitude: 1.5, Unit: "in", Severity: &sev},
+			EventTime:   time.Date(2024, 4, 26, 15, 4, 5, 123000000, time.UTC),
+			Location: model.Location{
+				Raw: "2 NW DALLAS", Name: "Dallas", Distance: &dist, Direction: &dir,
+				State: "TX", County: "Dallas",
+			},
+			Comments:     "trees down",
+			SourceOffice: "FWD",
+			TimeBucket:   time.Date(2024, 4, 26, 15, 0, 0, 0, time.UTC),
+			ProcessedAt:  time.Date(2024, 4, 26, 15, 10, 0, 0, time.UTC),
+			Pipeline:     "nws-lsr",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			raw, err := json.Marshal(r)
+			require.NoError(t, err)
+			var want any
+			require.NoError(t, json.Unmarshal(raw, &want))
+
+			got, rest := decodeMsgpack(t, appendReport(nil, r))
+			assert.Empty(t, rest, "no trailing bytes")
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+// TestAppendReport_CoversEveryJSONField fills every field of StormReport by
+// reflection and checks each JSON key, nested ones included, is written, so a
+// field added to the model can't be silently left out of MessagePack exports.
+func TestAppendReport_CoversEveryJSONField(t *testing.T) {
+	var r model.StormReport
+	populate(t, reflect.ValueOf(&r).Elem())
+
+	got, rest := decodeMsgpack(t, appendReport(nil, &r))
+	assert.Empty(t, rest, "no trailing bytes")
+	assertJSONKeys(t, reflect.TypeOf(r), got, "report")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// populate sets every field reachable from v to a non-zero value.
+func populate(t *testing.T, v reflect.Value) {
+	t.Helper()
+	switch {
+	case v.Type() == timeType:
+		v.Set(reflect.ValueOf(time.Date(2024, 4, 26, 15, 4, 5, 0, time.UTC)))
+	case v.Kind() == reflect.String:
+		v.SetString("x")
+	case v.Kind() == reflect.Float64:
+		v.SetFloat(1.5)
+	case v.Kind() == reflect.Pointer:
+		v.Set(reflect.New(v.Type().Elem()))
+		populate(t, v.Elem())
+	case v.Kind() == reflect.Struct:
+		for i := range v.NumField() {
+			populate(t, v.Field(i))
+		}
+	default:
+		t.Fatalf("%s fields are not encoded by appendReport; add them there and here", v.Type())
+	}
+}
+
+// assertJSONKeys checks that decoded, a map decoded from MessagePack, has a
+// key for every JSON-tagged field of typ, recursing into nested structs.
+func assertJSONKeys(t *testing.T, typ reflect.Type, decoded any, path string) {
+	t.Helper()
+	m, ok := decoded.(map[string]any)
+	require.True(t, ok, "%s is not a map", path)
+	require.Len(t, m, typ.NumField(), "%s key count", path)
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		key, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		v, ok := m[key]
+		if !assert.True(t, ok, "%s.%s is missing", path, key) {
+			continue
+		}
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			assertJSONKeys(t, ft, v, path+"."+key)
+		}
+	}
+}
+
+// decodeMsgpack decodes the formats appendReport writes into the values
+// encoding/json produces: maps, strings, float64s, and timestamps as RFC 3339
+// strings.
+func decodeMsgpack(t *testing.T, b []byte) (any, []byte) {
+	t.Helper()
+	require.NotEmpty(t, b)
+	c := b[0]
+	switch {
+	case c&0xf0 == 0x80, c == 0xde:
+		n, rest := int(c&0x0f), b[1:]
+		if c == 0xde {
+			n, rest = int(binary.BigEndian.Uint16(b[1:])), b[3:]
+		}
+		m := make(map[string]any, n)
+		for range n {
+			var k, v any
+			k, rest = decodeMsgpack(t, rest)
+			v, rest = decodeMsgpack(t, rest)
+			m[k.(string)] = v
+		}
+		return m, rest
+	case c&0xe0 == 0xa0:
+		n := int(c & 0x1f)
+		return string(b[1 : 1+n]), b[1+n:]
+	case c == 0xd9:
+		n := int(b[1])
+		return string(b[2 : 2+n]), b[2+n:]
+	case c == 0xda:
+		n := int(binary.BigEndian.Uint16(b[1:]))
+		return string(b[3 : 3+n]), b[3+n:]
+	case c == 0xcb:
+		return math.Float64frombits(binary.BigEndian.Uint64(b[1:])), b[9:]
+	case c == 0xd6 && b[1] == msgpackTimestamp:
+		sec := binary.BigEndian.Uint32(b[2:])
+		return formatTime(time.Unix(int64(sec), 0)), b[6:]
+	case c == 0xd7 && b[1] == msgpackTimestamp:
+		v := binary.BigEndian.Uint64(b[2:])
+		return formatTime(time.Unix(int64(v&(1<<34-1)), int64(v>>34))), b[10:]
+	case c == 0xc7 && b[1] == 12 && b[2] == msgpackTimestamp:
+		nsec := binary.BigEndian.Uint32(b[3:])
+		sec := int64(binary.BigEndian.Uint64(b[7:]))
+		return formatTime(time.Unix(sec, int64(nsec))), b[15:]
+	}
+	t.Fatalf("unexpected msgpack format 0x%02x", c)
+	return nil, nil
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"slices"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// Aggregation row kinds counted by truncateAggregationRows.
+const (
+	rowEventType = iota
+	rowState
+	rowCounty
+	rowHour
+	rowSeverity
+	rowDayOfWeek
+	rowMagnitudeBucket
+)
+
+// aggregationRow identifies one group in StormAggregations: index i of its
+// list, and for counties, county j of state i.
+type aggregationRow struct {
+	kind, i, j int
+}
+
+// truncateAggregationRows keeps at most maxRows of the groups across every
+// aggregation list in agg, counties included, preferring the highest counts,
+// and reports whether any were dropped. Each list keeps its order. A state's
+// count is at least any of its counties', and states rank ahead of their
+// counties on ties, so a county is never kept without its state. Kept states
+// are copied rather than modified, since agg may share groups with a cached
+// result.
+func truncateAggregationRows(agg *model.StormAggregations, maxRows int) bool {
+	type candidate struct {
+		row   aggregationRow
+		count int
+	}
+	var candidates []candidate
+	for i, g := range agg.ByEventType {
+		candidates = append(candidates, candidate{aggregationRow{rowEventType, i, 0}, g.Count})
+	}
+	for i, sg := range agg.ByState {
+		candidates = append(candidates, candidate{aggregationRow{rowState, i, 0}, sg.Count})
+		for j, cg := range sg.Counties {
+			candidates = append(candidates, candidate{aggregationRow{rowCounty, i, j}, cg.Count})
+		}
+	}
+	for i, g := range agg.ByHour {
+		candidates = append(candidates, candidate{aggregationRow{rowHour, i, 0}, g.Count})
+	}
+	for i, g := range agg.BySeverity {
+		candidates = append(candidates, candidate{aggregationRow{rowSeverity, i, 0}, g.Count})
+	}
+	for i, g := range agg.ByDayOfWeek {
+		candidates = append(candidates, candidate{aggregationRow{rowDayOfWeek, i, 0}, g.Count})
+	}
+	for i, g := range agg.ByMagnitudeBucket {
+		candidates = append(candidates, candidate{aggregationRow{rowMagnitudeBucket, i, 0}, g.Count})
+	}
+	if len(candidates) <= maxRows {
+		return false
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return b.count - a.count
+	})
+	keep := make(map[aggregationRow]bool, maxRows)
+	for _, c := range candidates[:maxRows] {
+		keep[c.row] = true
+	}
+
+	agg.ByEventType = keepRows(agg.ByEventType, rowEventType, keep)
+	agg.ByHour = keepRows(agg.ByHour, rowHour, keep)
+	agg.BySeverity = keepRows(agg.BySeverity, rowSeverity, keep)
+	agg.ByDayOfWeek = keepRows(agg.ByDayOfWeek, rowDayOfWeek, keep)
+	agg.ByMagnitudeBucket = keepRows(agg.ByMagnitudeBucket, rowMagnitudeBucket, keep)
+	states := make([]*model.StateGroup, 0, len(agg.ByState))
+	for i, sg := range agg.ByState {
+		if !keep[aggregationRow{rowState, i, 0}] {
+			continue
+		}
+		kept := *sg
+		kept.Counties = make([]*model.CountyGroup, 0, len(sg.Counties))
+		for j, cg := range sg.Counties {
+			if keep[aggregationRow{rowCounty, i, j}] {
+				kept.Counties = append(kept.Counties, cg)
+			}
+		}
+		states = append(states, &kept)
+	}
+	agg.ByState = states
+	return true
+}
+
+// keepRows returns the groups of kind whose rows are in keep, in order.
+func keepRows[T any](groups []T, kind int, keep map[aggregationRow]bool) []T {
+	kept := make([]T, 0, len(groups))
+	for i, g := range groups {
+		if keep[aggregationRow{kind, i, 0}] {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
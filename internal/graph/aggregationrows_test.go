@@ -0,0 +1,94 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testAggregations() *model.StormAggregations {
+	t0 := time.Date(2024, 4, 26, 18, 0, 0, 0, time.UTC)
+	return &model.StormAggregations{
+		ByEventType: []*model.EventTypeGroup{
+			{EventType: "hail", Count: 40},
+			{EventType: "wind", Count: 8},
+		},
+		ByState: []*model.StateGroup{
+			{State: "TX", Count: 30, Counties: []*model.CountyGroup{
+				{County: "Dallas", Count: 25},
+				{County: "Tarrant", Count: 5},
+			}},
+			{State: "OK", Count: 18, Counties: []*model.CountyGroup{
+				{County: "Tulsa", Count: 18},
+			}},
+		},
+		ByHour: []*model.TimeGroup{
+			{Bucket: t0, Count: 2},
+			{Bucket: t0.Add(time.Hour), Count: 46},
+		},
+	}
+}
+
+func TestTruncateAggregationRows(t *testing.T) {
+	agg := testAggregations()
+	original := agg.ByState[0]
+
+	// The top five by count are hour(46), hail(40), TX(30), Dallas(25) and
+	// OK(18); Tulsa(18) ties OK but ranks after it and is dropped.
+	require.True(t, truncateAggregationRows(agg, 5))
+
+	require.Len(t, agg.ByEventType, 1)
+	assert.Equal(t, "hail", agg.ByEventType[0].EventType)
+	require.Len(t, agg.ByHour, 1)
+	assert.Equal(t, 46, agg.ByHour[0].Count)
+	require.Len(t, agg.ByState, 2)
+	assert.Equal(t, "TX", agg.ByState[0].State)
+	require.Len(t, agg.ByState[0].Counties, 1)
+	assert.Equal(t, "Dallas", agg.ByState[0].Counties[0].County)
+	assert.Equal(t, "OK", agg.ByState[1].State)
+	assert.Empty(t, agg.ByState[1].Counties, "Tulsa ties OK but ranks after it")
+
+	assert.Len(t, original.Counties, 2, "the shared state group is not modified")
+}
+
+func TestTruncateAggregationRows_WithinCap(t *testing.T) {
+	agg := testAggregations()
+	assert.False(t, truncateAggregationRows(agg, 9))
+	assert.Len(t, agg.ByEventType, 2)
+	assert.Len(t, agg.ByState[0].Counties, 2)
+	assert.Len(t, agg.ByHour, 2)
+}
+
+func TestTruncateAggregationRows_CountyNeverWithoutState(t *testing.T) {
+	agg := testAggregations()
+	require.True(t, truncateAggregationRows(agg, 2))
+	// hour(46) and hail(40) fill the cap; no state fits, so no county does.
+	assert.Empty(t, agg.ByState)
+	assert.Len(t, agg.ByEventType, 1)
+	assert.Len(t, agg.ByHour, 1)
+}
+
+func TestTruncateAggregationRows_CountsEveryList(t *testing.T) {
+	agg := &model.StormAggregations{
+		BySeverity: []*model.SeverityGroup{{Count: 12}, {Count: 1}},
+		ByDayOfWeek: []*model.DayOfWeekGroup{
+			{DayOfWeek: 5, Name: "Friday", Count: 9},
+			{DayOfWeek: 6, Name: "Saturday", Count: 2},
+		},
+		ByMagnitudeBucket: []*model.MagnitudeBucketGroup{
+			{EventType: "hail", Count: 3},
+			{EventType: "hail", Count: 30},
+		},
+	}
+	require.True(t, truncateAggregationRows(agg, 3))
+
+	require.Len(t, agg.BySeverity, 1)
+	assert.Equal(t, 12, agg.BySeverity[0].Count)
+	require.Len(t, agg.ByDayOfWeek, 1)
+	assert.Equal(t, "Friday", agg.ByDayOfWeek[0].Name)
+	require.Len(t, agg.ByMagnitudeBucket, 1)
+	assert.Equal(t, 30, agg.ByMagnitudeBucket[0].Count)
+}
@@ -0,0 +1,228 @@
+package graph
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// cacheState collects cache keys from resolvers during a single request.
+// The response is cacheable only if every root field that ran marked it, none
+// vetoed it, and it carries no GraphQL errors. Root fields that don't know
+// about caching never mark, so they keep the response uncached by default.
+type cacheState struct {
+	mu     sync.Mutex
+	keys   []string
+	roots  int
+	vetoed bool
+}
+
+type cacheStateContextKey struct{}
+
+// CacheHeaders adds Cache-Control and ETag headers to responses whose
+// resolvers marked them as immutable (see markCacheable), and answers a
+// matching If-None-Match with 304 Not Modified. Other responses pass through
+// untouched. The GraphQL server must also use CacheTracking, or no response
+// is ever cacheable.
+func CacheHeaders(maxAge time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			state := &cacheState{}
+			r = r.WithContext(context.WithValue(r.Context(), cacheStateContextKey{}, state))
+			next.ServeHTTP(&cacheWriter{ResponseWriter: w, r: r, state: state, maxAge: maxAge}, r)
+		})
+	}
+}
+
+// markCacheable records that the current response is safe to cache under key.
+func markCacheable(ctx context.Context, key string) {
+	if state, ok := ctx.Value(cacheStateContextKey{}).(*cacheState); ok {
+		state.mu.Lock()
+		state.keys = append(state.keys, key)
+		state.mu.Unlock()
+	}
+}
+
+// vetoCache prevents the current response from being cached.
+func vetoCache(ctx context.Context) {
+	if state, ok := ctx.Value(cacheStateContextKey{}).(*cacheState); ok {
+		state.mu.Lock()
+		state.vetoed = true
+		state.mu.Unlock()
+	}
+}
+
+// CacheTracking is the gqlgen extension that feeds CacheHeaders: it counts
+// root fields, so a response is only cached when all of them opted in, and
+// vetoes caching of responses with errors.
+type CacheTracking struct{}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.RootFieldInterceptor
+	graphql.ResponseInterceptor
+} = CacheTracking{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (CacheTracking) ExtensionName() string {
+	return "CacheTracking"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (CacheTracking) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptRootField implements graphql.RootFieldInterceptor.
+func (CacheTracking) InterceptRootField(ctx context.Context, next graphql.RootResolver) graphql.Marshaler {
+	if state, ok := ctx.Value(cacheStateContextKey{}).(*cacheState); ok {
+		state.mu.Lock()
+		state.roots++
+		state.mu.Unlock()
+	}
+	return next(ctx)
+}
+
+// InterceptResponse implements graphql.ResponseInterceptor. A partial result
+// with errors may succeed on retry, so it must not be cached.
+func (CacheTracking) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	resp := next(ctx)
+	if resp != nil && len(resp.Errors) > 0 {
+		vetoCache(ctx)
+	}
+	return resp
+}
+
+// etag returns the entity tag for the response, or "" if it is not cacheable.
+func (s *cacheState) etag() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.vetoed || s.roots == 0 || len(s.keys) < s.roots {
+		return ""
+	}
+	keys := slices.Clone(s.keys)
+	slices.Sort(keys)
+	sum := sha256.Sum256([]byte(strings.Join(keys, "\n")))
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// cacheWriter sets cache headers just before the status line is written,
+// after every resolver has run.
+type cacheWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	state       *cacheState
+	maxAge      time.Duration
+	wroteHeader bool
+	notModified bool
+}
+
+func (w *cacheWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if code == http.StatusOK {
+		if etag := w.state.etag(); etag != "" {
+			h := w.Header()
+			h.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(w.maxAge.Seconds())))
+			h.Set("ETag", etag)
+			h.Add("Vary", APIKeyHeader)
+			if etagMatches(w.r.Header.Get("If-None-Match"), etag) {
+				w.notModified = true
+				h.Del("Content-Type")
+				h.Del("Content-Length")
+				code = http.StatusNotModified
+			}
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *cacheWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.notModified {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// etagMatches reports whether an If-None-Match header lists etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheStormReports marks a stormReports response cacheable when it covers a
+// closed time window, and vetoes caching otherwise. It is a no-op unless the
+// CacheHeaders middleware is installed.
+func (r *queryResolver) cacheStormReports(ctx context.Context, filter *model.StormReportFilter, fields map[string]bool, meta *model.QueryMeta) {
+	if _, ok := ctx.Value(cacheStateContextKey{}).(*cacheState); !ok {
+		return
+	}
+	if meta.AggregationsTimedOut || !isHistorical(filter, fields, r.clock().Now()) {
+		vetoCache(ctx)
+		return
+	}
+	lastUpdated := meta.LastUpdated
+	if !fields["meta"] {
+		var err error
+		if lastUpdated, err = r.Store.LastUpdated(ctx); err != nil {
+			vetoCache(ctx)
+			return
+		}
+	}
+	key, err := historicalCacheKey(ctx, filter, lastUpdated)
+	if err != nil {
+		vetoCache(ctx)
+		return
+	}
+	markCacheable(ctx, key)
+}
+
+// isHistorical reports whether a stormReports result can no longer change
+// except through newly ingested reports: the window has closed by now, the
+// filter isn't relative to the current time, and no clock-derived field was
+// requested.
+func isHistorical(filter *model.StormReportFilter, fields map[string]bool, now time.Time) bool {
+	return filter.TimeRange.To.Before(now) &&
+		filter.IngestedWithinMinutes == nil &&
+		!fields["meta.dataLagMinutes"]
+}
+
+// historicalCacheKey identifies a historical result by the operation, the
+// normalized filter, the caller's API key (field masks differ per key), and
+// lastUpdated, so late-arriving reports produce a new ETag.
+func historicalCacheKey(ctx context.Context, filter *model.StormReportFilter, lastUpdated *time.Time) (string, error) {
+	normalized, err := json.Marshal(filter)
+	if err != nil {
+		return "", err
+	}
+	var updated string
+	if lastUpdated != nil {
+		updated = lastUpdated.UTC().Format(time.RFC3339Nano)
+	}
+	var query, operation string
+	if graphql.HasOperationContext(ctx) {
+		oc := graphql.GetOperationContext(ctx)
+		query, operation = oc.RawQuery, oc.OperationName
+	}
+	return strings.Join([]string{query, operation, string(normalized), apiKeyFromContext(ctx), updated}, "\x00"), nil
+}
@@ -0,0 +1,145 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// serveCached runs each of roots as a root field behind CacheHeaders.
+func serveCached(t *testing.T, mark func(ctx context.Context), ifNoneMatch string, roots ...func(ctx context.Context)) *httptest.ResponseRecorder {
+	t.Helper()
+	h := CacheHeaders(5 * time.Minute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, root := range append([]func(context.Context){mark}, roots...) {
+			CacheTracking{}.InterceptRootField(r.Context(), func(ctx context.Context) graphql.Marshaler {
+				root(ctx)
+				return graphql.Null
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"data":{}}`))
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCacheHeaders_Cacheable(t *testing.T) {
+	rec := serveCached(t, func(ctx context.Context) { markCacheable(ctx, "k") }, "")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+	assert.NotEmpty(t, rec.Header().Get("ETag"))
+	assert.Equal(t, APIKeyHeader, rec.Header().Get("Vary"))
+	assert.JSONEq(t, `{"data":{}}`, rec.Body.String())
+}
+
+func TestCacheHeaders_NotMarkedOrVetoed(t *testing.T) {
+	for name, mark := range map[string]func(context.Context){
+		"unmarked": func(context.Context) {},
+		"vetoed": func(ctx context.Context) {
+			markCacheable(ctx, "k")
+			vetoCache(ctx)
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			rec := serveCached(t, mark, "")
+			assert.Empty(t, rec.Header().Get("Cache-Control"))
+			assert.Empty(t, rec.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestCacheHeaders_EveryRootFieldMustMark(t *testing.T) {
+	mark := func(ctx context.Context) { markCacheable(ctx, "k") }
+
+	rec := serveCached(t, mark, "", mark)
+	assert.NotEmpty(t, rec.Header().Get("ETag"), "both root fields marked")
+
+	rec = serveCached(t, mark, "", func(context.Context) {})
+	assert.Empty(t, rec.Header().Get("Cache-Control"), "one root field did not opt in")
+	assert.Empty(t, rec.Header().Get("ETag"))
+}
+
+func TestCacheTracking_VetoesErrors(t *testing.T) {
+	state := &cacheState{}
+	ctx := context.WithValue(context.Background(), cacheStateContextKey{}, state)
+	CacheTracking{}.InterceptRootField(ctx, func(ctx context.Context) graphql.Marshaler {
+		markCacheable(ctx, "k")
+		return graphql.Null
+	})
+	require.NotEmpty(t, state.etag())
+
+	CacheTracking{}.InterceptResponse(ctx, func(context.Context) *graphql.Response {
+		return &graphql.Response{Errors: gqlerror.List{gqlerror.Errorf("partial")}}
+	})
+	assert.Empty(t, state.etag())
+}
+
+func TestCacheHeaders_NotModified(t *testing.T) {
+	mark := func(ctx context.Context) { markCacheable(ctx, "k") }
+	etag := serveCached(t, mark, "").Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	rec := serveCached(t, mark, `"other", `+etag)
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+	rec = serveCached(t, func(ctx context.Context) { markCacheable(ctx, "changed") }, etag)
+	assert.Equal(t, http.StatusOK, rec.Code, "a different key yields a new ETag")
+}
+
+func TestIsHistorical(t *testing.T) {
+	f := validFilter() // 2024 window
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, isHistorical(f, map[string]bool{"reports": true, "meta.lastUpdated": true}, now))
+	assert.False(t, isHistorical(f, map[string]bool{"meta.dataLagMinutes": true}, now))
+	assert.False(t, isHistorical(f, nil, f.TimeRange.To.Add(-time.Minute)), "window still open by the resolver clock")
+
+	minutes := 60
+	f.IngestedWithinMinutes = &minutes
+	assert.False(t, isHistorical(f, nil, now))
+
+	f = validFilter()
+	f.TimeRange.To = now.Add(time.Hour)
+	assert.False(t, isHistorical(f, nil, now))
+}
+
+func TestHistoricalCacheKey(t *testing.T) {
+	ctx := context.Background()
+	t1 := time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Minute)
+
+	base, err := historicalCacheKey(ctx, validFilter(), &t1)
+	require.NoError(t, err)
+
+	again, err := historicalCacheKey(ctx, validFilter(), &t1)
+	require.NoError(t, err)
+	assert.Equal(t, base, again)
+
+	updated, err := historicalCacheKey(ctx, validFilter(), &t2)
+	require.NoError(t, err)
+	assert.NotEqual(t, base, updated, "new reports change the key")
+
+	partner, err := historicalCacheKey(context.WithValue(ctx, apiKeyContextKey{}, "partner-a"), validFilter(), &t1)
+	require.NoError(t, err)
+	assert.NotEqual(t, base, partner, "field masks differ per API key")
+
+	f := validFilter()
+	f.States = []string{"TX"}
+	filtered, err := historicalCacheKey(ctx, f, &t1)
+	require.NoError(t, err)
+	assert.NotEqual(t, base, filtered)
+}
@@ -1,6 +1,31 @@
 package graph
 
-import "github.com/couchcryptid/storm-data-api/internal/model"
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// ComplexityBudget returns a complexity limit that allows internal for
+// requests carrying one of internalKeys in the X-API-Key header and public
+// for everyone else, so trusted analytics clients can run heavier queries
+// from the same deployment. Requires WithAPIKey in the HTTP middleware chain.
+func ComplexityBudget(public, internal int, internalKeys []string) *extension.ComplexityLimit {
+	trusted := make(map[string]bool, len(internalKeys))
+	for _, k := range internalKeys {
+		trusted[k] = true
+	}
+	return &extension.ComplexityLimit{
+		Func: func(ctx context.Context, _ *graphql.OperationContext) int {
+			if key := apiKeyFromContext(ctx); key != "" && trusted[key] {
+				return internal
+			}
+			return public
+		},
+	}
+}
 
 // NewComplexityRoot returns complexity estimators for expensive fields.
 // gqlgen computes total query complexity bottom-up and rejects queries exceeding
@@ -8,19 +33,27 @@ import "github.com/couchcryptid/storm-data-api/internal/model"
 // field can return:
 //   - Reports: up to MaxPageSize (20) items per query
 //   - ByEventType/ByState/ByHour: up to 10 groups each
+//   - BySeverity: up to 5 groups (four levels plus unclassified)
+//   - ByDayOfWeek: always 7 groups
+//   - ByMagnitudeBucket: up to 10 groups (4 ranges per type by default)
 //   - Counties: up to 5 per state
 //
 // Cost examples (budget = 600):
 //
 //	Dashboard query (reports + partial aggregations):  ~458  ✓
-//	Reports (all fields) + one aggregation + meta:     ~488  ✓
-//	All fields on all types (intentionally rejected):  ~628  ✗
+//	Reports (all fields) + one aggregation + meta:     ~568  ✓
+//	All fields on all types (intentionally rejected):  ~841  ✗
 //
 // See TestNewComplexityRoot_WorstCase for the exact field-by-field calculation.
 func NewComplexityRoot() ComplexityRoot {
 	return ComplexityRoot{
 		Query: struct {
-			StormReports func(childComplexity int, filter model.StormReportFilter) int
+			DataTimeExtent     func(childComplexity int) int
+			DistinctEventTypes func(childComplexity int, timeRange model.TimeRange) int
+			IngestionGap       func(childComplexity int, withinHours *int) int
+			StormReport        func(childComplexity int, id string) int
+			StormReports       func(childComplexity int, filter model.StormReportFilter) int
+			StormReportsBounds func(childComplexity int, filter model.StormReportFilter) int
 		}{
 			StormReports: func(childComplexity int, _ model.StormReportFilter) int {
 				return 1 + childComplexity
@@ -40,10 +73,17 @@ func NewComplexityRoot() ComplexityRoot {
 		},
 
 		StormAggregations: struct {
-			ByEventType func(childComplexity int) int
-			ByHour      func(childComplexity int) int
-			ByState     func(childComplexity int) int
-			TotalCount  func(childComplexity int) int
+			ByDayOfWeek       func(childComplexity int) int
+			ByEventType       func(childComplexity int) int
+			ByHour            func(childComplexity int) int
+			ByHourGranularity func(childComplexity int) int
+			ByMagnitudeBucket func(childComplexity int) int
+			BySeverity        func(childComplexity int) int
+			ByState           func(childComplexity int) int
+			SampleFraction    func(childComplexity int) int
+			Sampled           func(childComplexity int) int
+			TotalCount        func(childComplexity int) int
+			Truncated         func(childComplexity int) int
 		}{
 			ByEventType: func(childComplexity int) int {
 				return 10 * childComplexity
@@ -54,12 +94,22 @@ func NewComplexityRoot() ComplexityRoot {
 			ByHour: func(childComplexity int) int {
 				return 10 * childComplexity
 			},
+			BySeverity: func(childComplexity int) int {
+				return 5 * childComplexity
+			},
+			ByDayOfWeek: func(childComplexity int) int {
+				return 7 * childComplexity
+			},
+			ByMagnitudeBucket: func(childComplexity int) int {
+				return 10 * childComplexity
+			},
 		},
 
 		StateGroup: struct {
-			Count    func(childComplexity int) int
-			Counties func(childComplexity int) int
-			State    func(childComplexity int) int
+			Count        func(childComplexity int) int
+			Counties     func(childComplexity int) int
+			NearestMiles func(childComplexity int) int
+			State        func(childComplexity int) int
 		}{
 			Counties: func(childComplexity int) int {
 				return 5 * childComplexity
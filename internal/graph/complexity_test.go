@@ -1,10 +1,14 @@
 package graph
 
 import (
+	"context"
 	"testing"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewComplexityRoot_QueryStormReports(t *testing.T) {
@@ -27,6 +31,8 @@ func TestNewComplexityRoot_AggregationMultipliers(t *testing.T) {
 	assert.Equal(t, 30, c.StormAggregations.ByEventType(3))
 	assert.Equal(t, 30, c.StormAggregations.ByState(3))
 	assert.Equal(t, 30, c.StormAggregations.ByHour(3))
+	// Severity has at most five groups: 5 × child
+	assert.Equal(t, 10, c.StormAggregations.BySeverity(2))
 }
 
 func TestNewComplexityRoot_StateGroupCounties(t *testing.T) {
@@ -44,6 +50,7 @@ func TestNewComplexityRoot_NilForUnsetFields(t *testing.T) {
 	assert.Nil(t, c.StormReportsResult.Meta)
 	assert.Nil(t, c.StormReportsResult.Aggregations)
 	assert.Nil(t, c.StormAggregations.TotalCount)
+	assert.Nil(t, c.StormAggregations.ByHourGranularity)
 	assert.Nil(t, c.StateGroup.Count)
 	assert.Nil(t, c.StateGroup.State)
 }
@@ -53,38 +60,115 @@ func TestNewComplexityRoot_WorstCase(t *testing.T) {
 	// (custom multipliers replace the default). Object fields add +1 for themselves.
 	//
 	//   reportChild = id(1) + eventType(1) + geo(1+2=3) + measurement(1+3=4) +
-	//     eventTime(1) + sourceOffice(1) + location(1+6=7) + comments(1) +
-	//     timeBucket(1) + processedAt(1) = 21
-	//   reports = MaxPageSize(20) × 21 = 420
+	//     eventTime(1) + sourceOffice(1) + location(1+6+parsedLocation(1+3)=11) + comments(1) +
+	//     timeBucket(1) + processedAt(1) = 25
+	//   reports = MaxPageSize(20) × 25 = 500
 	//   byEventType = 10 × (eventType(1) + count(1) + maxMeasurement(1+3=4)) = 60
-	//   byState = 10 × (state(1) + count(1) + counties(5×2=10)) = 120
+	//   byState = 10 × (state(1) + count(1) + nearestMiles(1) + counties(5×3=15)) = 180
 	//   byHour = 10 × (bucket(1) + count(1)) = 20
-	//   aggregations = 1 + totalCount(1) + byEventType(60) + byState(120) + byHour(20) = 202
+	//   bySeverity = 5 × (severity(1) + count(1)) = 10
+	//   byDayOfWeek = 7 × (dayOfWeek(1) + name(1) + count(1)) = 21
+	//   byMagnitudeBucket = 10 × (eventType(1) + min(1) + max(1) + count(1)) = 40
+	//   aggregations = 1 + totalCount(1) + byEventType(60) + byState(180) + byHour(20) +
+	//     byHourGranularity(1) + bySeverity(10) + byDayOfWeek(21) + byMagnitudeBucket(40) +
+	//     truncated(1) = 335
 	//   meta = 1 + lastUpdated(1) + dataLagMinutes(1) = 3
-	//   total = 1 + totalCount(1) + hasMore(1) + reports(420) + aggregations(202) + meta(3) = 628
+	//   total = 1 + totalCount(1) + hasMore(1) + reports(500) + aggregations(335) + meta(3) = 841
 	// Note: This exceeds 600, so a client requesting ALL fields at max depth would be
 	// rejected. This is by design — typical queries request a subset.
 
 	c := NewComplexityRoot()
 
-	reportChildComplexity := 21
-	reports := c.StormReportsResult.Reports(reportChildComplexity) // 20 × 21 = 420
-	assert.Equal(t, 420, reports)
+	reportChildComplexity := 25
+	reports := c.StormReportsResult.Reports(reportChildComplexity) // 20 × 25 = 500
+	assert.Equal(t, 500, reports)
 
 	byEventType := c.StormAggregations.ByEventType(6) // 10 × 6 = 60
 	assert.Equal(t, 60, byEventType)
 
-	counties := c.StateGroup.Counties(2)                 // 5 × 2 = 10
-	byState := c.StormAggregations.ByState(2 + counties) // 10 × 12 = 120
-	assert.Equal(t, 120, byState)
+	counties := c.StateGroup.Counties(3)                 // 5 × 3 = 15
+	byState := c.StormAggregations.ByState(3 + counties) // 10 × 18 = 180
+	assert.Equal(t, 180, byState)
 
 	byHour := c.StormAggregations.ByHour(2) // 10 × 2 = 20
 	assert.Equal(t, 20, byHour)
 
+	bySeverity := c.StormAggregations.BySeverity(2) // 5 × 2 = 10
+	assert.Equal(t, 10, bySeverity)
+
+	byDayOfWeek := c.StormAggregations.ByDayOfWeek(3) // 7 × 3 = 21
+	assert.Equal(t, 21, byDayOfWeek)
+
+	byMagnitudeBucket := c.StormAggregations.ByMagnitudeBucket(4) // 10 × 4 = 40
+	assert.Equal(t, 40, byMagnitudeBucket)
+
 	// A realistic worst-case: reports (all fields) + one aggregation type + meta
-	//   totalCount(1) + hasMore(1) + reports(420) + aggregations(1+1+60) + meta(1+2) = 487
+	//   totalCount(1) + hasMore(1) + reports(500) + aggregations(1+1+60) + meta(1+2) = 567
 	realisticChild := 2 + reports + (1 + 1 + byEventType) + (1 + 2)
 	total := c.Query.StormReports(realisticChild, model.StormReportFilter{})
-	assert.Equal(t, 488, total)
+	assert.Equal(t, 568, total)
 	assert.LessOrEqual(t, total, 600, "realistic worst-case should fit within 600 budget")
 }
+
+func TestComplexityLimit_MultiOperationUsesSelectedOperation(t *testing.T) {
+	// Shallow: stormReports(1) + totalCount(1) = 2
+	// Deep:    reports alone is MaxPageSize(20) × id(1) = 20, well above the limit
+	exec := newTestExecutor()
+	exec.Use(extension.FixedComplexityLimit(10))
+
+	for _, tc := range []struct {
+		operationName string
+		wantErr       bool
+	}{
+		{"Shallow", false},
+		{"Deep", true},
+	} {
+		t.Run(tc.operationName, func(t *testing.T) {
+			ctx := graphql.StartOperationTrace(context.Background())
+			_, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{
+				Query:         multiOpDocument,
+				OperationName: tc.operationName,
+			})
+			if tc.wantErr {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0].Message, "operation has complexity")
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
+
+func TestComplexityBudget_InternalKeyGetsHigherLimit(t *testing.T) {
+	// Deep costs 72: over the public budget, within the internal one.
+	exec := newTestExecutor()
+	exec.Use(ComplexityBudget(10, 100, []string{"analytics"}))
+
+	for _, tc := range []struct {
+		name    string
+		apiKey  string
+		wantErr bool
+	}{
+		{"public", "", true},
+		{"unknown key", "partner-a", true},
+		{"internal key", "analytics", false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.apiKey != "" {
+				ctx = context.WithValue(ctx, apiKeyContextKey{}, tc.apiKey)
+			}
+			ctx = graphql.StartOperationTrace(ctx)
+			_, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{
+				Query:         multiOpDocument,
+				OperationName: "Deep",
+			})
+			if tc.wantErr {
+				require.Len(t, errs, 1)
+				assert.Contains(t, errs[0].Message, "operation has complexity 72, which exceeds the limit of 10")
+				return
+			}
+			assert.Empty(t, errs)
+		})
+	}
+}
@@ -32,13 +32,20 @@ func (d DepthLimit) Validate(graphql.ExecutableSchema) error {
 }
 
 // InterceptOperation implements graphql.OperationInterceptor.
+// For documents containing several named operations, oc.Operation is the one
+// selected by operationName, so only the operation being executed is measured.
 func (d DepthLimit) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
 	oc := graphql.GetOperationContext(ctx)
 	// Skip depth check for introspection queries
 	if isIntrospectionQuery(oc.Operation.SelectionSet) {
 		return next(ctx)
 	}
-	depth := queryDepth(oc.Operation.SelectionSet)
+	depth, err := queryDepth(oc.Operation.SelectionSet)
+	if err != nil {
+		return func(ctx context.Context) *graphql.Response {
+			return graphql.ErrorResponse(ctx, "%s", err)
+		}
+	}
 	if depth > d.MaxDepth {
 		return func(ctx context.Context) *graphql.Response {
 			return graphql.ErrorResponse(ctx, "query depth %d exceeds maximum allowed depth of %d", depth, d.MaxDepth)
@@ -47,29 +54,48 @@ func (d DepthLimit) InterceptOperation(ctx context.Context, next graphql.Operati
 	return next(ctx)
 }
 
-// queryDepth computes the deepest nesting level in a selection set.
-func queryDepth(selSet ast.SelectionSet) int {
+// queryDepth computes the deepest nesting level in a selection set. Document
+// validation normally rejects fragment cycles before this runs, but a fragment
+// that spreads itself (directly or through others) is reported as an error
+// rather than recursing until the stack overflows.
+func queryDepth(selSet ast.SelectionSet) (int, error) {
+	return selectionDepth(selSet, map[string]bool{})
+}
+
+// selectionDepth walks selSet, tracking the fragments currently being
+// expanded in active. The same fragment may appear in sibling branches; only
+// re-entering one that is still being expanded is a cycle.
+func selectionDepth(selSet ast.SelectionSet, active map[string]bool) (int, error) {
 	if len(selSet) == 0 {
-		return 0
+		return 0, nil
 	}
 	maxChild := 0
 	for _, sel := range selSet {
 		var childDepth int
+		var err error
 		switch s := sel.(type) {
 		case *ast.Field:
-			childDepth = queryDepth(s.SelectionSet)
+			childDepth, err = selectionDepth(s.SelectionSet, active)
 		case *ast.InlineFragment:
-			childDepth = queryDepth(s.SelectionSet)
+			childDepth, err = selectionDepth(s.SelectionSet, active)
 		case *ast.FragmentSpread:
 			if s.Definition != nil {
-				childDepth = queryDepth(s.Definition.SelectionSet)
+				if active[s.Name] {
+					return 0, fmt.Errorf("fragment %q spreads itself", s.Name)
+				}
+				active[s.Name] = true
+				childDepth, err = selectionDepth(s.Definition.SelectionSet, active)
+				delete(active, s.Name)
 			}
 		}
+		if err != nil {
+			return 0, err
+		}
 		if childDepth > maxChild {
 			maxChild = childDepth
 		}
 	}
-	return 1 + maxChild
+	return 1 + maxChild, nil
 }
 
 // isIntrospectionQuery checks if the query is an introspection query.
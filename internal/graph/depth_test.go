@@ -1,8 +1,12 @@
 package graph
 
 import (
+	"context"
+	"strings"
 	"testing"
 
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/executor"
 	"github.com/vektah/gqlparser/v2/ast"
 )
 
@@ -31,7 +35,10 @@ func TestQueryDepth(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := queryDepth(tt.sel)
+			got, err := queryDepth(tt.sel)
+			if err != nil {
+				t.Fatalf("queryDepth() error: %v", err)
+			}
 			if got != tt.want {
 				t.Errorf("queryDepth() = %d, want %d", got, tt.want)
 			}
@@ -39,6 +46,35 @@ func TestQueryDepth(t *testing.T) {
 	}
 }
 
+func TestQueryDepth_SelfReferencingFragment(t *testing.T) {
+	def := &ast.FragmentDefinition{Name: "Loop"}
+	def.SelectionSet = ast.SelectionSet{
+		field("a"),
+		&ast.FragmentSpread{Name: "Loop", Definition: def},
+	}
+	sel := ast.SelectionSet{field("root"), &ast.FragmentSpread{Name: "Loop", Definition: def}}
+
+	_, err := queryDepth(sel)
+	if err == nil || !strings.Contains(err.Error(), `fragment "Loop" spreads itself`) {
+		t.Errorf("expected fragment cycle error, got %v", err)
+	}
+}
+
+func TestQueryDepth_RepeatedFragmentIsNotACycle(t *testing.T) {
+	def := &ast.FragmentDefinition{Name: "Leaf", SelectionSet: ast.SelectionSet{field("x")}}
+	nested := field("a")
+	nested.SelectionSet = ast.SelectionSet{&ast.FragmentSpread{Name: "Leaf", Definition: def}}
+	sel := ast.SelectionSet{&ast.FragmentSpread{Name: "Leaf", Definition: def}, nested}
+
+	got, err := queryDepth(sel)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 3 {
+		t.Errorf("queryDepth() = %d, want 3", got)
+	}
+}
+
 func TestDepthLimitValidate(t *testing.T) {
 	if (DepthLimit{MaxDepth: 0}).Validate(nil) == nil {
 		t.Error("expected error for MaxDepth=0")
@@ -47,3 +83,80 @@ func TestDepthLimitValidate(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+// multiOpDocument contains a shallow and a deep operation so tests can verify
+// that limits apply to the operation selected by operationName.
+const multiOpDocument = `
+query Shallow {
+  stormReports(filter: {timeRange: {from: "2024-04-26T00:00:00Z", to: "2024-04-27T00:00:00Z"}}) {
+    totalCount
+  }
+}
+
+query Deep {
+  stormReports(filter: {timeRange: {from: "2024-04-26T00:00:00Z", to: "2024-04-27T00:00:00Z"}}) {
+    reports { id }
+    aggregations { byState { counties { county } } }
+  }
+}
+`
+
+// newTestExecutor returns an executor over the real schema. Resolvers are never
+// reached by these tests, so the store can be nil.
+func newTestExecutor() *executor.Executor {
+	return executor.New(NewExecutableSchema(Config{
+		Resolvers:  &Resolver{},
+		Complexity: NewComplexityRoot(),
+	}))
+}
+
+// operationContext parses and validates doc, selecting operationName, and
+// returns a context carrying the resulting OperationContext.
+func operationContext(t *testing.T, exec *executor.Executor, doc, operationName string) context.Context {
+	t.Helper()
+	ctx := graphql.StartOperationTrace(context.Background())
+	oc, errs := exec.CreateOperationContext(ctx, &graphql.RawParams{
+		Query:         doc,
+		OperationName: operationName,
+	})
+	if errs != nil {
+		t.Fatalf("create operation context: %v", errs)
+	}
+	return graphql.WithOperationContext(ctx, oc)
+}
+
+func TestDepthLimit_MultiOperationUsesSelectedOperation(t *testing.T) {
+	limit := DepthLimit{MaxDepth: 3}
+	tests := []struct {
+		operationName string
+		wantRejected  bool
+	}{
+		{"Shallow", false},
+		{"Deep", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationName, func(t *testing.T) {
+			ctx := operationContext(t, newTestExecutor(), multiOpDocument, tt.operationName)
+
+			called := false
+			next := func(context.Context) graphql.ResponseHandler {
+				called = true
+				return func(context.Context) *graphql.Response { return &graphql.Response{} }
+			}
+			resp := limit.InterceptOperation(ctx, next)(ctx)
+
+			if tt.wantRejected {
+				if called {
+					t.Fatal("expected operation to be rejected before execution")
+				}
+				if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "query depth 5 exceeds") {
+					t.Errorf("unexpected response errors: %v", resp.Errors)
+				}
+				return
+			}
+			if !called {
+				t.Errorf("expected operation to be executed, got errors: %v", resp.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/config"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// APIKeyHeader is the request header identifying the calling partner.
+const APIKeyHeader = "X-API-Key"
+
+type apiKeyContextKey struct{}
+
+// WithAPIKey returns middleware that stores the request's API key in the
+// context, so per-caller policies such as FieldMask, ComplexityBudget and
+// Limits.forCaller can look it up during execution. Only keys listed in keys
+// are stored; a missing or unknown key leaves the caller anonymous, so a
+// made-up header can't unlock anything.
+func WithAPIKey(keys []string) func(http.Handler) http.Handler {
+	known := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		known[k] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get(APIKeyHeader); key != "" && known[key] {
+				r = r.WithContext(context.WithValue(r.Context(), apiKeyContextKey{}, key))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// apiKeyFromContext returns the caller's API key, or "" if none was sent or
+// the key is not configured.
+func apiKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(apiKeyContextKey{}).(string)
+	return key
+}
+
+// DefaultMaskKey is the FieldMask entry applied to callers without a
+// configured API key.
+const DefaultMaskKey = config.DefaultMaskKey
+
+// FieldMask maps an API key to the fields that caller may not see, named as
+// "Type.field" (e.g. "StormReport.comments"). Configured keys without an
+// entry see every field. Anonymous callers, including those sending an
+// unknown key, get the DefaultMaskKey entry or, when there is none, every
+// field masked for any key, so dropping the header never reveals more.
+type FieldMask map[string][]string
+
+// fieldsFor returns the set of fields masked for apiKey ("" for anonymous
+// callers), or nil when the caller sees every field.
+func (m FieldMask) fieldsFor(apiKey string) map[string]bool {
+	var fields []string
+	switch def, ok := m[DefaultMaskKey]; {
+	case apiKey != "":
+		fields = m[apiKey]
+	case ok:
+		fields = def
+	default:
+		for _, f := range m {
+			fields = append(fields, f...)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	return set
+}
+
+// hides reports whether field is masked for the caller in ctx.
+func (m FieldMask) hides(ctx context.Context, field string) bool {
+	if len(m) == 0 {
+		return false
+	}
+	return m.fieldsFor(apiKeyFromContext(ctx))[field]
+}
+
+// commentsField is the masked field that impactKeywords and
+// matchedImpactKeyword read.
+const commentsField = "StormReport.comments"
+
+// checkMaskedFilters rejects filters that would probe fields masked for the
+// caller. impactKeywords matches against comments, so repeated probes would
+// reveal what hidden comments say.
+func (m FieldMask) checkMaskedFilters(ctx context.Context, filter *model.StormReportFilter) error {
+	if len(filter.ImpactKeywords) > 0 && m.hides(ctx, commentsField) {
+		return reject(ruleImpactKeywords, "impactKeywords is not available: comments are hidden for this caller")
+	}
+	return nil
+}
+
+// Middleware returns a gqlgen field middleware that redacts masked fields.
+// A masked field resolves to its Go zero value (an empty string for
+// comments, null for optional fields), so the query shape is unchanged and
+// partners don't need a separate schema.
+func (m FieldMask) Middleware() graphql.FieldMiddleware {
+	masked := make(map[string]map[string]bool, len(m)+1)
+	for key := range m {
+		if key != DefaultMaskKey {
+			masked[key] = m.fieldsFor(key)
+		}
+	}
+	masked[""] = m.fieldsFor("")
+	return func(ctx context.Context, next graphql.Resolver) (any, error) {
+		fields := masked[apiKeyFromContext(ctx)]
+		if fields == nil {
+			return next(ctx)
+		}
+		fc := graphql.GetFieldContext(ctx)
+		if !fields[fc.Object+"."+fc.Field.Name] {
+			return next(ctx)
+		}
+		res, err := next(ctx)
+		if err != nil || res == nil {
+			return res, err
+		}
+		return reflect.Zero(reflect.TypeOf(res)).Interface(), nil
+	}
+}
+
+// Redact zeroes the fields of r masked for the caller in ctx, so encoders
+// outside GraphQL, such as /export, honour the same mask. "Type.field" is
+// matched case-insensitively against the Go struct and field names, which
+// mirror the schema (StormReport.sourceOffice is StormReport.SourceOffice).
+func (m FieldMask) Redact(ctx context.Context, r *model.StormReport) {
+	if len(m) == 0 || r == nil {
+		return
+	}
+	if fields := m.fieldsFor(apiKeyFromContext(ctx)); fields != nil {
+		redactStruct(reflect.ValueOf(r).Elem(), fields)
+	}
+}
+
+// redactStruct zeroes masked fields of v, recursing into nested model types
+// such as Location and Measurement.
+func redactStruct(v reflect.Value, fields map[string]bool) {
+	t := v.Type()
+	for i := range t.NumField() {
+		sf, fv := t.Field(i), v.Field(i)
+		for masked := range fields {
+			typ, name, ok := strings.Cut(masked, ".")
+			if ok && typ == t.Name() && strings.EqualFold(name, sf.Name) {
+				fv.SetZero()
+				break
+			}
+		}
+		if fv.Kind() == reflect.Struct && fv.Type().PkgPath() == t.PkgPath() {
+			redactStruct(fv, fields)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package graph
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+func fieldCtx(apiKey, object, name string) context.Context {
+	ctx := context.Background()
+	if apiKey != "" {
+		ctx = context.WithValue(ctx, apiKeyContextKey{}, apiKey)
+	}
+	return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+		Object: object,
+		Field:  graphql.CollectedField{Field: &ast.Field{Name: name}},
+	})
+}
+
+func TestFieldMask_Middleware(t *testing.T) {
+	mw := FieldMask{"partner-a": {"StormReport.comments", "Location.distance"}}.Middleware()
+	distance := 2.5
+	tests := []struct {
+		name   string
+		apiKey string
+		object string
+		field  string
+		value  any
+		want   any
+	}{
+		{"masked string", "partner-a", "StormReport", "comments", "hail damage at 123 Main St", ""},
+		{"masked nullable", "partner-a", "Location", "distance", &distance, (*float64)(nil)},
+		{"unmasked field", "partner-a", "StormReport", "sourceOffice", "OUN", "OUN"},
+		{"key without mask", "partner-b", "StormReport", "comments", "visible", "visible"},
+		{"no key gets every mask", "", "StormReport", "comments", "hidden", ""},
+		{"no key, unmasked field", "", "StormReport", "sourceOffice", "OUN", "OUN"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := mw(fieldCtx(tt.apiKey, tt.object, tt.field), func(context.Context) (any, error) {
+				return tt.value, nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFieldMask_DefaultEntry(t *testing.T) {
+	mw := FieldMask{
+		"partner-a":    {"StormReport.comments", "StormReport.sourceOffice"},
+		DefaultMaskKey: {"StormReport.sourceOffice"},
+	}.Middleware()
+	resolve := func(apiKey, field string) any {
+		got, err := mw(fieldCtx(apiKey, "StormReport", field), func(context.Context) (any, error) {
+			return "visible", nil
+		})
+		require.NoError(t, err)
+		return got
+	}
+	assert.Equal(t, "", resolve("", "sourceOffice"))
+	assert.Equal(t, "visible", resolve("", "comments"), "the default entry replaces the union")
+	assert.Equal(t, "", resolve("partner-a", "comments"))
+}
+
+func TestFieldMask_RequestWithoutKeyCannotReadMaskedField(t *testing.T) {
+	mw := FieldMask{"partner-a": {"StormReport.comments"}}.Middleware()
+	apiKeys := WithAPIKey([]string{"partner-a", "internal"})
+
+	for _, header := range []string{"", "made-up", "partner-b"} {
+		t.Run("key="+header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/query", nil)
+			if header != "" {
+				req.Header.Set(APIKeyHeader, header)
+			}
+			var ctx context.Context
+			apiKeys(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) { ctx = r.Context() })).ServeHTTP(httptest.NewRecorder(), req)
+
+			ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{
+				Object: "StormReport",
+				Field:  graphql.CollectedField{Field: &ast.Field{Name: "comments"}},
+			})
+			got, err := mw(ctx, func(context.Context) (any, error) { return "hail damage at 123 Main St", nil })
+			require.NoError(t, err)
+			assert.Equal(t, "", got)
+		})
+	}
+}
+
+func TestWithAPIKey(t *testing.T) {
+	var got string
+	handler := WithAPIKey([]string{"partner-a"})(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = apiKeyFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/query", nil)
+	req.Header.Set(APIKeyHeader, "partner-a")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, "partner-a", got)
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/query", nil))
+	assert.Empty(t, got)
+
+	unknown := httptest.NewRequest(http.MethodPost, "/query", nil)
+	unknown.Header.Set(APIKeyHeader, "made-up")
+	handler.ServeHTTP(httptest.NewRecorder(), unknown)
+	assert.Empty(t, got, "unknown keys are treated as no key")
+}
+
+func TestFieldMask_CommentsDerivedValues(t *testing.T) {
+	mask := FieldMask{"partner-a": {"StormReport.comments"}, "partner-b": {"StormReport.sourceOffice"}}
+	filter := &model.StormReportFilter{ImpactKeywords: []string{"roof"}}
+
+	for _, key := range []string{"", "partner-a"} {
+		ctx := fieldCtx(key, "Query", "stormReports")
+		err := mask.checkMaskedFilters(ctx, filter)
+		require.Error(t, err, "key %q", key)
+		assert.Contains(t, err.Error(), "impactKeywords is not available")
+		var vErr *ValidationError
+		require.ErrorAs(t, err, &vErr)
+		assert.Equal(t, ruleImpactKeywords, vErr.Rule)
+	}
+	require.NoError(t, mask.checkMaskedFilters(fieldCtx("partner-b", "Query", "stormReports"), filter))
+	require.NoError(t, FieldMask(nil).checkMaskedFilters(fieldCtx("", "Query", "stormReports"), filter))
+
+	report := &model.StormReport{Comments: "Roof damage reported"}
+	resolve := func(key string) *string {
+		ctx := fieldCtx(key, "StormReport", "matchedImpactKeyword")
+		fc := graphql.GetFieldContext(ctx)
+		fc.Parent = &graphql.FieldContext{Args: map[string]any{"filter": *filter}}
+		got, err := (&stormReportResolver{&Resolver{FieldMask: mask}}).MatchedImpactKeyword(ctx, report)
+		require.NoError(t, err)
+		return got
+	}
+	assert.Nil(t, resolve("partner-a"), "masked comments must not leak through the match")
+	require.NotNil(t, resolve("partner-b"))
+	assert.Equal(t, "roof", *resolve("partner-b"))
+}
+
+func TestFieldMask_Redact(t *testing.T) {
+	m := FieldMask{
+		"*":         {"StormReport.comments", "StormReport.sourceOffice", "Location.county"},
+		"partner-a": nil,
+	}
+	newReport := func() *model.StormReport {
+		return &model.StormReport{ID: "a", Comments: "secret", SourceOffice: "FWD", Location: model.Location{State: "TX", County: "Dallas"}}
+	}
+
+	anon := newReport()
+	m.Redact(context.Background(), anon)
+	assert.Equal(t, "a", anon.ID)
+	assert.Empty(t, anon.Comments)
+	assert.Empty(t, anon.SourceOffice)
+	assert.Empty(t, anon.Location.County)
+	assert.Equal(t, "TX", anon.Location.State)
+
+	known := newReport()
+	m.Redact(context.WithValue(context.Background(), apiKeyContextKey{}, "partner-a"), known)
+	assert.Equal(t, newReport(), known)
+}
@@ -3,11 +3,12 @@ package graph
 import (
 	"context"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
-	"github.com/couchcryptid/storm-data-api/internal/store"
 )
 
 // collectFields returns the set of field names requested on StormReportsResult,
@@ -24,16 +25,108 @@ func collectFields(ctx context.Context) map[string]bool {
 	return fields
 }
 
-// applyMeta fetches and assigns lastUpdated and dataLagMinutes to the QueryMeta.
-func applyMeta(ctx context.Context, s *store.Store, meta *model.QueryMeta) error {
+// aggregationDimensions lists the StormAggregations breakdowns, each served by
+// its own UNION ALL branch of the aggregation query.
+var aggregationDimensions = []string{"byEventType", "byState", "byHour", "bySeverity", "byDayOfWeek", "byMagnitudeBucket"}
+
+// requestedDimensions returns the aggregation breakdowns selected in fields,
+// in aggregationDimensions order.
+func requestedDimensions(fields map[string]bool) []string {
+	var dims []string
+	for _, d := range aggregationDimensions {
+		if fields["aggregations."+d] {
+			dims = append(dims, d)
+		}
+	}
+	return dims
+}
+
+// requestedReportFields returns the StormReport fields selected under
+// reports, for ListStormReports to project its columns. When reports isn't
+// selected the query still runs for totalCount and hasMore, so only id is
+// asked for. matchedImpactKeyword is computed from comments, so it pulls in
+// that column.
+func requestedReportFields(fields map[string]bool) []string {
+	if !fields["reports"] {
+		return []string{"id"}
+	}
+	var names []string
+	for f := range fields {
+		if name, ok := strings.CutPrefix(f, "reports."); ok {
+			names = append(names, name)
+		}
+	}
+	if fields["reports.matchedImpactKeyword"] && !fields["reports.comments"] {
+		names = append(names, "comments")
+	}
+	return names
+}
+
+// impactKeywords returns the impactKeywords of the stormReports filter
+// enclosing the field being resolved, or nil.
+func impactKeywords(ctx context.Context) []string {
+	for fc := graphql.GetFieldContext(ctx); fc != nil; fc = fc.Parent {
+		if filter, ok := fc.Args["filter"].(model.StormReportFilter); ok {
+			return filter.ImpactKeywords
+		}
+	}
+	return nil
+}
+
+// matchImpactKeyword returns the first of keywords that comments contains,
+// ignoring case like the ILIKE filter, or nil if none does.
+func matchImpactKeyword(comments string, keywords []string) *string {
+	comments = strings.ToLower(comments)
+	for _, keyword := range keywords {
+		keyword = strings.TrimSpace(keyword)
+		if keyword != "" && strings.Contains(comments, strings.ToLower(keyword)) {
+			return &keyword
+		}
+	}
+	return nil
+}
+
+// needsAggregationQuery reports whether any per-group breakdown was requested.
+// aggregations.totalCount alone is served by the COUNT(*) that ListStormReports
+// already runs, so the UNION ALL CTE can be skipped entirely.
+func needsAggregationQuery(fields map[string]bool) bool {
+	return len(requestedDimensions(fields)) > 0
+}
+
+// reportDensity returns count per square mile of the near search circle, or
+// nil when filter has no single circle: near is unset, or a per-type
+// radiusMiles override gives some event types a different one.
+func reportDensity(filter *model.StormReportFilter, count int) *float64 {
+	if filter.Near == nil || filter.Near.RadiusMiles == nil || *filter.Near.RadiusMiles <= 0 {
+		return nil
+	}
+	for _, typeFilter := range filter.EventTypeFilters {
+		if typeFilter.RadiusMiles != nil && *typeFilter.RadiusMiles != *filter.Near.RadiusMiles {
+			return nil
+		}
+	}
+	r := *filter.Near.RadiusMiles
+	density := float64(count) / (math.Pi * r * r)
+	return &density
+}
+
+// applyMeta fetches and assigns lastUpdated and dataLagMinutes to the QueryMeta,
+// measuring the lag on clk.
+func applyMeta(ctx context.Context, s ReportStore, meta *model.QueryMeta, clk clock.Clock) error {
 	lastUpdated, err := s.LastUpdated(ctx)
 	if err != nil {
 		return err
 	}
+	setLastUpdated(meta, lastUpdated, clk)
+	return nil
+}
+
+// setLastUpdated assigns lastUpdated and, when there is data, the whole
+// minutes between it and clk's current time.
+func setLastUpdated(meta *model.QueryMeta, lastUpdated *time.Time, clk clock.Clock) {
 	meta.LastUpdated = lastUpdated
 	if lastUpdated != nil {
-		lag := int(math.Round(time.Since(*lastUpdated).Minutes()))
+		lag := int(math.Round(clk.Now().Sub(*lastUpdated).Minutes()))
 		meta.DataLagMinutes = &lag
 	}
-	return nil
 }
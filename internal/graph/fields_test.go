@@ -0,0 +1,125 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/clock"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestedDimensions(t *testing.T) {
+	fields := map[string]bool{
+		"aggregations":                   true,
+		"aggregations.totalCount":        true,
+		"aggregations.byHourGranularity": true,
+		"aggregations.byDayOfWeek":       true,
+		"aggregations.byState":           true,
+	}
+	assert.Equal(t, []string{"byState", "byDayOfWeek"}, requestedDimensions(fields))
+	assert.Empty(t, requestedDimensions(map[string]bool{"reports": true}))
+}
+
+func TestNeedsAggregationQuery(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields map[string]bool
+		want   bool
+	}{
+		{"no aggregations", map[string]bool{"reports": true}, false},
+		{"totalCount only", map[string]bool{"aggregations": true, "aggregations.totalCount": true}, false},
+		{"byEventType", map[string]bool{"aggregations": true, "aggregations.byEventType": true}, true},
+		{"byState", map[string]bool{"aggregations": true, "aggregations.byState": true}, true},
+		{"bySeverity", map[string]bool{"aggregations": true, "aggregations.bySeverity": true}, true},
+		{"byDayOfWeek", map[string]bool{"aggregations": true, "aggregations.byDayOfWeek": true}, true},
+		{"byMagnitudeBucket", map[string]bool{"aggregations": true, "aggregations.byMagnitudeBucket": true}, true},
+		{"byHourGranularity only", map[string]bool{"aggregations": true, "aggregations.byHourGranularity": true}, false},
+		{"byHour with totalCount", map[string]bool{"aggregations": true, "aggregations.totalCount": true, "aggregations.byHour": true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, needsAggregationQuery(tt.fields))
+		})
+	}
+}
+
+func TestRequestedReportFields(t *testing.T) {
+	fields := map[string]bool{
+		"totalCount":        true,
+		"reports":           true,
+		"reports.id":        true,
+		"reports.geo":       true,
+		"reports.eventTime": true,
+		"meta":              true,
+	}
+	assert.ElementsMatch(t, []string{"id", "geo", "eventTime"}, requestedReportFields(fields))
+
+	// Count-only queries still run the list query, but need no report data.
+	assert.Equal(t, []string{"id"}, requestedReportFields(map[string]bool{"totalCount": true}))
+
+	// matchedImpactKeyword is computed from comments.
+	fields = map[string]bool{"reports": true, "reports.id": true, "reports.matchedImpactKeyword": true}
+	assert.ElementsMatch(t, []string{"id", "matchedImpactKeyword", "comments"}, requestedReportFields(fields))
+}
+
+func TestMatchImpactKeyword(t *testing.T) {
+	keywords := []string{"roof damage", " Trees Down "}
+	assert.Equal(t, "roof damage", *matchImpactKeyword("Minor ROOF DAMAGE to a barn. Trees down.", keywords), "first keyword wins")
+	assert.Equal(t, "Trees Down", *matchImpactKeyword("several trees down on Hwy 9", keywords))
+	assert.Nil(t, matchImpactKeyword("1.75 inch hail", keywords))
+	assert.Nil(t, matchImpactKeyword("trees down", nil))
+}
+
+func TestImpactKeywords_FromEnclosingFilter(t *testing.T) {
+	assert.Nil(t, impactKeywords(context.Background()))
+
+	ctx := graphql.WithFieldContext(context.Background(), &graphql.FieldContext{
+		Args: map[string]any{"filter": model.StormReportFilter{ImpactKeywords: []string{"trees down"}}},
+	})
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{})
+	ctx = graphql.WithFieldContext(ctx, &graphql.FieldContext{})
+	assert.Equal(t, []string{"trees down"}, impactKeywords(ctx))
+}
+
+func TestReportDensity(t *testing.T) {
+	radius := 10.0
+	filter := &model.StormReportFilter{Near: &model.GeoRadiusFilter{Lat: 35.2, Lon: -97.4, RadiusMiles: &radius}}
+
+	density := reportDensity(filter, 157)
+	require.NotNil(t, density)
+	assert.InDelta(t, 0.5, *density, 0.001, "157 reports over ~314 sq mi")
+
+	// An override matching near.radiusMiles still describes one circle.
+	filter.EventTypeFilters = []*model.EventTypeFilter{{EventType: model.EventTypeHail, RadiusMiles: &radius}}
+	assert.NotNil(t, reportDensity(filter, 157))
+
+	wider := 50.0
+	filter.EventTypeFilters = []*model.EventTypeFilter{{EventType: model.EventTypeTornado, RadiusMiles: &wider}}
+	assert.Nil(t, reportDensity(filter, 157), "mixed radii")
+
+	assert.Nil(t, reportDensity(&model.StormReportFilter{}, 157), "no near")
+}
+
+func TestSetLastUpdated(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 4, 27, 12, 0, 0, 0, time.UTC))
+	lastUpdated := clk.Now().Add(-90 * time.Second)
+
+	meta := &model.QueryMeta{}
+	setLastUpdated(meta, &lastUpdated, clk)
+	assert.Equal(t, &lastUpdated, meta.LastUpdated)
+	require.NotNil(t, meta.DataLagMinutes)
+	assert.Equal(t, 2, *meta.DataLagMinutes, "90s rounds to 2 minutes")
+
+	clk.Advance(time.Hour)
+	setLastUpdated(meta, &lastUpdated, clk)
+	assert.Equal(t, 62, *meta.DataLagMinutes)
+
+	meta = &model.QueryMeta{}
+	setLastUpdated(meta, nil, clk)
+	assert.Nil(t, meta.LastUpdated)
+	assert.Nil(t, meta.DataLagMinutes, "no data, no lag")
+}
@@ -40,6 +40,9 @@ type Config struct {
 }
 
 type ResolverRoot interface {
+	Geo() GeoResolver
+	Location() LocationResolver
+	Measurement() MeasurementResolver
 	Query() QueryResolver
 	StormReport() StormReportResolver
 }
@@ -49,8 +52,20 @@ type DirectiveRoot struct {
 
 type ComplexityRoot struct {
 	CountyGroup struct {
-		Count  func(childComplexity int) int
-		County func(childComplexity int) int
+		Count        func(childComplexity int) int
+		County       func(childComplexity int) int
+		NearestMiles func(childComplexity int) int
+	}
+
+	DataTimeExtent struct {
+		Earliest func(childComplexity int) int
+		Latest   func(childComplexity int) int
+	}
+
+	DayOfWeekGroup struct {
+		Count     func(childComplexity int) int
+		DayOfWeek func(childComplexity int) int
+		Name      func(childComplexity int) int
 	}
 
 	EventTypeGroup struct {
@@ -64,13 +79,34 @@ type ComplexityRoot struct {
 		Lon func(childComplexity int) int
 	}
 
+	GeoBounds struct {
+		MaxLat func(childComplexity int) int
+		MaxLon func(childComplexity int) int
+		MinLat func(childComplexity int) int
+		MinLon func(childComplexity int) int
+	}
+
+	IngestionGap struct {
+		From    func(childComplexity int) int
+		Minutes func(childComplexity int) int
+		To      func(childComplexity int) int
+	}
+
 	Location struct {
-		County    func(childComplexity int) int
-		Direction func(childComplexity int) int
-		Distance  func(childComplexity int) int
-		Name      func(childComplexity int) int
-		Raw       func(childComplexity int) int
-		State     func(childComplexity int) int
+		County         func(childComplexity int) int
+		Direction      func(childComplexity int) int
+		Distance       func(childComplexity int) int
+		Name           func(childComplexity int) int
+		ParsedLocation func(childComplexity int) int
+		Raw            func(childComplexity int) int
+		State          func(childComplexity int) int
+	}
+
+	MagnitudeBucketGroup struct {
+		Count     func(childComplexity int) int
+		EventType func(childComplexity int) int
+		Max       func(childComplexity int) int
+		Min       func(childComplexity int) int
 	}
 
 	Measurement struct {
@@ -79,39 +115,68 @@ type ComplexityRoot struct {
 		Unit      func(childComplexity int) int
 	}
 
+	ParsedLocation struct {
+		Direction func(childComplexity int) int
+		Distance  func(childComplexity int) int
+		Name      func(childComplexity int) int
+	}
+
 	Query struct {
-		StormReports func(childComplexity int, filter model.StormReportFilter) int
+		DataTimeExtent     func(childComplexity int) int
+		DistinctEventTypes func(childComplexity int, timeRange model.TimeRange) int
+		IngestionGap       func(childComplexity int, withinHours *int) int
+		StormReport        func(childComplexity int, id string) int
+		StormReports       func(childComplexity int, filter model.StormReportFilter) int
+		StormReportsBounds func(childComplexity int, filter model.StormReportFilter) int
 	}
 
 	QueryMeta struct {
-		DataLagMinutes func(childComplexity int) int
-		LastUpdated    func(childComplexity int) int
+		AggregationsTimedOut func(childComplexity int) int
+		Centroid             func(childComplexity int) int
+		DataLagMinutes       func(childComplexity int) int
+		Density              func(childComplexity int) int
+		LastUpdated          func(childComplexity int) int
 	}
 
-	StateGroup struct {
+	SeverityGroup struct {
 		Count    func(childComplexity int) int
-		Counties func(childComplexity int) int
-		State    func(childComplexity int) int
+		Severity func(childComplexity int) int
+	}
+
+	StateGroup struct {
+		Count        func(childComplexity int) int
+		Counties     func(childComplexity int) int
+		NearestMiles func(childComplexity int) int
+		State        func(childComplexity int) int
 	}
 
 	StormAggregations struct {
-		ByEventType func(childComplexity int) int
-		ByHour      func(childComplexity int) int
-		ByState     func(childComplexity int) int
-		TotalCount  func(childComplexity int) int
+		ByDayOfWeek       func(childComplexity int) int
+		ByEventType       func(childComplexity int) int
+		ByHour            func(childComplexity int) int
+		ByHourGranularity func(childComplexity int) int
+		ByMagnitudeBucket func(childComplexity int) int
+		BySeverity        func(childComplexity int) int
+		ByState           func(childComplexity int) int
+		SampleFraction    func(childComplexity int) int
+		Sampled           func(childComplexity int) int
+		TotalCount        func(childComplexity int) int
+		Truncated         func(childComplexity int) int
 	}
 
 	StormReport struct {
-		Comments     func(childComplexity int) int
-		EventTime    func(childComplexity int) int
-		EventType    func(childComplexity int) int
-		Geo          func(childComplexity int) int
-		ID           func(childComplexity int) int
-		Location     func(childComplexity int) int
-		Measurement  func(childComplexity int) int
-		ProcessedAt  func(childComplexity int) int
-		SourceOffice func(childComplexity int) int
-		TimeBucket   func(childComplexity int) int
+		Comments             func(childComplexity int) int
+		EventTime            func(childComplexity int) int
+		EventType            func(childComplexity int) int
+		Geo                  func(childComplexity int) int
+		ID                   func(childComplexity int) int
+		Location             func(childComplexity int) int
+		MatchedImpactKeyword func(childComplexity int) int
+		Measurement          func(childComplexity int) int
+		Pipeline             func(childComplexity int) int
+		ProcessedAt          func(childComplexity int) int
+		SourceOffice         func(childComplexity int) int
+		TimeBucket           func(childComplexity int) int
 	}
 
 	StormReportsResult struct {
@@ -128,11 +193,28 @@ type ComplexityRoot struct {
 	}
 }
 
+type GeoResolver interface {
+	Lat(ctx context.Context, obj *model.Geo) (float64, error)
+	Lon(ctx context.Context, obj *model.Geo) (float64, error)
+}
+type LocationResolver interface {
+	ParsedLocation(ctx context.Context, obj *model.Location) (*model.ParsedLocation, error)
+}
+type MeasurementResolver interface {
+	Magnitude(ctx context.Context, obj *model.Measurement) (float64, error)
+}
 type QueryResolver interface {
 	StormReports(ctx context.Context, filter model.StormReportFilter) (*model.StormReportsResult, error)
+	StormReport(ctx context.Context, id string) (*model.StormReport, error)
+	StormReportsBounds(ctx context.Context, filter model.StormReportFilter) (*model.GeoBounds, error)
+	DataTimeExtent(ctx context.Context) (*model.DataTimeExtent, error)
+	DistinctEventTypes(ctx context.Context, timeRange model.TimeRange) ([]model.EventType, error)
+	IngestionGap(ctx context.Context, withinHours *int) (*model.IngestionGap, error)
 }
 type StormReportResolver interface {
 	EventType(ctx context.Context, obj *model.StormReport) (string, error)
+
+	MatchedImpactKeyword(ctx context.Context, obj *model.StormReport) (*string, error)
 }
 
 type executableSchema struct {
@@ -166,6 +248,44 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.CountyGroup.County(childComplexity), true
+	case "CountyGroup.nearestMiles":
+		if e.complexity.CountyGroup.NearestMiles == nil {
+			break
+		}
+
+		return e.complexity.CountyGroup.NearestMiles(childComplexity), true
+
+	case "DataTimeExtent.earliest":
+		if e.complexity.DataTimeExtent.Earliest == nil {
+			break
+		}
+
+		return e.complexity.DataTimeExtent.Earliest(childComplexity), true
+	case "DataTimeExtent.latest":
+		if e.complexity.DataTimeExtent.Latest == nil {
+			break
+		}
+
+		return e.complexity.DataTimeExtent.Latest(childComplexity), true
+
+	case "DayOfWeekGroup.count":
+		if e.complexity.DayOfWeekGroup.Count == nil {
+			break
+		}
+
+		return e.complexity.DayOfWeekGroup.Count(childComplexity), true
+	case "DayOfWeekGroup.dayOfWeek":
+		if e.complexity.DayOfWeekGroup.DayOfWeek == nil {
+			break
+		}
+
+		return e.complexity.DayOfWeekGroup.DayOfWeek(childComplexity), true
+	case "DayOfWeekGroup.name":
+		if e.complexity.DayOfWeekGroup.Name == nil {
+			break
+		}
+
+		return e.complexity.DayOfWeekGroup.Name(childComplexity), true
 
 	case "EventTypeGroup.count":
 		if e.complexity.EventTypeGroup.Count == nil {
@@ -199,6 +319,50 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Geo.Lon(childComplexity), true
 
+	case "GeoBounds.maxLat":
+		if e.complexity.GeoBounds.MaxLat == nil {
+			break
+		}
+
+		return e.complexity.GeoBounds.MaxLat(childComplexity), true
+	case "GeoBounds.maxLon":
+		if e.complexity.GeoBounds.MaxLon == nil {
+			break
+		}
+
+		return e.complexity.GeoBounds.MaxLon(childComplexity), true
+	case "GeoBounds.minLat":
+		if e.complexity.GeoBounds.MinLat == nil {
+			break
+		}
+
+		return e.complexity.GeoBounds.MinLat(childComplexity), true
+	case "GeoBounds.minLon":
+		if e.complexity.GeoBounds.MinLon == nil {
+			break
+		}
+
+		return e.complexity.GeoBounds.MinLon(childComplexity), true
+
+	case "IngestionGap.from":
+		if e.complexity.IngestionGap.From == nil {
+			break
+		}
+
+		return e.complexity.IngestionGap.From(childComplexity), true
+	case "IngestionGap.minutes":
+		if e.complexity.IngestionGap.Minutes == nil {
+			break
+		}
+
+		return e.complexity.IngestionGap.Minutes(childComplexity), true
+	case "IngestionGap.to":
+		if e.complexity.IngestionGap.To == nil {
+			break
+		}
+
+		return e.complexity.IngestionGap.To(childComplexity), true
+
 	case "Location.county":
 		if e.complexity.Location.County == nil {
 			break
@@ -223,6 +387,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Location.Name(childComplexity), true
+	case "Location.parsedLocation":
+		if e.complexity.Location.ParsedLocation == nil {
+			break
+		}
+
+		return e.complexity.Location.ParsedLocation(childComplexity), true
 	case "Location.raw":
 		if e.complexity.Location.Raw == nil {
 			break
@@ -236,6 +406,31 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Location.State(childComplexity), true
 
+	case "MagnitudeBucketGroup.count":
+		if e.complexity.MagnitudeBucketGroup.Count == nil {
+			break
+		}
+
+		return e.complexity.MagnitudeBucketGroup.Count(childComplexity), true
+	case "MagnitudeBucketGroup.eventType":
+		if e.complexity.MagnitudeBucketGroup.EventType == nil {
+			break
+		}
+
+		return e.complexity.MagnitudeBucketGroup.EventType(childComplexity), true
+	case "MagnitudeBucketGroup.max":
+		if e.complexity.MagnitudeBucketGroup.Max == nil {
+			break
+		}
+
+		return e.complexity.MagnitudeBucketGroup.Max(childComplexity), true
+	case "MagnitudeBucketGroup.min":
+		if e.complexity.MagnitudeBucketGroup.Min == nil {
+			break
+		}
+
+		return e.complexity.MagnitudeBucketGroup.Min(childComplexity), true
+
 	case "Measurement.magnitude":
 		if e.complexity.Measurement.Magnitude == nil {
 			break
@@ -255,6 +450,64 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Measurement.Unit(childComplexity), true
 
+	case "ParsedLocation.direction":
+		if e.complexity.ParsedLocation.Direction == nil {
+			break
+		}
+
+		return e.complexity.ParsedLocation.Direction(childComplexity), true
+	case "ParsedLocation.distance":
+		if e.complexity.ParsedLocation.Distance == nil {
+			break
+		}
+
+		return e.complexity.ParsedLocation.Distance(childComplexity), true
+	case "ParsedLocation.name":
+		if e.complexity.ParsedLocation.Name == nil {
+			break
+		}
+
+		return e.complexity.ParsedLocation.Name(childComplexity), true
+
+	case "Query.dataTimeExtent":
+		if e.complexity.Query.DataTimeExtent == nil {
+			break
+		}
+
+		return e.complexity.Query.DataTimeExtent(childComplexity), true
+	case "Query.distinctEventTypes":
+		if e.complexity.Query.DistinctEventTypes == nil {
+			break
+		}
+
+		args, err := ec.field_Query_distinctEventTypes_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DistinctEventTypes(childComplexity, args["timeRange"].(model.TimeRange)), true
+	case "Query.ingestionGap":
+		if e.complexity.Query.IngestionGap == nil {
+			break
+		}
+
+		args, err := ec.field_Query_ingestionGap_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.IngestionGap(childComplexity, args["withinHours"].(*int)), true
+	case "Query.stormReport":
+		if e.complexity.Query.StormReport == nil {
+			break
+		}
+
+		args, err := ec.field_Query_stormReport_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.StormReport(childComplexity, args["id"].(string)), true
 	case "Query.stormReports":
 		if e.complexity.Query.StormReports == nil {
 			break
@@ -266,13 +519,42 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Query.StormReports(childComplexity, args["filter"].(model.StormReportFilter)), true
+	case "Query.stormReportsBounds":
+		if e.complexity.Query.StormReportsBounds == nil {
+			break
+		}
+
+		args, err := ec.field_Query_stormReportsBounds_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.StormReportsBounds(childComplexity, args["filter"].(model.StormReportFilter)), true
 
+	case "QueryMeta.aggregationsTimedOut":
+		if e.complexity.QueryMeta.AggregationsTimedOut == nil {
+			break
+		}
+
+		return e.complexity.QueryMeta.AggregationsTimedOut(childComplexity), true
+	case "QueryMeta.centroid":
+		if e.complexity.QueryMeta.Centroid == nil {
+			break
+		}
+
+		return e.complexity.QueryMeta.Centroid(childComplexity), true
 	case "QueryMeta.dataLagMinutes":
 		if e.complexity.QueryMeta.DataLagMinutes == nil {
 			break
 		}
 
 		return e.complexity.QueryMeta.DataLagMinutes(childComplexity), true
+	case "QueryMeta.density":
+		if e.complexity.QueryMeta.Density == nil {
+			break
+		}
+
+		return e.complexity.QueryMeta.Density(childComplexity), true
 	case "QueryMeta.lastUpdated":
 		if e.complexity.QueryMeta.LastUpdated == nil {
 			break
@@ -280,6 +562,19 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.QueryMeta.LastUpdated(childComplexity), true
 
+	case "SeverityGroup.count":
+		if e.complexity.SeverityGroup.Count == nil {
+			break
+		}
+
+		return e.complexity.SeverityGroup.Count(childComplexity), true
+	case "SeverityGroup.severity":
+		if e.complexity.SeverityGroup.Severity == nil {
+			break
+		}
+
+		return e.complexity.SeverityGroup.Severity(childComplexity), true
+
 	case "StateGroup.count":
 		if e.complexity.StateGroup.Count == nil {
 			break
@@ -292,6 +587,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.StateGroup.Counties(childComplexity), true
+	case "StateGroup.nearestMiles":
+		if e.complexity.StateGroup.NearestMiles == nil {
+			break
+		}
+
+		return e.complexity.StateGroup.NearestMiles(childComplexity), true
 	case "StateGroup.state":
 		if e.complexity.StateGroup.State == nil {
 			break
@@ -299,6 +600,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.StateGroup.State(childComplexity), true
 
+	case "StormAggregations.byDayOfWeek":
+		if e.complexity.StormAggregations.ByDayOfWeek == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.ByDayOfWeek(childComplexity), true
 	case "StormAggregations.byEventType":
 		if e.complexity.StormAggregations.ByEventType == nil {
 			break
@@ -311,18 +618,54 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.StormAggregations.ByHour(childComplexity), true
+	case "StormAggregations.byHourGranularity":
+		if e.complexity.StormAggregations.ByHourGranularity == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.ByHourGranularity(childComplexity), true
+	case "StormAggregations.byMagnitudeBucket":
+		if e.complexity.StormAggregations.ByMagnitudeBucket == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.ByMagnitudeBucket(childComplexity), true
+	case "StormAggregations.bySeverity":
+		if e.complexity.StormAggregations.BySeverity == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.BySeverity(childComplexity), true
 	case "StormAggregations.byState":
 		if e.complexity.StormAggregations.ByState == nil {
 			break
 		}
 
 		return e.complexity.StormAggregations.ByState(childComplexity), true
+	case "StormAggregations.sampleFraction":
+		if e.complexity.StormAggregations.SampleFraction == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.SampleFraction(childComplexity), true
+	case "StormAggregations.sampled":
+		if e.complexity.StormAggregations.Sampled == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.Sampled(childComplexity), true
 	case "StormAggregations.totalCount":
 		if e.complexity.StormAggregations.TotalCount == nil {
 			break
 		}
 
 		return e.complexity.StormAggregations.TotalCount(childComplexity), true
+	case "StormAggregations.truncated":
+		if e.complexity.StormAggregations.Truncated == nil {
+			break
+		}
+
+		return e.complexity.StormAggregations.Truncated(childComplexity), true
 
 	case "StormReport.comments":
 		if e.complexity.StormReport.Comments == nil {
@@ -360,12 +703,24 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.StormReport.Location(childComplexity), true
+	case "StormReport.matchedImpactKeyword":
+		if e.complexity.StormReport.MatchedImpactKeyword == nil {
+			break
+		}
+
+		return e.complexity.StormReport.MatchedImpactKeyword(childComplexity), true
 	case "StormReport.measurement":
 		if e.complexity.StormReport.Measurement == nil {
 			break
 		}
 
 		return e.complexity.StormReport.Measurement(childComplexity), true
+	case "StormReport.pipeline":
+		if e.complexity.StormReport.Pipeline == nil {
+			break
+		}
+
+		return e.complexity.StormReport.Pipeline(childComplexity), true
 	case "StormReport.processedAt":
 		if e.complexity.StormReport.ProcessedAt == nil {
 			break
@@ -439,6 +794,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 	inputUnmarshalMap := graphql.BuildUnmarshalerMap(
 		ec.unmarshalInputEventTypeFilter,
 		ec.unmarshalInputGeoRadiusFilter,
+		ec.unmarshalInputHourOfDayRange,
 		ec.unmarshalInputStormReportFilter,
 		ec.unmarshalInputTimeRange,
 	)
@@ -553,6 +909,50 @@ func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
+func (ec *executionContext) field_Query_distinctEventTypes_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "timeRange", ec.unmarshalNTimeRange2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeRange)
+	if err != nil {
+		return nil, err
+	}
+	args["timeRange"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_ingestionGap_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "withinHours", ec.unmarshalOInt2ᚖint)
+	if err != nil {
+		return nil, err
+	}
+	args["withinHours"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_stormReport_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "id", ec.unmarshalNID2string)
+	if err != nil {
+		return nil, err
+	}
+	args["id"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_stormReportsBounds_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "filter", ec.unmarshalNStormReportFilter2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReportFilter)
+	if err != nil {
+		return nil, err
+	}
+	args["filter"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Query_stormReports_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -674,196 +1074,188 @@ func (ec *executionContext) fieldContext_CountyGroup_count(_ context.Context, fi
 	return fc, nil
 }
 
-func (ec *executionContext) _EventTypeGroup_eventType(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _CountyGroup_nearestMiles(ctx context.Context, field graphql.CollectedField, obj *model.CountyGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_EventTypeGroup_eventType,
+		ec.fieldContext_CountyGroup_nearestMiles,
 		func(ctx context.Context) (any, error) {
-			return obj.EventType, nil
+			return obj.NearestMiles, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_EventTypeGroup_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_CountyGroup_nearestMiles(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EventTypeGroup",
+		Object:     "CountyGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EventTypeGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _DataTimeExtent_earliest(ctx context.Context, field graphql.CollectedField, obj *model.DataTimeExtent) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_EventTypeGroup_count,
+		ec.fieldContext_DataTimeExtent_earliest,
 		func(ctx context.Context) (any, error) {
-			return obj.Count, nil
+			return obj.Earliest, nil
 		},
 		nil,
-		ec.marshalNInt2int,
+		ec.marshalNDateTime2timeᚐTime,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_EventTypeGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataTimeExtent_earliest(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EventTypeGroup",
+		Object:     "DataTimeExtent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _EventTypeGroup_maxMeasurement(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _DataTimeExtent_latest(ctx context.Context, field graphql.CollectedField, obj *model.DataTimeExtent) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_EventTypeGroup_maxMeasurement,
+		ec.fieldContext_DataTimeExtent_latest,
 		func(ctx context.Context) (any, error) {
-			return obj.MaxMeasurement, nil
+			return obj.Latest, nil
 		},
 		nil,
-		ec.marshalOMeasurement2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMeasurement,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_EventTypeGroup_maxMeasurement(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DataTimeExtent_latest(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "EventTypeGroup",
+		Object:     "DataTimeExtent",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "magnitude":
-				return ec.fieldContext_Measurement_magnitude(ctx, field)
-			case "unit":
-				return ec.fieldContext_Measurement_unit(ctx, field)
-			case "severity":
-				return ec.fieldContext_Measurement_severity(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Measurement", field.Name)
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Geo_lat(ctx context.Context, field graphql.CollectedField, obj *model.Geo) (ret graphql.Marshaler) {
+func (ec *executionContext) _DayOfWeekGroup_dayOfWeek(ctx context.Context, field graphql.CollectedField, obj *model.DayOfWeekGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Geo_lat,
+		ec.fieldContext_DayOfWeekGroup_dayOfWeek,
 		func(ctx context.Context) (any, error) {
-			return obj.Lat, nil
+			return obj.DayOfWeek, nil
 		},
 		nil,
-		ec.marshalNFloat2float64,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Geo_lat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DayOfWeekGroup_dayOfWeek(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Geo",
+		Object:     "DayOfWeekGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Geo_lon(ctx context.Context, field graphql.CollectedField, obj *model.Geo) (ret graphql.Marshaler) {
+func (ec *executionContext) _DayOfWeekGroup_name(ctx context.Context, field graphql.CollectedField, obj *model.DayOfWeekGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Geo_lon,
+		ec.fieldContext_DayOfWeekGroup_name,
 		func(ctx context.Context) (any, error) {
-			return obj.Lon, nil
+			return obj.Name, nil
 		},
 		nil,
-		ec.marshalNFloat2float64,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Geo_lon(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DayOfWeekGroup_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Geo",
+		Object:     "DayOfWeekGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_raw(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _DayOfWeekGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.DayOfWeekGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_raw,
+		ec.fieldContext_DayOfWeekGroup_count,
 		func(ctx context.Context) (any, error) {
-			return obj.Raw, nil
+			return obj.Count, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_raw(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DayOfWeekGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "DayOfWeekGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_name(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _EventTypeGroup_eventType(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_name,
+		ec.fieldContext_EventTypeGroup_eventType,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			return obj.EventType, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -872,9 +1264,9 @@ func (ec *executionContext) _Location_name(ctx context.Context, field graphql.Co
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EventTypeGroup_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "EventTypeGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -885,130 +1277,138 @@ func (ec *executionContext) fieldContext_Location_name(_ context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_distance(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _EventTypeGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_distance,
+		ec.fieldContext_EventTypeGroup_count,
 		func(ctx context.Context) (any, error) {
-			return obj.Distance, nil
+			return obj.Count, nil
 		},
 		nil,
-		ec.marshalOFloat2ᚖfloat64,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_distance(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EventTypeGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "EventTypeGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_direction(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _EventTypeGroup_maxMeasurement(ctx context.Context, field graphql.CollectedField, obj *model.EventTypeGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_direction,
+		ec.fieldContext_EventTypeGroup_maxMeasurement,
 		func(ctx context.Context) (any, error) {
-			return obj.Direction, nil
+			return obj.MaxMeasurement, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalOMeasurement2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMeasurement,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_direction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_EventTypeGroup_maxMeasurement(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "EventTypeGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "magnitude":
+				return ec.fieldContext_Measurement_magnitude(ctx, field)
+			case "unit":
+				return ec.fieldContext_Measurement_unit(ctx, field)
+			case "severity":
+				return ec.fieldContext_Measurement_severity(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Measurement", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_state(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _Geo_lat(ctx context.Context, field graphql.CollectedField, obj *model.Geo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_state,
+		ec.fieldContext_Geo_lat,
 		func(ctx context.Context) (any, error) {
-			return obj.State, nil
+			return ec.resolvers.Geo().Lat(ctx, obj)
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNFloat2float64,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_state(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Geo_lat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "Geo",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Location_county(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
+func (ec *executionContext) _Geo_lon(ctx context.Context, field graphql.CollectedField, obj *model.Geo) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Location_county,
+		ec.fieldContext_Geo_lon,
 		func(ctx context.Context) (any, error) {
-			return obj.County, nil
+			return ec.resolvers.Geo().Lon(ctx, obj)
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNFloat2float64,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Location_county(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Geo_lon(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Location",
+		Object:     "Geo",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Measurement_magnitude(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
+func (ec *executionContext) _GeoBounds_minLat(ctx context.Context, field graphql.CollectedField, obj *model.GeoBounds) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Measurement_magnitude,
+		ec.fieldContext_GeoBounds_minLat,
 		func(ctx context.Context) (any, error) {
-			return obj.Magnitude, nil
+			return obj.MinLat, nil
 		},
 		nil,
 		ec.marshalNFloat2float64,
@@ -1017,9 +1417,9 @@ func (ec *executionContext) _Measurement_magnitude(ctx context.Context, field gr
 	)
 }
 
-func (ec *executionContext) fieldContext_Measurement_magnitude(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GeoBounds_minLat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Measurement",
+		Object:     "GeoBounds",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1030,291 +1430,217 @@ func (ec *executionContext) fieldContext_Measurement_magnitude(_ context.Context
 	return fc, nil
 }
 
-func (ec *executionContext) _Measurement_unit(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
+func (ec *executionContext) _GeoBounds_maxLat(ctx context.Context, field graphql.CollectedField, obj *model.GeoBounds) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Measurement_unit,
+		ec.fieldContext_GeoBounds_maxLat,
 		func(ctx context.Context) (any, error) {
-			return obj.Unit, nil
+			return obj.MaxLat, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNFloat2float64,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Measurement_unit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GeoBounds_maxLat(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Measurement",
+		Object:     "GeoBounds",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Measurement_severity(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
+func (ec *executionContext) _GeoBounds_minLon(ctx context.Context, field graphql.CollectedField, obj *model.GeoBounds) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Measurement_severity,
+		ec.fieldContext_GeoBounds_minLon,
 		func(ctx context.Context) (any, error) {
-			return obj.Severity, nil
+			return obj.MinLon, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNFloat2float64,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Measurement_severity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GeoBounds_minLon(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Measurement",
+		Object:     "GeoBounds",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_stormReports(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _GeoBounds_maxLon(ctx context.Context, field graphql.CollectedField, obj *model.GeoBounds) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query_stormReports,
+		ec.fieldContext_GeoBounds_maxLon,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Query().StormReports(ctx, fc.Args["filter"].(model.StormReportFilter))
+			return obj.MaxLon, nil
 		},
 		nil,
-		ec.marshalNStormReportsResult2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReportsResult,
+		ec.marshalNFloat2float64,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query_stormReports(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GeoBounds_maxLon(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "GeoBounds",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "totalCount":
-				return ec.fieldContext_StormReportsResult_totalCount(ctx, field)
-			case "hasMore":
-				return ec.fieldContext_StormReportsResult_hasMore(ctx, field)
-			case "reports":
-				return ec.fieldContext_StormReportsResult_reports(ctx, field)
-			case "aggregations":
-				return ec.fieldContext_StormReportsResult_aggregations(ctx, field)
-			case "meta":
-				return ec.fieldContext_StormReportsResult_meta(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type StormReportsResult", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_stormReports_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _IngestionGap_from(ctx context.Context, field graphql.CollectedField, obj *model.IngestionGap) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___type,
+		ec.fieldContext_IngestionGap_from,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.introspectType(fc.Args["name"].(string))
+			return obj.From, nil
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_IngestionGap_from(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "IngestionGap",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _IngestionGap_to(ctx context.Context, field graphql.CollectedField, obj *model.IngestionGap) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Query___schema,
+		ec.fieldContext_IngestionGap_to,
 		func(ctx context.Context) (any, error) {
-			return ec.introspectSchema()
+			return obj.To, nil
 		},
 		nil,
-		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_IngestionGap_to(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "IngestionGap",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _QueryMeta_lastUpdated(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
+func (ec *executionContext) _IngestionGap_minutes(ctx context.Context, field graphql.CollectedField, obj *model.IngestionGap) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_QueryMeta_lastUpdated,
+		ec.fieldContext_IngestionGap_minutes,
 		func(ctx context.Context) (any, error) {
-			return obj.LastUpdated, nil
+			return obj.Minutes, nil
 		},
 		nil,
-		ec.marshalODateTime2ᚖtimeᚐTime,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_QueryMeta_lastUpdated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_IngestionGap_minutes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "QueryMeta",
+		Object:     "IngestionGap",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _QueryMeta_dataLagMinutes(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_raw(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_QueryMeta_dataLagMinutes,
+		ec.fieldContext_Location_raw,
 		func(ctx context.Context) (any, error) {
-			return obj.DataLagMinutes, nil
+			return obj.Raw, nil
 		},
 		nil,
-		ec.marshalOInt2ᚖint,
+		ec.marshalNString2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_QueryMeta_dataLagMinutes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_raw(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "QueryMeta",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StateGroup_state(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_name(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StateGroup_state,
+		ec.fieldContext_Location_name,
 		func(ctx context.Context) (any, error) {
-			return obj.State, nil
+			return obj.Name, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -1323,9 +1649,9 @@ func (ec *executionContext) _StateGroup_state(ctx context.Context, field graphql
 	)
 }
 
-func (ec *executionContext) fieldContext_StateGroup_state(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StateGroup",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1336,386 +1662,352 @@ func (ec *executionContext) fieldContext_StateGroup_state(_ context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _StateGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_distance(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StateGroup_count,
+		ec.fieldContext_Location_distance,
 		func(ctx context.Context) (any, error) {
-			return obj.Count, nil
+			return obj.Distance, nil
 		},
 		nil,
-		ec.marshalNInt2int,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StateGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_distance(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StateGroup",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StateGroup_counties(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_direction(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StateGroup_counties,
+		ec.fieldContext_Location_direction,
 		func(ctx context.Context) (any, error) {
-			return obj.Counties, nil
+			return obj.Direction, nil
 		},
 		nil,
-		ec.marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroupᚄ,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StateGroup_counties(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_direction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StateGroup",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "county":
-				return ec.fieldContext_CountyGroup_county(ctx, field)
-			case "count":
-				return ec.fieldContext_CountyGroup_count(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type CountyGroup", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormAggregations_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_state(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormAggregations_totalCount,
+		ec.fieldContext_Location_state,
 		func(ctx context.Context) (any, error) {
-			return obj.TotalCount, nil
+			return obj.State, nil
 		},
 		nil,
-		ec.marshalNInt2int,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormAggregations_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_state(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormAggregations",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormAggregations_byEventType(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_county(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormAggregations_byEventType,
+		ec.fieldContext_Location_county,
 		func(ctx context.Context) (any, error) {
-			return obj.ByEventType, nil
+			return obj.County, nil
 		},
 		nil,
-		ec.marshalNEventTypeGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeGroupᚄ,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormAggregations_byEventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_county(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormAggregations",
+		Object:     "Location",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "eventType":
-				return ec.fieldContext_EventTypeGroup_eventType(ctx, field)
-			case "count":
-				return ec.fieldContext_EventTypeGroup_count(ctx, field)
-			case "maxMeasurement":
-				return ec.fieldContext_EventTypeGroup_maxMeasurement(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type EventTypeGroup", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormAggregations_byState(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
+func (ec *executionContext) _Location_parsedLocation(ctx context.Context, field graphql.CollectedField, obj *model.Location) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormAggregations_byState,
+		ec.fieldContext_Location_parsedLocation,
 		func(ctx context.Context) (any, error) {
-			return obj.ByState, nil
+			return ec.resolvers.Location().ParsedLocation(ctx, obj)
 		},
 		nil,
-		ec.marshalNStateGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStateGroupᚄ,
+		ec.marshalNParsedLocation2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐParsedLocation,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormAggregations_byState(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Location_parsedLocation(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormAggregations",
+		Object:     "Location",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "state":
-				return ec.fieldContext_StateGroup_state(ctx, field)
-			case "count":
-				return ec.fieldContext_StateGroup_count(ctx, field)
-			case "counties":
-				return ec.fieldContext_StateGroup_counties(ctx, field)
+			case "name":
+				return ec.fieldContext_ParsedLocation_name(ctx, field)
+			case "distance":
+				return ec.fieldContext_ParsedLocation_distance(ctx, field)
+			case "direction":
+				return ec.fieldContext_ParsedLocation_direction(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type StateGroup", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ParsedLocation", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormAggregations_byHour(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
+func (ec *executionContext) _MagnitudeBucketGroup_eventType(ctx context.Context, field graphql.CollectedField, obj *model.MagnitudeBucketGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormAggregations_byHour,
+		ec.fieldContext_MagnitudeBucketGroup_eventType,
 		func(ctx context.Context) (any, error) {
-			return obj.ByHour, nil
+			return obj.EventType, nil
 		},
 		nil,
-		ec.marshalNTimeGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGroupᚄ,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormAggregations_byHour(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MagnitudeBucketGroup_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormAggregations",
+		Object:     "MagnitudeBucketGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "bucket":
-				return ec.fieldContext_TimeGroup_bucket(ctx, field)
-			case "count":
-				return ec.fieldContext_TimeGroup_count(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type TimeGroup", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_id(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _MagnitudeBucketGroup_min(ctx context.Context, field graphql.CollectedField, obj *model.MagnitudeBucketGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_id,
+		ec.fieldContext_MagnitudeBucketGroup_min,
 		func(ctx context.Context) (any, error) {
-			return obj.ID, nil
+			return obj.Min, nil
 		},
 		nil,
-		ec.marshalNID2string,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MagnitudeBucketGroup_min(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "MagnitudeBucketGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_eventType(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _MagnitudeBucketGroup_max(ctx context.Context, field graphql.CollectedField, obj *model.MagnitudeBucketGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_eventType,
+		ec.fieldContext_MagnitudeBucketGroup_max,
 		func(ctx context.Context) (any, error) {
-			return ec.resolvers.StormReport().EventType(ctx, obj)
+			return obj.Max, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MagnitudeBucketGroup_max(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "MagnitudeBucketGroup",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_geo(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _MagnitudeBucketGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.MagnitudeBucketGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_geo,
+		ec.fieldContext_MagnitudeBucketGroup_count,
 		func(ctx context.Context) (any, error) {
-			return obj.Geo, nil
+			return obj.Count, nil
 		},
 		nil,
-		ec.marshalNGeo2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeo,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_geo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MagnitudeBucketGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "MagnitudeBucketGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "lat":
-				return ec.fieldContext_Geo_lat(ctx, field)
-			case "lon":
-				return ec.fieldContext_Geo_lon(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Geo", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_measurement(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _Measurement_magnitude(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_measurement,
+		ec.fieldContext_Measurement_magnitude,
 		func(ctx context.Context) (any, error) {
-			return obj.Measurement, nil
+			return ec.resolvers.Measurement().Magnitude(ctx, obj)
 		},
 		nil,
-		ec.marshalNMeasurement2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMeasurement,
+		ec.marshalNFloat2float64,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_measurement(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Measurement_magnitude(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "Measurement",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "magnitude":
-				return ec.fieldContext_Measurement_magnitude(ctx, field)
-			case "unit":
-				return ec.fieldContext_Measurement_unit(ctx, field)
-			case "severity":
-				return ec.fieldContext_Measurement_severity(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Measurement", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_eventTime(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _Measurement_unit(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_eventTime,
+		ec.fieldContext_Measurement_unit,
 		func(ctx context.Context) (any, error) {
-			return obj.EventTime, nil
+			return obj.Unit, nil
 		},
 		nil,
-		ec.marshalNDateTime2timeᚐTime,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_eventTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Measurement_unit(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "Measurement",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_sourceOffice(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _Measurement_severity(ctx context.Context, field graphql.CollectedField, obj *model.Measurement) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_sourceOffice,
+		ec.fieldContext_Measurement_severity,
 		func(ctx context.Context) (any, error) {
-			return obj.SourceOffice, nil
+			return obj.Severity, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_sourceOffice(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Measurement_severity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "Measurement",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -1726,216 +2018,169 @@ func (ec *executionContext) fieldContext_StormReport_sourceOffice(_ context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_location(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParsedLocation_name(ctx context.Context, field graphql.CollectedField, obj *model.ParsedLocation) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_location,
+		ec.fieldContext_ParsedLocation_name,
 		func(ctx context.Context) (any, error) {
-			return obj.Location, nil
+			return obj.Name, nil
 		},
 		nil,
-		ec.marshalNLocation2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐLocation,
+		ec.marshalNString2string,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_location(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParsedLocation_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "ParsedLocation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "raw":
-				return ec.fieldContext_Location_raw(ctx, field)
-			case "name":
-				return ec.fieldContext_Location_name(ctx, field)
-			case "distance":
-				return ec.fieldContext_Location_distance(ctx, field)
-			case "direction":
-				return ec.fieldContext_Location_direction(ctx, field)
-			case "state":
-				return ec.fieldContext_Location_state(ctx, field)
-			case "county":
-				return ec.fieldContext_Location_county(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Location", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_comments(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParsedLocation_distance(ctx context.Context, field graphql.CollectedField, obj *model.ParsedLocation) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_comments,
+		ec.fieldContext_ParsedLocation_distance,
 		func(ctx context.Context) (any, error) {
-			return obj.Comments, nil
+			return obj.Distance, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
-		true,
-	)
-}
-
-func (ec *executionContext) fieldContext_StormReport_comments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "StormReport",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
-		},
-	}
-	return fc, nil
-}
-
-func (ec *executionContext) _StormReport_timeBucket(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
-	return graphql.ResolveField(
-		ctx,
-		ec.OperationContext,
-		field,
-		ec.fieldContext_StormReport_timeBucket,
-		func(ctx context.Context) (any, error) {
-			return obj.TimeBucket, nil
-		},
-		nil,
-		ec.marshalNDateTime2timeᚐTime,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_timeBucket(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParsedLocation_distance(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "ParsedLocation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReport_processedAt(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+func (ec *executionContext) _ParsedLocation_direction(ctx context.Context, field graphql.CollectedField, obj *model.ParsedLocation) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReport_processedAt,
+		ec.fieldContext_ParsedLocation_direction,
 		func(ctx context.Context) (any, error) {
-			return obj.ProcessedAt, nil
+			return obj.Direction, nil
 		},
 		nil,
-		ec.marshalNDateTime2timeᚐTime,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReport_processedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParsedLocation_direction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReport",
+		Object:     "ParsedLocation",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReportsResult_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_stormReports(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReportsResult_totalCount,
+		ec.fieldContext_Query_stormReports,
 		func(ctx context.Context) (any, error) {
-			return obj.TotalCount, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().StormReports(ctx, fc.Args["filter"].(model.StormReportFilter))
 		},
 		nil,
-		ec.marshalNInt2int,
+		ec.marshalNStormReportsResult2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReportsResult,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReportsResult_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_stormReports(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReportsResult",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "totalCount":
+				return ec.fieldContext_StormReportsResult_totalCount(ctx, field)
+			case "hasMore":
+				return ec.fieldContext_StormReportsResult_hasMore(ctx, field)
+			case "reports":
+				return ec.fieldContext_StormReportsResult_reports(ctx, field)
+			case "aggregations":
+				return ec.fieldContext_StormReportsResult_aggregations(ctx, field)
+			case "meta":
+				return ec.fieldContext_StormReportsResult_meta(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type StormReportsResult", field.Name)
 		},
 	}
-	return fc, nil
-}
-
-func (ec *executionContext) _StormReportsResult_hasMore(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
-	return graphql.ResolveField(
-		ctx,
-		ec.OperationContext,
-		field,
-		ec.fieldContext_StormReportsResult_hasMore,
-		func(ctx context.Context) (any, error) {
-			return obj.HasMore, nil
-		},
-		nil,
-		ec.marshalNBoolean2bool,
-		true,
-		true,
-	)
-}
-
-func (ec *executionContext) fieldContext_StormReportsResult_hasMore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "StormReportsResult",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
-		},
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_stormReports_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReportsResult_reports(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_stormReport(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReportsResult_reports,
+		ec.fieldContext_Query_stormReport,
 		func(ctx context.Context) (any, error) {
-			return obj.Reports, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().StormReport(ctx, fc.Args["id"].(string))
 		},
 		nil,
-		ec.marshalNStormReport2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReportᚄ,
-		true,
+		ec.marshalOStormReport2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReport,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReportsResult_reports(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_stormReport(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReportsResult",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
@@ -1958,439 +2203,529 @@ func (ec *executionContext) fieldContext_StormReportsResult_reports(_ context.Co
 				return ec.fieldContext_StormReport_timeBucket(ctx, field)
 			case "processedAt":
 				return ec.fieldContext_StormReport_processedAt(ctx, field)
+			case "pipeline":
+				return ec.fieldContext_StormReport_pipeline(ctx, field)
+			case "matchedImpactKeyword":
+				return ec.fieldContext_StormReport_matchedImpactKeyword(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type StormReport", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_stormReport_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReportsResult_aggregations(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_stormReportsBounds(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReportsResult_aggregations,
+		ec.fieldContext_Query_stormReportsBounds,
 		func(ctx context.Context) (any, error) {
-			return obj.Aggregations, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().StormReportsBounds(ctx, fc.Args["filter"].(model.StormReportFilter))
 		},
 		nil,
-		ec.marshalNStormAggregations2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormAggregations,
-		true,
+		ec.marshalOGeoBounds2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeoBounds,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReportsResult_aggregations(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_stormReportsBounds(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReportsResult",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "totalCount":
-				return ec.fieldContext_StormAggregations_totalCount(ctx, field)
-			case "byEventType":
-				return ec.fieldContext_StormAggregations_byEventType(ctx, field)
-			case "byState":
-				return ec.fieldContext_StormAggregations_byState(ctx, field)
-			case "byHour":
-				return ec.fieldContext_StormAggregations_byHour(ctx, field)
+			case "minLat":
+				return ec.fieldContext_GeoBounds_minLat(ctx, field)
+			case "maxLat":
+				return ec.fieldContext_GeoBounds_maxLat(ctx, field)
+			case "minLon":
+				return ec.fieldContext_GeoBounds_minLon(ctx, field)
+			case "maxLon":
+				return ec.fieldContext_GeoBounds_maxLon(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type StormAggregations", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type GeoBounds", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_stormReportsBounds_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _StormReportsResult_meta(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_dataTimeExtent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_StormReportsResult_meta,
+		ec.fieldContext_Query_dataTimeExtent,
 		func(ctx context.Context) (any, error) {
-			return obj.Meta, nil
+			return ec.resolvers.Query().DataTimeExtent(ctx)
 		},
 		nil,
-		ec.marshalNQueryMeta2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐQueryMeta,
-		true,
+		ec.marshalODataTimeExtent2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDataTimeExtent,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_StormReportsResult_meta(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_dataTimeExtent(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "StormReportsResult",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "lastUpdated":
-				return ec.fieldContext_QueryMeta_lastUpdated(ctx, field)
-			case "dataLagMinutes":
-				return ec.fieldContext_QueryMeta_dataLagMinutes(ctx, field)
+			case "earliest":
+				return ec.fieldContext_DataTimeExtent_earliest(ctx, field)
+			case "latest":
+				return ec.fieldContext_DataTimeExtent_latest(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type QueryMeta", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type DataTimeExtent", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TimeGroup_bucket(ctx context.Context, field graphql.CollectedField, obj *model.TimeGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_distinctEventTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_TimeGroup_bucket,
+		ec.fieldContext_Query_distinctEventTypes,
 		func(ctx context.Context) (any, error) {
-			return obj.Bucket, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().DistinctEventTypes(ctx, fc.Args["timeRange"].(model.TimeRange))
 		},
 		nil,
-		ec.marshalNDateTime2timeᚐTime,
+		ec.marshalNEventType2ᚕgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext_TimeGroup_bucket(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_distinctEventTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TimeGroup",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type DateTime does not have child fields")
+			return nil, errors.New("field of type EventType does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_distinctEventTypes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _TimeGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.TimeGroup) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query_ingestionGap(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_TimeGroup_count,
+		ec.fieldContext_Query_ingestionGap,
 		func(ctx context.Context) (any, error) {
-			return obj.Count, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Query().IngestionGap(ctx, fc.Args["withinHours"].(*int))
 		},
 		nil,
-		ec.marshalNInt2int,
-		true,
+		ec.marshalOIngestionGap2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐIngestionGap,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_TimeGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_ingestionGap(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "TimeGroup",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "from":
+				return ec.fieldContext_IngestionGap_from(ctx, field)
+			case "to":
+				return ec.fieldContext_IngestionGap_to(ctx, field)
+			case "minutes":
+				return ec.fieldContext_IngestionGap_minutes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type IngestionGap", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_ingestionGap_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Directive_name,
+		ec.fieldContext_Query___type,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			fc := graphql.GetFieldContext(ctx)
+			return ec.introspectType(fc.Args["name"].(string))
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Directive_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Directive",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
+		IsMethod:   true,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Directive_description,
+		ec.fieldContext_Query___schema,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return ec.introspectSchema()
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Directive_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query___schema(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Directive",
+		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_isRepeatable(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _QueryMeta_lastUpdated(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Directive_isRepeatable,
+		ec.fieldContext_QueryMeta_lastUpdated,
 		func(ctx context.Context) (any, error) {
-			return obj.IsRepeatable, nil
+			return obj.LastUpdated, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
-		true,
+		ec.marshalODateTime2ᚖtimeᚐTime,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Directive_isRepeatable(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_QueryMeta_lastUpdated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Directive",
+		Object:     "QueryMeta",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _QueryMeta_dataLagMinutes(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Directive_locations,
+		ec.fieldContext_QueryMeta_dataLagMinutes,
 		func(ctx context.Context) (any, error) {
-			return obj.Locations, nil
+			return obj.DataLagMinutes, nil
 		},
 		nil,
-		ec.marshalN__DirectiveLocation2ᚕstringᚄ,
-		true,
+		ec.marshalOInt2ᚖint,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Directive_locations(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_QueryMeta_dataLagMinutes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Directive",
+		Object:     "QueryMeta",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type __DirectiveLocation does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+func (ec *executionContext) _QueryMeta_centroid(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Directive_args,
+		ec.fieldContext_QueryMeta_centroid,
 		func(ctx context.Context) (any, error) {
-			return obj.Args, nil
+			return obj.Centroid, nil
 		},
 		nil,
-		ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValueᚄ,
-		true,
+		ec.marshalOGeo2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeo,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Directive_args(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_QueryMeta_centroid(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Directive",
+		Object:     "QueryMeta",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext___InputValue_name(ctx, field)
-			case "description":
-				return ec.fieldContext___InputValue_description(ctx, field)
-			case "type":
-				return ec.fieldContext___InputValue_type(ctx, field)
-			case "defaultValue":
-				return ec.fieldContext___InputValue_defaultValue(ctx, field)
-			case "isDeprecated":
-				return ec.fieldContext___InputValue_isDeprecated(ctx, field)
-			case "deprecationReason":
-				return ec.fieldContext___InputValue_deprecationReason(ctx, field)
+			case "lat":
+				return ec.fieldContext_Geo_lat(ctx, field)
+			case "lon":
+				return ec.fieldContext_Geo_lon(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __InputValue", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Geo", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field___Directive_args_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _QueryMeta_density(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___EnumValue_name,
+		ec.fieldContext_QueryMeta_density,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			return obj.Density, nil
 		},
 		nil,
-		ec.marshalNString2string,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___EnumValue_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_QueryMeta_density(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__EnumValue",
+		Object:     "QueryMeta",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___EnumValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _QueryMeta_aggregationsTimedOut(ctx context.Context, field graphql.CollectedField, obj *model.QueryMeta) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___EnumValue_description,
+		ec.fieldContext_QueryMeta_aggregationsTimedOut,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return obj.AggregationsTimedOut, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___EnumValue_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_QueryMeta_aggregationsTimedOut(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__EnumValue",
+		Object:     "QueryMeta",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityGroup_severity(ctx context.Context, field graphql.CollectedField, obj *model.SeverityGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___EnumValue_isDeprecated,
+		ec.fieldContext_SeverityGroup_severity,
 		func(ctx context.Context) (any, error) {
-			return obj.IsDeprecated(), nil
+			return obj.Severity, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___EnumValue_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityGroup_severity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__EnumValue",
+		Object:     "SeverityGroup",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _SeverityGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.SeverityGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___EnumValue_deprecationReason,
+		ec.fieldContext_SeverityGroup_count,
 		func(ctx context.Context) (any, error) {
-			return obj.DeprecationReason(), nil
+			return obj.Count, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___EnumValue_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_SeverityGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__EnumValue",
+		Object:     "SeverityGroup",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StateGroup_state(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_name,
+		ec.fieldContext_StateGroup_state,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			return obj.State, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -2399,9 +2734,9 @@ func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.Col
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StateGroup_state(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StateGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2412,759 +2747,648 @@ func (ec *executionContext) fieldContext___Field_name(_ context.Context, field g
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StateGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_description,
+		ec.fieldContext_StateGroup_count,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return obj.Count, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNInt2int,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StateGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StateGroup",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StateGroup_counties(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_args,
+		ec.fieldContext_StateGroup_counties,
 		func(ctx context.Context) (any, error) {
-			return obj.Args, nil
+			return obj.Counties, nil
 		},
 		nil,
-		ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValueᚄ,
+		ec.marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroupᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_args(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StateGroup_counties(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StateGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext___InputValue_name(ctx, field)
-			case "description":
-				return ec.fieldContext___InputValue_description(ctx, field)
-			case "type":
-				return ec.fieldContext___InputValue_type(ctx, field)
-			case "defaultValue":
-				return ec.fieldContext___InputValue_defaultValue(ctx, field)
-			case "isDeprecated":
-				return ec.fieldContext___InputValue_isDeprecated(ctx, field)
-			case "deprecationReason":
-				return ec.fieldContext___InputValue_deprecationReason(ctx, field)
+			case "county":
+				return ec.fieldContext_CountyGroup_county(ctx, field)
+			case "count":
+				return ec.fieldContext_CountyGroup_count(ctx, field)
+			case "nearestMiles":
+				return ec.fieldContext_CountyGroup_nearestMiles(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __InputValue", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type CountyGroup", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field___Field_args_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StateGroup_nearestMiles(ctx context.Context, field graphql.CollectedField, obj *model.StateGroup) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_type,
+		ec.fieldContext_StateGroup_nearestMiles,
 		func(ctx context.Context) (any, error) {
-			return obj.Type, nil
+			return obj.NearestMiles, nil
 		},
 		nil,
-		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StateGroup_nearestMiles(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StateGroup",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_isDeprecated,
+		ec.fieldContext_StormAggregations_totalCount,
 		func(ctx context.Context) (any, error) {
-			return obj.IsDeprecated(), nil
+			return obj.TotalCount, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNInt2int,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byEventType(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Field_deprecationReason,
+		ec.fieldContext_StormAggregations_byEventType,
 		func(ctx context.Context) (any, error) {
-			return obj.DeprecationReason(), nil
+			return obj.ByEventType, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNEventTypeGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeGroupᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Field_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byEventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Field",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "eventType":
+				return ec.fieldContext_EventTypeGroup_eventType(ctx, field)
+			case "count":
+				return ec.fieldContext_EventTypeGroup_count(ctx, field)
+			case "maxMeasurement":
+				return ec.fieldContext_EventTypeGroup_maxMeasurement(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type EventTypeGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byState(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_name,
+		ec.fieldContext_StormAggregations_byState,
 		func(ctx context.Context) (any, error) {
-			return obj.Name, nil
+			return obj.ByState, nil
 		},
 		nil,
-		ec.marshalNString2string,
+		ec.marshalNStateGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStateGroupᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byState(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "state":
+				return ec.fieldContext_StateGroup_state(ctx, field)
+			case "count":
+				return ec.fieldContext_StateGroup_count(ctx, field)
+			case "counties":
+				return ec.fieldContext_StateGroup_counties(ctx, field)
+			case "nearestMiles":
+				return ec.fieldContext_StateGroup_nearestMiles(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type StateGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byHour(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_description,
+		ec.fieldContext_StormAggregations_byHour,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return obj.ByHour, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNTimeGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGroupᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byHour(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "bucket":
+				return ec.fieldContext_TimeGroup_bucket(ctx, field)
+			case "count":
+				return ec.fieldContext_TimeGroup_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type TimeGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byHourGranularity(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_type,
+		ec.fieldContext_StormAggregations_byHourGranularity,
 		func(ctx context.Context) (any, error) {
-			return obj.Type, nil
+			return obj.ByHourGranularity, nil
 		},
 		nil,
-		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNTimeGranularity2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGranularity,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byHourGranularity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type TimeGranularity does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_bySeverity(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_defaultValue,
+		ec.fieldContext_StormAggregations_bySeverity,
 		func(ctx context.Context) (any, error) {
-			return obj.DefaultValue, nil
+			return obj.BySeverity, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNSeverityGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverityGroupᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_defaultValue(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_bySeverity(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "severity":
+				return ec.fieldContext_SeverityGroup_severity(ctx, field)
+			case "count":
+				return ec.fieldContext_SeverityGroup_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SeverityGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byDayOfWeek(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_isDeprecated,
+		ec.fieldContext_StormAggregations_byDayOfWeek,
 		func(ctx context.Context) (any, error) {
-			return obj.IsDeprecated(), nil
+			return obj.ByDayOfWeek, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
+		ec.marshalNDayOfWeekGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDayOfWeekGroupᚄ,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byDayOfWeek(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "dayOfWeek":
+				return ec.fieldContext_DayOfWeekGroup_dayOfWeek(ctx, field)
+			case "name":
+				return ec.fieldContext_DayOfWeekGroup_name(ctx, field)
+			case "count":
+				return ec.fieldContext_DayOfWeekGroup_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DayOfWeekGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___InputValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_byMagnitudeBucket(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___InputValue_deprecationReason,
+		ec.fieldContext_StormAggregations_byMagnitudeBucket,
 		func(ctx context.Context) (any, error) {
-			return obj.DeprecationReason(), nil
+			return obj.ByMagnitudeBucket, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNMagnitudeBucketGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMagnitudeBucketGroupᚄ,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___InputValue_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_byMagnitudeBucket(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__InputValue",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "eventType":
+				return ec.fieldContext_MagnitudeBucketGroup_eventType(ctx, field)
+			case "min":
+				return ec.fieldContext_MagnitudeBucketGroup_min(ctx, field)
+			case "max":
+				return ec.fieldContext_MagnitudeBucketGroup_max(ctx, field)
+			case "count":
+				return ec.fieldContext_MagnitudeBucketGroup_count(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MagnitudeBucketGroup", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_sampled(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_description,
+		ec.fieldContext_StormAggregations_sampled,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return obj.Sampled, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNBoolean2bool,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_sampled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_sampleFraction(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_types,
+		ec.fieldContext_StormAggregations_sampleFraction,
 		func(ctx context.Context) (any, error) {
-			return obj.Types(), nil
+			return obj.SampleFraction, nil
 		},
 		nil,
-		ec.marshalN__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐTypeᚄ,
-		true,
+		ec.marshalOFloat2ᚖfloat64,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_types(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_sampleFraction(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormAggregations_truncated(ctx context.Context, field graphql.CollectedField, obj *model.StormAggregations) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_queryType,
+		ec.fieldContext_StormAggregations_truncated,
 		func(ctx context.Context) (any, error) {
-			return obj.QueryType(), nil
+			return obj.Truncated, nil
 		},
 		nil,
-		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNBoolean2bool,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_queryType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormAggregations_truncated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormAggregations",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_id(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_mutationType,
+		ec.fieldContext_StormReport_id,
 		func(ctx context.Context) (any, error) {
-			return obj.MutationType(), nil
+			return obj.ID, nil
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNID2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_mutationType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_eventType(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_subscriptionType,
+		ec.fieldContext_StormReport_eventType,
 		func(ctx context.Context) (any, error) {
-			return obj.SubscriptionType(), nil
+			return ec.resolvers.StormReport().EventType(ctx, obj)
 		},
 		nil,
-		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		ec.marshalNString2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_subscriptionType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_eventType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormReport",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "isOneOf":
-				return ec.fieldContext___Type_isOneOf(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Schema_directives(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_geo(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Schema_directives,
+		ec.fieldContext_StormReport_geo,
 		func(ctx context.Context) (any, error) {
-			return obj.Directives(), nil
+			return obj.Geo, nil
 		},
 		nil,
-		ec.marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirectiveᚄ,
+		ec.marshalNGeo2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeo,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___Schema_directives(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_geo(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Schema",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext___Directive_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Directive_description(ctx, field)
-			case "isRepeatable":
-				return ec.fieldContext___Directive_isRepeatable(ctx, field)
-			case "locations":
-				return ec.fieldContext___Directive_locations(ctx, field)
-			case "args":
-				return ec.fieldContext___Directive_args(ctx, field)
+			case "lat":
+				return ec.fieldContext_Geo_lat(ctx, field)
+			case "lon":
+				return ec.fieldContext_Geo_lon(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type __Directive", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Geo", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_measurement(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Type_kind,
+		ec.fieldContext_StormReport_measurement,
 		func(ctx context.Context) (any, error) {
-			return obj.Kind(), nil
+			return obj.Measurement, nil
 		},
 		nil,
-		ec.marshalN__TypeKind2string,
+		ec.marshalNMeasurement2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMeasurement,
 		true,
 		true,
 	)
 }
 
-func (ec *executionContext) fieldContext___Type_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_measurement(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Type",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type __TypeKind does not have child fields")
+			switch field.Name {
+			case "magnitude":
+				return ec.fieldContext_Measurement_magnitude(ctx, field)
+			case "unit":
+				return ec.fieldContext_Measurement_unit(ctx, field)
+			case "severity":
+				return ec.fieldContext_Measurement_severity(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Measurement", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_eventTime(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Type_name,
+		ec.fieldContext_StormReport_eventTime,
 		func(ctx context.Context) (any, error) {
-			return obj.Name(), nil
+			return obj.EventTime, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Type_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_eventTime(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Type",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_sourceOffice(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Type_description,
+		ec.fieldContext_StormReport_sourceOffice,
 		func(ctx context.Context) (any, error) {
-			return obj.Description(), nil
+			return obj.SourceOffice, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNString2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Type_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_sourceOffice(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Type",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
@@ -3173,57 +3397,1588 @@ func (ec *executionContext) fieldContext___Type_description(_ context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_location(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Type_specifiedByURL,
+		ec.fieldContext_StormReport_location,
 		func(ctx context.Context) (any, error) {
-			return obj.SpecifiedByURL(), nil
+			return obj.Location, nil
 		},
 		nil,
-		ec.marshalOString2ᚖstring,
+		ec.marshalNLocation2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐLocation,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Type_specifiedByURL(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_location(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Type",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "raw":
+				return ec.fieldContext_Location_raw(ctx, field)
+			case "name":
+				return ec.fieldContext_Location_name(ctx, field)
+			case "distance":
+				return ec.fieldContext_Location_distance(ctx, field)
+			case "direction":
+				return ec.fieldContext_Location_direction(ctx, field)
+			case "state":
+				return ec.fieldContext_Location_state(ctx, field)
+			case "county":
+				return ec.fieldContext_Location_county(ctx, field)
+			case "parsedLocation":
+				return ec.fieldContext_Location_parsedLocation(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Location", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+func (ec *executionContext) _StormReport_comments(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext___Type_fields,
+		ec.fieldContext_StormReport_comments,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return obj.Fields(fc.Args["includeDeprecated"].(bool)), nil
+			return obj.Comments, nil
 		},
 		nil,
-		ec.marshalO__Field2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐFieldᚄ,
+		ec.marshalNString2string,
+		true,
 		true,
-		false,
 	)
 }
 
-func (ec *executionContext) fieldContext___Type_fields(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_StormReport_comments(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "__Type",
+		Object:     "StormReport",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReport_timeBucket(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReport_timeBucket,
+		func(ctx context.Context) (any, error) {
+			return obj.TimeBucket, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReport_timeBucket(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReport",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReport_processedAt(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReport_processedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.ProcessedAt, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReport_processedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReport",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReport_pipeline(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReport_pipeline,
+		func(ctx context.Context) (any, error) {
+			return obj.Pipeline, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReport_pipeline(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReport",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReport_matchedImpactKeyword(ctx context.Context, field graphql.CollectedField, obj *model.StormReport) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReport_matchedImpactKeyword,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.StormReport().MatchedImpactKeyword(ctx, obj)
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReport_matchedImpactKeyword(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReport",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReportsResult_totalCount(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReportsResult_totalCount,
+		func(ctx context.Context) (any, error) {
+			return obj.TotalCount, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReportsResult_totalCount(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReportsResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReportsResult_hasMore(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReportsResult_hasMore,
+		func(ctx context.Context) (any, error) {
+			return obj.HasMore, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReportsResult_hasMore(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReportsResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReportsResult_reports(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReportsResult_reports,
+		func(ctx context.Context) (any, error) {
+			return obj.Reports, nil
+		},
+		nil,
+		ec.marshalNStormReport2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReportᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReportsResult_reports(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReportsResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_StormReport_id(ctx, field)
+			case "eventType":
+				return ec.fieldContext_StormReport_eventType(ctx, field)
+			case "geo":
+				return ec.fieldContext_StormReport_geo(ctx, field)
+			case "measurement":
+				return ec.fieldContext_StormReport_measurement(ctx, field)
+			case "eventTime":
+				return ec.fieldContext_StormReport_eventTime(ctx, field)
+			case "sourceOffice":
+				return ec.fieldContext_StormReport_sourceOffice(ctx, field)
+			case "location":
+				return ec.fieldContext_StormReport_location(ctx, field)
+			case "comments":
+				return ec.fieldContext_StormReport_comments(ctx, field)
+			case "timeBucket":
+				return ec.fieldContext_StormReport_timeBucket(ctx, field)
+			case "processedAt":
+				return ec.fieldContext_StormReport_processedAt(ctx, field)
+			case "pipeline":
+				return ec.fieldContext_StormReport_pipeline(ctx, field)
+			case "matchedImpactKeyword":
+				return ec.fieldContext_StormReport_matchedImpactKeyword(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type StormReport", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReportsResult_aggregations(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReportsResult_aggregations,
+		func(ctx context.Context) (any, error) {
+			return obj.Aggregations, nil
+		},
+		nil,
+		ec.marshalNStormAggregations2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormAggregations,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReportsResult_aggregations(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReportsResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "totalCount":
+				return ec.fieldContext_StormAggregations_totalCount(ctx, field)
+			case "byEventType":
+				return ec.fieldContext_StormAggregations_byEventType(ctx, field)
+			case "byState":
+				return ec.fieldContext_StormAggregations_byState(ctx, field)
+			case "byHour":
+				return ec.fieldContext_StormAggregations_byHour(ctx, field)
+			case "byHourGranularity":
+				return ec.fieldContext_StormAggregations_byHourGranularity(ctx, field)
+			case "bySeverity":
+				return ec.fieldContext_StormAggregations_bySeverity(ctx, field)
+			case "byDayOfWeek":
+				return ec.fieldContext_StormAggregations_byDayOfWeek(ctx, field)
+			case "byMagnitudeBucket":
+				return ec.fieldContext_StormAggregations_byMagnitudeBucket(ctx, field)
+			case "sampled":
+				return ec.fieldContext_StormAggregations_sampled(ctx, field)
+			case "sampleFraction":
+				return ec.fieldContext_StormAggregations_sampleFraction(ctx, field)
+			case "truncated":
+				return ec.fieldContext_StormAggregations_truncated(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type StormAggregations", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _StormReportsResult_meta(ctx context.Context, field graphql.CollectedField, obj *model.StormReportsResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_StormReportsResult_meta,
+		func(ctx context.Context) (any, error) {
+			return obj.Meta, nil
+		},
+		nil,
+		ec.marshalNQueryMeta2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐQueryMeta,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_StormReportsResult_meta(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "StormReportsResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "lastUpdated":
+				return ec.fieldContext_QueryMeta_lastUpdated(ctx, field)
+			case "dataLagMinutes":
+				return ec.fieldContext_QueryMeta_dataLagMinutes(ctx, field)
+			case "centroid":
+				return ec.fieldContext_QueryMeta_centroid(ctx, field)
+			case "density":
+				return ec.fieldContext_QueryMeta_density(ctx, field)
+			case "aggregationsTimedOut":
+				return ec.fieldContext_QueryMeta_aggregationsTimedOut(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type QueryMeta", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TimeGroup_bucket(ctx context.Context, field graphql.CollectedField, obj *model.TimeGroup) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_TimeGroup_bucket,
+		func(ctx context.Context) (any, error) {
+			return obj.Bucket, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_TimeGroup_bucket(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TimeGroup",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _TimeGroup_count(ctx context.Context, field graphql.CollectedField, obj *model.TimeGroup) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_TimeGroup_count,
+		func(ctx context.Context) (any, error) {
+			return obj.Count, nil
+		},
+		nil,
+		ec.marshalNInt2int,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_TimeGroup_count(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "TimeGroup",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Directive_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Directive_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Directive_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Directive_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Directive_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Directive_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Directive_isRepeatable(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Directive_isRepeatable,
+		func(ctx context.Context) (any, error) {
+			return obj.IsRepeatable, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Directive_isRepeatable(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Directive_locations(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Directive_locations,
+		func(ctx context.Context) (any, error) {
+			return obj.Locations, nil
+		},
+		nil,
+		ec.marshalN__DirectiveLocation2ᚕstringᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Directive_locations(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type __DirectiveLocation does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Directive_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Directive) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Directive_args,
+		func(ctx context.Context) (any, error) {
+			return obj.Args, nil
+		},
+		nil,
+		ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValueᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Directive_args(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Directive",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext___InputValue_name(ctx, field)
+			case "description":
+				return ec.fieldContext___InputValue_description(ctx, field)
+			case "type":
+				return ec.fieldContext___InputValue_type(ctx, field)
+			case "defaultValue":
+				return ec.fieldContext___InputValue_defaultValue(ctx, field)
+			case "isDeprecated":
+				return ec.fieldContext___InputValue_isDeprecated(ctx, field)
+			case "deprecationReason":
+				return ec.fieldContext___InputValue_deprecationReason(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __InputValue", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field___Directive_args_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___EnumValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___EnumValue_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___EnumValue_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__EnumValue",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___EnumValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___EnumValue_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___EnumValue_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__EnumValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___EnumValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___EnumValue_isDeprecated,
+		func(ctx context.Context) (any, error) {
+			return obj.IsDeprecated(), nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___EnumValue_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__EnumValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___EnumValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.EnumValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___EnumValue_deprecationReason,
+		func(ctx context.Context) (any, error) {
+			return obj.DeprecationReason(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___EnumValue_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__EnumValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_args(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_args,
+		func(ctx context.Context) (any, error) {
+			return obj.Args, nil
+		},
+		nil,
+		ec.marshalN__InputValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐInputValueᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_args(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext___InputValue_name(ctx, field)
+			case "description":
+				return ec.fieldContext___InputValue_description(ctx, field)
+			case "type":
+				return ec.fieldContext___InputValue_type(ctx, field)
+			case "defaultValue":
+				return ec.fieldContext___InputValue_defaultValue(ctx, field)
+			case "isDeprecated":
+				return ec.fieldContext___InputValue_isDeprecated(ctx, field)
+			case "deprecationReason":
+				return ec.fieldContext___InputValue_deprecationReason(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __InputValue", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field___Field_args_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_type(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_type,
+		func(ctx context.Context) (any, error) {
+			return obj.Type, nil
+		},
+		nil,
+		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_isDeprecated,
+		func(ctx context.Context) (any, error) {
+			return obj.IsDeprecated(), nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Field_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.Field) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Field_deprecationReason,
+		func(ctx context.Context) (any, error) {
+			return obj.DeprecationReason(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Field_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Field",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_name(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_description(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_type(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_type,
+		func(ctx context.Context) (any, error) {
+			return obj.Type, nil
+		},
+		nil,
+		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_type(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_defaultValue(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_defaultValue,
+		func(ctx context.Context) (any, error) {
+			return obj.DefaultValue, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_defaultValue(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_isDeprecated(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_isDeprecated,
+		func(ctx context.Context) (any, error) {
+			return obj.IsDeprecated(), nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_isDeprecated(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___InputValue_deprecationReason(ctx context.Context, field graphql.CollectedField, obj *introspection.InputValue) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___InputValue_deprecationReason,
+		func(ctx context.Context) (any, error) {
+			return obj.DeprecationReason(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___InputValue_deprecationReason(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__InputValue",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_types(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_types,
+		func(ctx context.Context) (any, error) {
+			return obj.Types(), nil
+		},
+		nil,
+		ec.marshalN__Type2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐTypeᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_types(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_queryType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_queryType,
+		func(ctx context.Context) (any, error) {
+			return obj.QueryType(), nil
+		},
+		nil,
+		ec.marshalN__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_queryType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_mutationType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_mutationType,
+		func(ctx context.Context) (any, error) {
+			return obj.MutationType(), nil
+		},
+		nil,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_mutationType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_subscriptionType(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_subscriptionType,
+		func(ctx context.Context) (any, error) {
+			return obj.SubscriptionType(), nil
+		},
+		nil,
+		ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_subscriptionType(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "kind":
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "isOneOf":
+				return ec.fieldContext___Type_isOneOf(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Schema_directives(ctx context.Context, field graphql.CollectedField, obj *introspection.Schema) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Schema_directives,
+		func(ctx context.Context) (any, error) {
+			return obj.Directives(), nil
+		},
+		nil,
+		ec.marshalN__Directive2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐDirectiveᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Schema_directives(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Schema",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext___Directive_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Directive_description(ctx, field)
+			case "isRepeatable":
+				return ec.fieldContext___Directive_isRepeatable(ctx, field)
+			case "locations":
+				return ec.fieldContext___Directive_locations(ctx, field)
+			case "args":
+				return ec.fieldContext___Directive_args(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type __Directive", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Type_kind(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Type_kind,
+		func(ctx context.Context) (any, error) {
+			return obj.Kind(), nil
+		},
+		nil,
+		ec.marshalN__TypeKind2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext___Type_kind(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type __TypeKind does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Type_name(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Type_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Type_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Type_description(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Type_description,
+		func(ctx context.Context) (any, error) {
+			return obj.Description(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Type_description(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Type_specifiedByURL(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Type_specifiedByURL,
+		func(ctx context.Context) (any, error) {
+			return obj.SpecifiedByURL(), nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Type_specifiedByURL(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) ___Type_fields(ctx context.Context, field graphql.CollectedField, obj *introspection.Type) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext___Type_fields,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return obj.Fields(fc.Args["includeDeprecated"].(bool)), nil
+		},
+		nil,
+		ec.marshalO__Field2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐFieldᚄ,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext___Type_fields(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "__Type",
+		Field:      field,
+		IsMethod:   true,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
@@ -3625,7 +5380,48 @@ func (ec *executionContext) unmarshalInputGeoRadiusFilter(ctx context.Context, o
 			if err != nil {
 				return it, err
 			}
-			it.RadiusMiles = data
+			it.RadiusMiles = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputHourOfDayRange(ctx context.Context, obj any) (model.HourOfDayRange, error) {
+	var it model.HourOfDayRange
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"from", "to", "timeZone"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "from":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("from"))
+			data, err := ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.From = data
+		case "to":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("to"))
+			data, err := ec.unmarshalNInt2int(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.To = data
+		case "timeZone":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("timeZone"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.TimeZone = data
 		}
 	}
 
@@ -3639,7 +5435,7 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"timeRange", "near", "states", "counties", "eventTypes", "severity", "minMagnitude", "eventTypeFilters", "sortBy", "sortOrder", "limit", "offset"}
+	fieldsInOrder := [...]string{"timeRange", "hourOfDayRange", "ingestedWithinMinutes", "idPrefix", "near", "nearPlace", "states", "counties", "excludeStates", "excludeCounties", "pipelines", "impactKeywords", "eventTypes", "severity", "minSeverity", "minMagnitude", "eventTypeFilters", "sortBy", "sortOrder", "limit", "offset", "sampleAggregations", "magnitudeBucketEdges", "maxAggregationRows"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -3648,11 +5444,32 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 		switch k {
 		case "timeRange":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("timeRange"))
-			data, err := ec.unmarshalNTimeRange2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeRange(ctx, v)
+			data, err := ec.unmarshalOTimeRange2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeRange(ctx, v)
 			if err != nil {
 				return it, err
 			}
 			it.TimeRange = data
+		case "hourOfDayRange":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("hourOfDayRange"))
+			data, err := ec.unmarshalOHourOfDayRange2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐHourOfDayRange(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.HourOfDayRange = data
+		case "ingestedWithinMinutes":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ingestedWithinMinutes"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.IngestedWithinMinutes = data
+		case "idPrefix":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("idPrefix"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.IDPrefix = data
 		case "near":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("near"))
 			data, err := ec.unmarshalOGeoRadiusFilter2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeoRadiusFilter(ctx, v)
@@ -3660,6 +5477,13 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 				return it, err
 			}
 			it.Near = data
+		case "nearPlace":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("nearPlace"))
+			data, err := ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.NearPlace = data
 		case "states":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("states"))
 			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
@@ -3674,6 +5498,34 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 				return it, err
 			}
 			it.Counties = data
+		case "excludeStates":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("excludeStates"))
+			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ExcludeStates = data
+		case "excludeCounties":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("excludeCounties"))
+			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ExcludeCounties = data
+		case "pipelines":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pipelines"))
+			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Pipelines = data
+		case "impactKeywords":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("impactKeywords"))
+			data, err := ec.unmarshalOString2ᚕstringᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ImpactKeywords = data
 		case "eventTypes":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("eventTypes"))
 			data, err := ec.unmarshalOEventType2ᚕgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeᚄ(ctx, v)
@@ -3688,6 +5540,13 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 				return it, err
 			}
 			it.Severity = data
+		case "minSeverity":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minSeverity"))
+			data, err := ec.unmarshalOSeverity2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverity(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MinSeverity = data
 		case "minMagnitude":
 			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("minMagnitude"))
 			data, err := ec.unmarshalOFloat2ᚖfloat64(ctx, v)
@@ -3730,6 +5589,27 @@ func (ec *executionContext) unmarshalInputStormReportFilter(ctx context.Context,
 				return it, err
 			}
 			it.Offset = data
+		case "sampleAggregations":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sampleAggregations"))
+			data, err := ec.unmarshalOBoolean2ᚖbool(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.SampleAggregations = data
+		case "magnitudeBucketEdges":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("magnitudeBucketEdges"))
+			data, err := ec.unmarshalOFloat2ᚕfloat64ᚄ(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MagnitudeBucketEdges = data
+		case "maxAggregationRows":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("maxAggregationRows"))
+			data, err := ec.unmarshalOInt2ᚖint(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.MaxAggregationRows = data
 		}
 	}
 
@@ -3799,6 +5679,253 @@ func (ec *executionContext) _CountyGroup(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nearestMiles":
+			out.Values[i] = ec._CountyGroup_nearestMiles(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var dataTimeExtentImplementors = []string{"DataTimeExtent"}
+
+func (ec *executionContext) _DataTimeExtent(ctx context.Context, sel ast.SelectionSet, obj *model.DataTimeExtent) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, dataTimeExtentImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DataTimeExtent")
+		case "earliest":
+			out.Values[i] = ec._DataTimeExtent_earliest(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "latest":
+			out.Values[i] = ec._DataTimeExtent_latest(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var dayOfWeekGroupImplementors = []string{"DayOfWeekGroup"}
+
+func (ec *executionContext) _DayOfWeekGroup(ctx context.Context, sel ast.SelectionSet, obj *model.DayOfWeekGroup) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, dayOfWeekGroupImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("DayOfWeekGroup")
+		case "dayOfWeek":
+			out.Values[i] = ec._DayOfWeekGroup_dayOfWeek(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "name":
+			out.Values[i] = ec._DayOfWeekGroup_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "count":
+			out.Values[i] = ec._DayOfWeekGroup_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var eventTypeGroupImplementors = []string{"EventTypeGroup"}
+
+func (ec *executionContext) _EventTypeGroup(ctx context.Context, sel ast.SelectionSet, obj *model.EventTypeGroup) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, eventTypeGroupImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("EventTypeGroup")
+		case "eventType":
+			out.Values[i] = ec._EventTypeGroup_eventType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "count":
+			out.Values[i] = ec._EventTypeGroup_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxMeasurement":
+			out.Values[i] = ec._EventTypeGroup_maxMeasurement(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var geoImplementors = []string{"Geo"}
+
+func (ec *executionContext) _Geo(ctx context.Context, sel ast.SelectionSet, obj *model.Geo) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, geoImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Geo")
+		case "lat":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Geo_lat(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "lon":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Geo_lon(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -3822,29 +5949,37 @@ func (ec *executionContext) _CountyGroup(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
-var eventTypeGroupImplementors = []string{"EventTypeGroup"}
+var geoBoundsImplementors = []string{"GeoBounds"}
 
-func (ec *executionContext) _EventTypeGroup(ctx context.Context, sel ast.SelectionSet, obj *model.EventTypeGroup) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, eventTypeGroupImplementors)
+func (ec *executionContext) _GeoBounds(ctx context.Context, sel ast.SelectionSet, obj *model.GeoBounds) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, geoBoundsImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("EventTypeGroup")
-		case "eventType":
-			out.Values[i] = ec._EventTypeGroup_eventType(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("GeoBounds")
+		case "minLat":
+			out.Values[i] = ec._GeoBounds_minLat(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "count":
-			out.Values[i] = ec._EventTypeGroup_count(ctx, field, obj)
+		case "maxLat":
+			out.Values[i] = ec._GeoBounds_maxLat(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "minLon":
+			out.Values[i] = ec._GeoBounds_minLon(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "maxLon":
+			out.Values[i] = ec._GeoBounds_maxLon(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "maxMeasurement":
-			out.Values[i] = ec._EventTypeGroup_maxMeasurement(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -3868,24 +6003,29 @@ func (ec *executionContext) _EventTypeGroup(ctx context.Context, sel ast.Selecti
 	return out
 }
 
-var geoImplementors = []string{"Geo"}
+var ingestionGapImplementors = []string{"IngestionGap"}
 
-func (ec *executionContext) _Geo(ctx context.Context, sel ast.SelectionSet, obj *model.Geo) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, geoImplementors)
+func (ec *executionContext) _IngestionGap(ctx context.Context, sel ast.SelectionSet, obj *model.IngestionGap) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, ingestionGapImplementors)
 
 	out := graphql.NewFieldSet(fields)
 	deferred := make(map[string]*graphql.FieldSet)
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("Geo")
-		case "lat":
-			out.Values[i] = ec._Geo_lat(ctx, field, obj)
+			out.Values[i] = graphql.MarshalString("IngestionGap")
+		case "from":
+			out.Values[i] = ec._IngestionGap_from(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
-		case "lon":
-			out.Values[i] = ec._Geo_lon(ctx, field, obj)
+		case "to":
+			out.Values[i] = ec._IngestionGap_to(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "minutes":
+			out.Values[i] = ec._IngestionGap_minutes(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -3926,12 +6066,12 @@ func (ec *executionContext) _Location(ctx context.Context, sel ast.SelectionSet,
 		case "raw":
 			out.Values[i] = ec._Location_raw(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "name":
 			out.Values[i] = ec._Location_name(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "distance":
 			out.Values[i] = ec._Location_distance(ctx, field, obj)
@@ -3940,10 +6080,94 @@ func (ec *executionContext) _Location(ctx context.Context, sel ast.SelectionSet,
 		case "state":
 			out.Values[i] = ec._Location_state(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "county":
 			out.Values[i] = ec._Location_county(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "parsedLocation":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Location_parsedLocation(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var magnitudeBucketGroupImplementors = []string{"MagnitudeBucketGroup"}
+
+func (ec *executionContext) _MagnitudeBucketGroup(ctx context.Context, sel ast.SelectionSet, obj *model.MagnitudeBucketGroup) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, magnitudeBucketGroupImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("MagnitudeBucketGroup")
+		case "eventType":
+			out.Values[i] = ec._MagnitudeBucketGroup_eventType(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "min":
+			out.Values[i] = ec._MagnitudeBucketGroup_min(ctx, field, obj)
+		case "max":
+			out.Values[i] = ec._MagnitudeBucketGroup_max(ctx, field, obj)
+		case "count":
+			out.Values[i] = ec._MagnitudeBucketGroup_count(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
@@ -3982,14 +6206,45 @@ func (ec *executionContext) _Measurement(ctx context.Context, sel ast.SelectionS
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Measurement")
 		case "magnitude":
-			out.Values[i] = ec._Measurement_magnitude(ctx, field, obj)
-			if out.Values[i] == graphql.Null {
-				out.Invalids++
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Measurement_magnitude(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
 			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		case "unit":
 			out.Values[i] = ec._Measurement_unit(ctx, field, obj)
 			if out.Values[i] == graphql.Null {
-				out.Invalids++
+				atomic.AddUint32(&out.Invalids, 1)
 			}
 		case "severity":
 			out.Values[i] = ec._Measurement_severity(ctx, field, obj)
@@ -4016,6 +6271,49 @@ func (ec *executionContext) _Measurement(ctx context.Context, sel ast.SelectionS
 	return out
 }
 
+var parsedLocationImplementors = []string{"ParsedLocation"}
+
+func (ec *executionContext) _ParsedLocation(ctx context.Context, sel ast.SelectionSet, obj *model.ParsedLocation) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, parsedLocationImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ParsedLocation")
+		case "name":
+			out.Values[i] = ec._ParsedLocation_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "distance":
+			out.Values[i] = ec._ParsedLocation_distance(ctx, field, obj)
+		case "direction":
+			out.Values[i] = ec._ParsedLocation_direction(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var queryImplementors = []string{"Query"}
 
 func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -4032,10 +6330,89 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			Field:  field,
 		})
 
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Query")
-		case "stormReports":
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Query")
+		case "stormReports":
+			field := field
+
+			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_stormReports(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&fs.Invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "stormReport":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_stormReport(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "stormReportsBounds":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_stormReportsBounds(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "dataTimeExtent":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_dataTimeExtent(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "distinctEventTypes":
 			field := field
 
 			innerFunc := func(ctx context.Context, fs *graphql.FieldSet) (res graphql.Marshaler) {
@@ -4044,7 +6421,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_stormReports(ctx, field)
+				res = ec._Query_distinctEventTypes(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&fs.Invalids, 1)
 				}
@@ -4056,6 +6433,25 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 			}
 
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
+		case "ingestionGap":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_ingestionGap(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx,
+					func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+			}
+
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return rrm(innerCtx) })
 		case "__type":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
@@ -4103,6 +6499,56 @@ func (ec *executionContext) _QueryMeta(ctx context.Context, sel ast.SelectionSet
 			out.Values[i] = ec._QueryMeta_lastUpdated(ctx, field, obj)
 		case "dataLagMinutes":
 			out.Values[i] = ec._QueryMeta_dataLagMinutes(ctx, field, obj)
+		case "centroid":
+			out.Values[i] = ec._QueryMeta_centroid(ctx, field, obj)
+		case "density":
+			out.Values[i] = ec._QueryMeta_density(ctx, field, obj)
+		case "aggregationsTimedOut":
+			out.Values[i] = ec._QueryMeta_aggregationsTimedOut(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var severityGroupImplementors = []string{"SeverityGroup"}
+
+func (ec *executionContext) _SeverityGroup(ctx context.Context, sel ast.SelectionSet, obj *model.SeverityGroup) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, severityGroupImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("SeverityGroup")
+		case "severity":
+			out.Values[i] = ec._SeverityGroup_severity(ctx, field, obj)
+		case "count":
+			out.Values[i] = ec._SeverityGroup_count(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4152,6 +6598,8 @@ func (ec *executionContext) _StateGroup(ctx context.Context, sel ast.SelectionSe
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "nearestMiles":
+			out.Values[i] = ec._StateGroup_nearestMiles(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4206,6 +6654,38 @@ func (ec *executionContext) _StormAggregations(ctx context.Context, sel ast.Sele
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "byHourGranularity":
+			out.Values[i] = ec._StormAggregations_byHourGranularity(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "bySeverity":
+			out.Values[i] = ec._StormAggregations_bySeverity(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "byDayOfWeek":
+			out.Values[i] = ec._StormAggregations_byDayOfWeek(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "byMagnitudeBucket":
+			out.Values[i] = ec._StormAggregations_byMagnitudeBucket(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "sampled":
+			out.Values[i] = ec._StormAggregations_sampled(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "sampleFraction":
+			out.Values[i] = ec._StormAggregations_sampleFraction(ctx, field, obj)
+		case "truncated":
+			out.Values[i] = ec._StormAggregations_truncated(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4321,6 +6801,44 @@ func (ec *executionContext) _StormReport(ctx context.Context, sel ast.SelectionS
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&out.Invalids, 1)
 			}
+		case "pipeline":
+			out.Values[i] = ec._StormReport_pipeline(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "matchedImpactKeyword":
+			field := field
+
+			innerFunc := func(ctx context.Context, _ *graphql.FieldSet) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._StormReport_matchedImpactKeyword(ctx, field, obj)
+				return res
+			}
+
+			if field.Deferrable != nil {
+				dfs, ok := deferred[field.Deferrable.Label]
+				di := 0
+				if ok {
+					dfs.AddField(field)
+					di = len(dfs.Values) - 1
+				} else {
+					dfs = graphql.NewFieldSet([]graphql.CollectedField{field})
+					deferred[field.Deferrable.Label] = dfs
+				}
+				dfs.Concurrently(di, func(ctx context.Context) graphql.Marshaler {
+					return innerFunc(ctx, dfs)
+				})
+
+				// don't run the out.Concurrently() call below
+				out.Values[i] = graphql.Null
+				continue
+			}
+
+			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -4795,10 +7313,159 @@ func (ec *executionContext) marshalNBoolean2bool(ctx context.Context, sel ast.Se
 			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
 		}
 	}
-	return res
+	return res
+}
+
+func (ec *executionContext) marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.CountyGroup) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNCountyGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroup(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNCountyGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroup(ctx context.Context, sel ast.SelectionSet, v *model.CountyGroup) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._CountyGroup(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNDateTime2timeᚐTime(ctx context.Context, v any) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNDateTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) marshalNDayOfWeekGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDayOfWeekGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.DayOfWeekGroup) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNDayOfWeekGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDayOfWeekGroup(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNDayOfWeekGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDayOfWeekGroup(ctx context.Context, sel ast.SelectionSet, v *model.DayOfWeekGroup) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._DayOfWeekGroup(ctx, sel, v)
+}
+
+func (ec *executionContext) unmarshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx context.Context, v any) (model.EventType, error) {
+	var res model.EventType
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx context.Context, sel ast.SelectionSet, v model.EventType) graphql.Marshaler {
+	return v
+}
+
+func (ec *executionContext) unmarshalNEventType2ᚕgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeᚄ(ctx context.Context, v any) ([]model.EventType, error) {
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]model.EventType, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
 }
 
-func (ec *executionContext) marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.CountyGroup) graphql.Marshaler {
+func (ec *executionContext) marshalNEventType2ᚕgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []model.EventType) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
 	isLen1 := len(v) == 1
@@ -4822,7 +7489,7 @@ func (ec *executionContext) marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcrypti
 			if !isLen1 {
 				defer wg.Done()
 			}
-			ret[i] = ec.marshalNCountyGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroup(ctx, sel, v[i])
+			ret[i] = ec.marshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx, sel, v[i])
 		}
 		if isLen1 {
 			f(i)
@@ -4842,42 +7509,6 @@ func (ec *executionContext) marshalNCountyGroup2ᚕᚖgithubᚗcomᚋcouchcrypti
 	return ret
 }
 
-func (ec *executionContext) marshalNCountyGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐCountyGroup(ctx context.Context, sel ast.SelectionSet, v *model.CountyGroup) graphql.Marshaler {
-	if v == nil {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
-		}
-		return graphql.Null
-	}
-	return ec._CountyGroup(ctx, sel, v)
-}
-
-func (ec *executionContext) unmarshalNDateTime2timeᚐTime(ctx context.Context, v any) (time.Time, error) {
-	res, err := graphql.UnmarshalTime(v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalNDateTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
-	_ = sel
-	res := graphql.MarshalTime(v)
-	if res == graphql.Null {
-		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
-			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
-		}
-	}
-	return res
-}
-
-func (ec *executionContext) unmarshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx context.Context, v any) (model.EventType, error) {
-	var res model.EventType
-	err := res.UnmarshalGQL(v)
-	return res, graphql.ErrorOnPath(ctx, err)
-}
-
-func (ec *executionContext) marshalNEventType2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventType(ctx context.Context, sel ast.SelectionSet, v model.EventType) graphql.Marshaler {
-	return v
-}
-
 func (ec *executionContext) unmarshalNEventTypeFilter2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐEventTypeFilter(ctx context.Context, v any) (*model.EventTypeFilter, error) {
 	res, err := ec.unmarshalInputEventTypeFilter(ctx, v)
 	return &res, graphql.ErrorOnPath(ctx, err)
@@ -4993,10 +7624,78 @@ func (ec *executionContext) marshalNLocation2githubᚗcomᚋcouchcryptidᚋstorm
 	return ec._Location(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNMagnitudeBucketGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMagnitudeBucketGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.MagnitudeBucketGroup) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNMagnitudeBucketGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMagnitudeBucketGroup(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNMagnitudeBucketGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMagnitudeBucketGroup(ctx context.Context, sel ast.SelectionSet, v *model.MagnitudeBucketGroup) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._MagnitudeBucketGroup(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNMeasurement2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐMeasurement(ctx context.Context, sel ast.SelectionSet, v model.Measurement) graphql.Marshaler {
 	return ec._Measurement(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNParsedLocation2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐParsedLocation(ctx context.Context, sel ast.SelectionSet, v model.ParsedLocation) graphql.Marshaler {
+	return ec._ParsedLocation(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNParsedLocation2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐParsedLocation(ctx context.Context, sel ast.SelectionSet, v *model.ParsedLocation) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ParsedLocation(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNQueryMeta2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐQueryMeta(ctx context.Context, sel ast.SelectionSet, v *model.QueryMeta) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
@@ -5017,6 +7716,60 @@ func (ec *executionContext) marshalNSeverity2githubᚗcomᚋcouchcryptidᚋstorm
 	return v
 }
 
+func (ec *executionContext) marshalNSeverityGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverityGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.SeverityGroup) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNSeverityGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverityGroup(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNSeverityGroup2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverityGroup(ctx context.Context, sel ast.SelectionSet, v *model.SeverityGroup) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._SeverityGroup(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNStateGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStateGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.StateGroup) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -5170,6 +7923,23 @@ func (ec *executionContext) marshalNString2string(ctx context.Context, sel ast.S
 	return res
 }
 
+func (ec *executionContext) unmarshalNTimeGranularity2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGranularity(ctx context.Context, v any) (model.TimeGranularity, error) {
+	tmp, err := graphql.UnmarshalString(v)
+	res := model.TimeGranularity(tmp)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTimeGranularity2githubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGranularity(ctx context.Context, sel ast.SelectionSet, v model.TimeGranularity) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalString(string(v))
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNTimeGroup2ᚕᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeGroupᚄ(ctx context.Context, sel ast.SelectionSet, v []*model.TimeGroup) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -5512,6 +8282,13 @@ func (ec *executionContext) marshalOBoolean2ᚖbool(ctx context.Context, sel ast
 	return res
 }
 
+func (ec *executionContext) marshalODataTimeExtent2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐDataTimeExtent(ctx context.Context, sel ast.SelectionSet, v *model.DataTimeExtent) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._DataTimeExtent(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalODateTime2ᚖtimeᚐTime(ctx context.Context, v any) (*time.Time, error) {
 	if v == nil {
 		return nil, nil
@@ -5613,6 +8390,42 @@ func (ec *executionContext) unmarshalOEventTypeFilter2ᚕᚖgithubᚗcomᚋcouch
 	return res, nil
 }
 
+func (ec *executionContext) unmarshalOFloat2ᚕfloat64ᚄ(ctx context.Context, v any) ([]float64, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []any
+	vSlice = graphql.CoerceList(v)
+	var err error
+	res := make([]float64, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNFloat2float64(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOFloat2ᚕfloat64ᚄ(ctx context.Context, sel ast.SelectionSet, v []float64) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	for i := range v {
+		ret[i] = ec.marshalNFloat2float64(ctx, sel, v[i])
+	}
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) unmarshalOFloat2ᚖfloat64(ctx context.Context, v any) (*float64, error) {
 	if v == nil {
 		return nil, nil
@@ -5630,6 +8443,20 @@ func (ec *executionContext) marshalOFloat2ᚖfloat64(ctx context.Context, sel as
 	return graphql.WrapContextMarshaler(ctx, res)
 }
 
+func (ec *executionContext) marshalOGeo2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeo(ctx context.Context, sel ast.SelectionSet, v *model.Geo) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Geo(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalOGeoBounds2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeoBounds(ctx context.Context, sel ast.SelectionSet, v *model.GeoBounds) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._GeoBounds(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOGeoRadiusFilter2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐGeoRadiusFilter(ctx context.Context, v any) (*model.GeoRadiusFilter, error) {
 	if v == nil {
 		return nil, nil
@@ -5638,6 +8465,21 @@ func (ec *executionContext) unmarshalOGeoRadiusFilter2ᚖgithubᚗcomᚋcouchcry
 	return &res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalOHourOfDayRange2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐHourOfDayRange(ctx context.Context, v any) (*model.HourOfDayRange, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputHourOfDayRange(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOIngestionGap2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐIngestionGap(ctx context.Context, sel ast.SelectionSet, v *model.IngestionGap) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._IngestionGap(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v any) (*int, error) {
 	if v == nil {
 		return nil, nil
@@ -5728,6 +8570,22 @@ func (ec *executionContext) marshalOSeverity2ᚕgithubᚗcomᚋcouchcryptidᚋst
 	return ret
 }
 
+func (ec *executionContext) unmarshalOSeverity2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverity(ctx context.Context, v any) (*model.Severity, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var res = new(model.Severity)
+	err := res.UnmarshalGQL(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOSeverity2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSeverity(ctx context.Context, sel ast.SelectionSet, v *model.Severity) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return v
+}
+
 func (ec *executionContext) unmarshalOSortField2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐSortField(ctx context.Context, v any) (*model.SortField, error) {
 	if v == nil {
 		return nil, nil
@@ -5766,6 +8624,13 @@ func (ec *executionContext) marshalOSortOrder2ᚖgithubᚗcomᚋcouchcryptidᚋs
 	return res
 }
 
+func (ec *executionContext) marshalOStormReport2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐStormReport(ctx context.Context, sel ast.SelectionSet, v *model.StormReport) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._StormReport(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalOString2ᚕstringᚄ(ctx context.Context, v any) ([]string, error) {
 	if v == nil {
 		return nil, nil
@@ -5820,6 +8685,14 @@ func (ec *executionContext) marshalOString2ᚖstring(ctx context.Context, sel as
 	return res
 }
 
+func (ec *executionContext) unmarshalOTimeRange2ᚖgithubᚗcomᚋcouchcryptidᚋstormᚑdataᚑapiᚋinternalᚋmodelᚐTimeRange(ctx context.Context, v any) (*model.TimeRange, error) {
+	if v == nil {
+		return nil, nil
+	}
+	res, err := ec.unmarshalInputTimeRange(ctx, v)
+	return &res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalO__EnumValue2ᚕgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐEnumValueᚄ(ctx context.Context, sel ast.SelectionSet, v []introspection.EnumValue) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
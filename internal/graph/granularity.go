@@ -0,0 +1,20 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// MaxHourlySpan is the longest timeRange still aggregated into hourly byHour
+// buckets. A week is 168 buckets, about what a chart can show legibly; longer
+// windows switch to daily buckets instead of returning thousands of groups.
+const MaxHourlySpan = 7 * 24 * time.Hour
+
+// byHourGranularity picks the byHour bucket width for a time range.
+func byHourGranularity(tr model.TimeRange) model.TimeGranularity {
+	if tr.To.Sub(tr.From) > MaxHourlySpan {
+		return model.TimeGranularityDay
+	}
+	return model.TimeGranularityHour
+}
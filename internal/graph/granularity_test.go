@@ -0,0 +1,26 @@
+package graph
+
+import (
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestByHourGranularity(t *testing.T) {
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		span time.Duration
+		want model.TimeGranularity
+	}{
+		{24 * time.Hour, model.TimeGranularityHour},
+		{MaxHourlySpan, model.TimeGranularityHour},
+		{MaxHourlySpan + time.Hour, model.TimeGranularityDay},
+		{30 * 24 * time.Hour, model.TimeGranularityDay},
+	}
+	for _, tt := range tests {
+		got := byHourGranularity(model.TimeRange{From: from, To: from.Add(tt.span)})
+		assert.Equal(t, tt.want, got, "span=%s", tt.span)
+	}
+}
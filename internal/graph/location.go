@@ -0,0 +1,108 @@
+package graph
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+)
+
+// rawLocationPattern matches NWS relative locations: a distance in miles, a
+// compass direction of up to three points, and the place name.
+var rawLocationPattern = regexp.MustCompile(`^\s*(\d+(?:\.\d+)?)\s+(N|NNE|NE|ENE|E|ESE|SE|SSE|S|SSW|SW|WSW|W|WNW|NW|NNW)\s+(.+?)\s*$`)
+
+// parsedLocation returns the structured components of loc. Stored components
+// win; rows ingested before the ETL parsed locations fall back to parsing Raw.
+func parsedLocation(loc *model.Location) *model.ParsedLocation {
+	if loc.Distance != nil || loc.Direction != nil {
+		return &model.ParsedLocation{Name: loc.Name, Distance: loc.Distance, Direction: loc.Direction}
+	}
+	if parsed := parseRawLocation(loc.Raw); parsed != nil {
+		return parsed
+	}
+	name := loc.Name
+	if name == "" {
+		name = loc.Raw
+	}
+	return &model.ParsedLocation{Name: name}
+}
+
+// parseRawLocation parses "2 NW Springfield" into its components, returning
+// nil when raw does not follow the "N DIR TOWN" pattern.
+func parseRawLocation(raw string) *model.ParsedLocation {
+	m := rawLocationPattern.FindStringSubmatch(raw)
+	if m == nil {
+		return nil
+	}
+	distance, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return nil
+	}
+	direction := m[2]
+	return &model.ParsedLocation{Name: m[3], Distance: &distance, Direction: &direction}
+}
+
+// resolveNearPlace turns filter.NearPlace into a near filter centred on the
+// place, located from stored reports naming it. The radius is left for
+// ValidateFilter to default. A name found in several states is rejected with
+// the candidates so the client can add the state. The lookup only runs once
+// the place name and time range pass their own checks, so a filter that
+// ValidateFilter would reject can't cost a query.
+func (r *Resolver) resolveNearPlace(ctx context.Context, filter *model.StormReportFilter, limits Limits) error {
+	if filter.NearPlace == nil {
+		return nil
+	}
+	if filter.Near != nil {
+		return reject(ruleLocationConflict, "near and nearPlace cannot both be set")
+	}
+	name, state, err := parsePlaceName(*filter.NearPlace)
+	if err != nil {
+		return err
+	}
+	if filter.TimeRange == nil {
+		return reject(ruleTimeRange, "timeRange is required")
+	}
+	if err := validateTimeRange(filter.TimeRange, limits); err != nil {
+		return err
+	}
+	places, err := r.Store.PlaceCandidates(ctx, name, state)
+	if err != nil {
+		return err
+	}
+	switch len(places) {
+	case 0:
+		return reject(ruleNearPlace, "nearPlace: no reports found for %q", *filter.NearPlace)
+	case 1:
+		filter.Near = &model.GeoRadiusFilter{Lat: places[0].Lat, Lon: places[0].Lon}
+		return nil
+	}
+	candidates := make([]string, len(places))
+	for i, p := range places {
+		candidates[i] = p.Name + ", " + p.State
+	}
+	return reject(ruleNearPlace, "nearPlace %q is ambiguous; add the state, e.g. one of: %s",
+		*filter.NearPlace, strings.Join(candidates, "; "))
+}
+
+// parsePlaceName splits "Omaha, NE" into a place name and normalized state
+// code. The state is optional; a suffix after the last comma must be a known
+// code.
+func parsePlaceName(place string) (name, state string, err error) {
+	if len(place) > MaxNearPlaceLength {
+		return "", "", reject(ruleNearPlace, "nearPlace must be at most %d characters", MaxNearPlaceLength)
+	}
+	name = strings.TrimSpace(place)
+	if i := strings.LastIndex(name, ","); i >= 0 {
+		code, ok := normalizeStateCode(name[i+1:])
+		if !ok {
+			return "", "", reject(ruleStateCode, "nearPlace: unknown state code %q", strings.TrimSpace(name[i+1:]))
+		}
+		name, state = strings.TrimSpace(name[:i]), code
+	}
+	if name == "" {
+		return "", "", reject(ruleNearPlace, "nearPlace must name a place")
+	}
+	return name, state, nil
+}
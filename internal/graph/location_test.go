@@ -0,0 +1,123 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRawLocation(t *testing.T) {
+	tests := []struct {
+		raw       string
+		name      string
+		distance  float64
+		direction string
+	}{
+		{"2 NW Springfield", "Springfield", 2, "NW"},
+		{"1.5 ESE Fort Worth", "Fort Worth", 1.5, "ESE"},
+		{"  10 N  Ada  ", "Ada", 10, "N"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			got := parseRawLocation(tt.raw)
+			require.NotNil(t, got)
+			assert.Equal(t, tt.name, got.Name)
+			require.NotNil(t, got.Distance)
+			assert.InDelta(t, tt.distance, *got.Distance, 0)
+			require.NotNil(t, got.Direction)
+			assert.Equal(t, tt.direction, *got.Direction)
+		})
+	}
+
+	for _, raw := range []string{"Springfield", "2 Springfield", "2 XY Springfield", "NW Springfield", ""} {
+		assert.Nil(t, parseRawLocation(raw), "raw=%q", raw)
+	}
+}
+
+func TestParsedLocation(t *testing.T) {
+	distance, direction := 3.0, "S"
+
+	t.Run("stored components win", func(t *testing.T) {
+		got := parsedLocation(&model.Location{Raw: "2 NW Springfield", Name: "Springfield", Distance: &distance, Direction: &direction})
+		assert.Equal(t, &model.ParsedLocation{Name: "Springfield", Distance: &distance, Direction: &direction}, got)
+	})
+
+	t.Run("legacy row parses raw", func(t *testing.T) {
+		got := parsedLocation(&model.Location{Raw: "2 NW Springfield", Name: "2 NW Springfield"})
+		require.NotNil(t, got.Distance)
+		assert.Equal(t, "Springfield", got.Name)
+		assert.InDelta(t, 2.0, *got.Distance, 0)
+	})
+
+	t.Run("at the place", func(t *testing.T) {
+		got := parsedLocation(&model.Location{Raw: "Springfield", Name: "Springfield"})
+		assert.Equal(t, &model.ParsedLocation{Name: "Springfield"}, got)
+	})
+
+	t.Run("empty name falls back to raw", func(t *testing.T) {
+		got := parsedLocation(&model.Location{Raw: "Near Springfield"})
+		assert.Equal(t, "Near Springfield", got.Name)
+	})
+}
+
+func TestParsePlaceName(t *testing.T) {
+	tests := []struct {
+		place, name, state string
+	}{
+		{"Norman", "Norman", ""},
+		{"  Omaha, ne ", "Omaha", "NE"},
+		{"Carter Lake,IA", "Carter Lake", "IA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.place, func(t *testing.T) {
+			name, state, err := parsePlaceName(tt.place)
+			require.NoError(t, err)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.state, state)
+		})
+	}
+
+	for _, place := range []string{"", "  ", ", NE", "Omaha, Nebraska"} {
+		t.Run("invalid "+place, func(t *testing.T) {
+			_, _, err := parsePlaceName(place)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestResolveNearPlace_ConflictsWithNear(t *testing.T) {
+	place := "Norman, OK"
+	filter := &model.StormReportFilter{
+		NearPlace: &place,
+		Near:      &model.GeoRadiusFilter{Lat: 35.2, Lon: -97.4},
+	}
+	err := (&Resolver{}).resolveNearPlace(context.Background(), filter, Limits{})
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, ruleLocationConflict, verr.Rule)
+}
+
+func TestResolveNearPlace_ChecksBeforeLookup(t *testing.T) {
+	long := strings.Repeat("a", MaxNearPlaceLength+1)
+	norman := "Norman, OK"
+	inverted := validFilter()
+	inverted.TimeRange.To = inverted.TimeRange.From
+
+	for name, filter := range map[string]*model.StormReportFilter{
+		"long name":         {NearPlace: &long, TimeRange: validFilter().TimeRange},
+		"missing timeRange": {NearPlace: &norman},
+		"bad timeRange":     {NearPlace: &norman, TimeRange: inverted.TimeRange},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeStore{}
+			err := (&Resolver{Store: fake}).resolveNearPlace(context.Background(), filter, Limits{})
+			var verr *ValidationError
+			require.ErrorAs(t, err, &verr)
+			assert.Empty(t, fake.calls, "no place lookup")
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+)
+
+// Reasons recorded by observeLimit.
+const (
+	limitDefaulted = "defaulted"
+	limitExceeded  = "exceeded"
+)
+
+// observeLimit counts stormReports requests whose limit ValidateFilter will
+// default to MaxPageSize (no limit given) or reject (limit above it). Clients
+// that rely on the default may not realize they only see the first page, so
+// the counter shows whether the cap is routinely truncating results.
+func (r *Resolver) observeLimit(ctx context.Context, limit *int) {
+	var reason string
+	switch {
+	case limit == nil:
+		reason = limitDefaulted
+	case *limit > MaxPageSize:
+		reason = limitExceeded
+	default:
+		return
+	}
+
+	if r.Metrics != nil {
+		r.Metrics.GraphQLLimitCapped.WithLabelValues(reason).Inc()
+	}
+	if r.Logger != nil {
+		var operation string
+		if graphql.HasOperationContext(ctx) {
+			operation = graphql.GetOperationContext(ctx).OperationName
+		}
+		r.Logger.DebugContext(ctx, "stormReports limit capped",
+			"reason", reason,
+			"max_page_size", MaxPageSize,
+			"operation", operation,
+			"client_ip", observability.ClientIPFromContext(ctx),
+		)
+	}
+}
@@ -0,0 +1,36 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveLimit(t *testing.T) {
+	var logs bytes.Buffer
+	m := observability.NewTestMetrics()
+	r := &Resolver{
+		Metrics: m,
+		Logger:  slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug})),
+	}
+	within, over := MaxPageSize, MaxPageSize+1
+
+	r.observeLimit(context.Background(), nil)
+	r.observeLimit(context.Background(), nil)
+	r.observeLimit(context.Background(), &within)
+	r.observeLimit(context.Background(), &over)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.GraphQLLimitCapped.WithLabelValues(limitDefaulted)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.GraphQLLimitCapped.WithLabelValues(limitExceeded)), 0)
+	assert.Equal(t, 3, bytes.Count(logs.Bytes(), []byte("stormReports limit capped")))
+	assert.Contains(t, logs.String(), "reason=exceeded")
+}
+
+func TestObserveLimit_NoInstrumentation(t *testing.T) {
+	assert.NotPanics(t, func() { (&Resolver{}).observeLimit(context.Background(), nil) })
+}
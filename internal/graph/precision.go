@@ -0,0 +1,39 @@
+package graph
+
+import "math"
+
+// Default output precision. Five decimal places of latitude/longitude is
+// about a meter; NWS magnitudes are never finer than hundredths.
+const (
+	DefaultCoordinateDecimals = 5
+	DefaultMagnitudeDecimals  = 2
+)
+
+// Precision controls how many decimal places geo.lat, geo.lon, and
+// measurement.magnitude carry in responses. Rounding trims float noise
+// (e.g. 32.750000000001) that some client JSON parsers mishandle, and
+// shortens the payload. Zero fields mean the package defaults.
+type Precision struct {
+	CoordinateDecimals int
+	MagnitudeDecimals  int
+}
+
+func (p Precision) coordinateDecimals() int {
+	if p.CoordinateDecimals > 0 {
+		return p.CoordinateDecimals
+	}
+	return DefaultCoordinateDecimals
+}
+
+func (p Precision) magnitudeDecimals() int {
+	if p.MagnitudeDecimals > 0 {
+		return p.MagnitudeDecimals
+	}
+	return DefaultMagnitudeDecimals
+}
+
+// roundTo rounds v to the given number of decimal places, half away from zero.
+func roundTo(v float64, decimals int) float64 {
+	scale := math.Pow10(decimals)
+	return math.Round(v*scale) / scale
+}
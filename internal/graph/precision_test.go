@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"context"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundTo(t *testing.T) {
+	assert.InDelta(t, 32.75, roundTo(32.750000000001, 5), 0)
+	assert.InDelta(t, -97.12346, roundTo(-97.123456, 5), 0)
+	assert.InDelta(t, 1.75, roundTo(1.7500000001, 2), 0)
+	assert.InDelta(t, 0.3, roundTo(0.1+0.2, 2), 0)
+}
+
+func TestPrecision_Defaults(t *testing.T) {
+	assert.Equal(t, DefaultCoordinateDecimals, Precision{}.coordinateDecimals())
+	assert.Equal(t, DefaultMagnitudeDecimals, Precision{}.magnitudeDecimals())
+	assert.Equal(t, 3, Precision{CoordinateDecimals: 3}.coordinateDecimals())
+	assert.Equal(t, 1, Precision{MagnitudeDecimals: 1}.magnitudeDecimals())
+}
+
+func TestPrecisionResolvers(t *testing.T) {
+	r := &Resolver{Precision: Precision{CoordinateDecimals: 2, MagnitudeDecimals: 1}}
+	geo := &model.Geo{Lat: 32.750000000001, Lon: -97.3349}
+
+	lat, err := r.Geo().Lat(context.Background(), geo)
+	require.NoError(t, err)
+	assert.InDelta(t, 32.75, lat, 0)
+
+	lon, err := r.Geo().Lon(context.Background(), geo)
+	require.NoError(t, err)
+	assert.InDelta(t, -97.33, lon, 0)
+
+	mag, err := r.Measurement().Magnitude(context.Background(), &model.Measurement{Magnitude: 1.749})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.7, mag, 0)
+
+	assert.InDelta(t, 32.750000000001, geo.Lat, 0, "resolver must not mutate the model")
+}
@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+)
+
+// observeRejection counts and logs a filter that failed validation, so
+// operators can tell a mis-tuned limit (many clients hitting one rule) from a
+// single misbehaving client. Errors other than ValidationError are ignored.
+func (r *Resolver) observeRejection(ctx context.Context, filter *model.StormReportFilter, err error) {
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		return
+	}
+
+	if r.Metrics != nil {
+		r.Metrics.GraphQLValidationRejections.WithLabelValues(verr.Rule).Inc()
+	}
+	if r.Logger != nil {
+		var operation string
+		if graphql.HasOperationContext(ctx) {
+			operation = graphql.GetOperationContext(ctx).OperationName
+		}
+		r.Logger.InfoContext(ctx, "filter rejected",
+			"rule", verr.Rule,
+			"error", verr.Error(),
+			"filter", loggableFilter(filter),
+			"operation", operation,
+			"client_ip", observability.ClientIPFromContext(ctx),
+		)
+	}
+}
+
+// loggableFilter renders filter as JSON for logs. Search coordinates are
+// rounded to whole degrees (roughly 100 km) so logs show the region being
+// queried without pinpointing a user's location.
+func loggableFilter(filter *model.StormReportFilter) string {
+	f := *filter
+	if f.Near != nil {
+		near := *f.Near
+		near.Lat, near.Lon = math.Round(near.Lat), math.Round(near.Lon)
+		f.Near = &near
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
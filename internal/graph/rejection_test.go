@@ -0,0 +1,64 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFilter_ReportsRule(t *testing.T) {
+	f := validFilter()
+	f.TimeRange.To = f.TimeRange.From
+	err := ValidateFilter(f, Limits{})
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	assert.Equal(t, ruleTimeRange, verr.Rule)
+	assert.Equal(t, "timeRange.to must be after timeRange.from", err.Error())
+}
+
+func TestObserveRejection(t *testing.T) {
+	var logs bytes.Buffer
+	m := observability.NewTestMetrics()
+	r := &Resolver{
+		Metrics: m,
+		Logger:  slog.New(slog.NewJSONHandler(&logs, nil)),
+	}
+	radius := 500.0
+	f := validFilter()
+	f.Near = &model.GeoRadiusFilter{Lat: 32.7767, Lon: -96.797, RadiusMiles: &radius}
+
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	r.observeRejection(context.Background(), f, err)
+	r.observeRejection(context.Background(), f, errors.New("database unavailable"))
+
+	assert.InDelta(t, 1, testutil.ToFloat64(m.GraphQLValidationRejections.WithLabelValues(ruleRadius)), 0)
+
+	var entry struct {
+		Msg    string `json:"msg"`
+		Rule   string `json:"rule"`
+		Error  string `json:"error"`
+		Filter string `json:"filter"`
+	}
+	require.NoError(t, json.Unmarshal(logs.Bytes(), &entry), "only the validation error is logged")
+	assert.Equal(t, "filter rejected", entry.Msg)
+	assert.Equal(t, ruleRadius, entry.Rule)
+	assert.Equal(t, "near.radiusMiles exceeds maximum of 200", entry.Error)
+	assert.Contains(t, entry.Filter, `"near":{"lat":33,"lon":-97,"radiusMiles":500}`, "coordinates are coarsened")
+	assert.InDelta(t, 32.7767, f.Near.Lat, 0, "the filter itself is untouched")
+}
+
+func TestObserveRejection_NoInstrumentation(t *testing.T) {
+	err := reject(ruleLimit, "limit exceeds maximum of %d", MaxPageSize)
+	assert.NotPanics(t, func() { (&Resolver{}).observeRejection(context.Background(), validFilter(), err) })
+}
@@ -1,10 +1,69 @@
 package graph
 
-import "github.com/couchcryptid/storm-data-api/internal/store"
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/clock"
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-api/internal/store"
+)
 
 //go:generate go run github.com/99designs/gqlgen generate
 
-// Resolver is the root resolver for the GraphQL schema.
+// ReportStore is the part of *store.Store the resolvers query.
+type ReportStore interface {
+	ListStormReports(ctx context.Context, filter *model.StormReportFilter, fields ...string) ([]*model.StormReport, int, error)
+	StormReportByID(ctx context.Context, id string) (*model.StormReport, error)
+	Aggregations(ctx context.Context, filter *model.StormReportFilter, granularity model.TimeGranularity) (*store.AggResult, error)
+	Extent(ctx context.Context, filter *model.StormReportFilter) (*store.Extent, error)
+	LastUpdated(ctx context.Context) (*time.Time, error)
+	EventTimeExtent(ctx context.Context) (*model.DataTimeExtent, error)
+	LargestIngestionGap(ctx context.Context, since time.Time) (*model.IngestionGap, error)
+	DistinctEventTypes(ctx context.Context, tr model.TimeRange) ([]model.EventType, error)
+	PlaceCandidates(ctx context.Context, name, state string) ([]store.Place, error)
+}
+
+// Resolver is the root resolver for the GraphQL schema. Metrics and Logger
+// are optional; resolvers skip instrumentation when they are nil. Clock
+// defaults to the system clock.
 type Resolver struct {
-	Store *store.Store
+	Store     ReportStore
+	Limits    Limits
+	Precision Precision
+	Metrics   *observability.Metrics
+	Logger    *slog.Logger
+
+	// FieldMask is the per-caller field mask also installed as field
+	// middleware. Resolvers consult it for values derived from masked fields.
+	FieldMask FieldMask
+
+	// DefaultTimeRange is the window, ending now, used for filters that omit
+	// timeRange. Zero makes timeRange required.
+	DefaultTimeRange time.Duration
+
+	// QueryConcurrency caps how many database queries one stormReports call
+	// runs at once (reports and count, aggregations, meta, centroid), so a
+	// single request can't hold most of the pool. Zero runs them all at once.
+	QueryConcurrency int
+
+	Clock clock.Clock
+}
+
+// limits returns the limits for the caller in ctx, measuring time on r's
+// clock.
+func (r *Resolver) limits(ctx context.Context) Limits {
+	l := r.Limits.forCaller(ctx)
+	l.now = r.clock().Now
+	return l
+}
+
+// clock returns r.Clock, or the system clock when it is unset.
+func (r *Resolver) clock() clock.Clock {
+	if r.Clock != nil {
+		return r.Clock
+	}
+	return clock.Real{}
 }
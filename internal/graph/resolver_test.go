@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore is a ReportStore whose queries each hold a slot for hold, so
+// tests can observe how many run at once.
+type fakeStore struct {
+	hold time.Duration
+
+	mu        sync.Mutex
+	active    int
+	maxActive int
+	calls     []string
+}
+
+func (f *fakeStore) query(name string) {
+	f.mu.Lock()
+	f.calls = append(f.calls, name)
+	f.active++
+	f.maxActive = max(f.maxActive, f.active)
+	f.mu.Unlock()
+
+	time.Sleep(f.hold)
+
+	f.mu.Lock()
+	f.active--
+	f.mu.Unlock()
+}
+
+func (f *fakeStore) ListStormReports(context.Context, *model.StormReportFilter, ...string) ([]*model.StormReport, int, error) {
+	f.query("ListStormReports")
+	return nil, 0, nil
+}
+
+func (f *fakeStore) StormReportByID(context.Context, string) (*model.StormReport, error) {
+	f.query("StormReportByID")
+	return nil, nil
+}
+
+func (f *fakeStore) Aggregations(context.Context, *model.StormReportFilter, model.TimeGranularity) (*store.AggResult, error) {
+	f.query("Aggregations")
+	return &store.AggResult{}, nil
+}
+
+func (f *fakeStore) Extent(context.Context, *model.StormReportFilter) (*store.Extent, error) {
+	f.query("Extent")
+	return &store.Extent{}, nil
+}
+
+func (f *fakeStore) LastUpdated(context.Context) (*time.Time, error) {
+	f.query("LastUpdated")
+	return nil, nil
+}
+
+func (f *fakeStore) EventTimeExtent(context.Context) (*model.DataTimeExtent, error) {
+	f.query("EventTimeExtent")
+	return nil, nil
+}
+
+func (f *fakeStore) LargestIngestionGap(context.Context, time.Time) (*model.IngestionGap, error) {
+	f.query("LargestIngestionGap")
+	return nil, nil
+}
+
+func (f *fakeStore) DistinctEventTypes(context.Context, model.TimeRange) ([]model.EventType, error) {
+	f.query("DistinctEventTypes")
+	return nil, nil
+}
+
+func (f *fakeStore) PlaceCandidates(context.Context, string, string) ([]store.Place, error) {
+	f.query("PlaceCandidates")
+	return nil, nil
+}
+
+// postQuery runs query against a server backed by r and returns the body.
+func postQuery(t *testing.T, r *Resolver, query string) string {
+	t.Helper()
+	srv := NewServer(NewExecutableSchema(Config{Resolvers: r, Complexity: NewComplexityRoot()}))
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/query", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+// fanOutQuery asks stormReports for reports, aggregations, meta and the
+// centroid: four concurrent store queries.
+const fanOutQuery = `{
+  stormReports(filter: {timeRange: {from: "2024-04-26T00:00:00Z", to: "2024-04-27T00:00:00Z"}}) {
+    totalCount
+    aggregations { byState { state count } }
+    meta { lastUpdated centroid { lat } }
+  }
+}`
+
+func TestStormReports_QueryConcurrency(t *testing.T) {
+	for _, limit := range []int{1, 2} {
+		fake := &fakeStore{hold: 20 * time.Millisecond}
+		body := postQuery(t, &Resolver{Store: fake, QueryConcurrency: limit}, fanOutQuery)
+
+		assert.NotContains(t, body, `"errors"`)
+		assert.Len(t, fake.calls, 4, "reports, aggregations, meta and centroid")
+		assert.Equal(t, limit, fake.maxActive, "at most %d queries at once", limit)
+	}
+}
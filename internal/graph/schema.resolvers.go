@@ -8,33 +8,80 @@ package graph
 import (
 	"context"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/store"
 	"golang.org/x/sync/errgroup"
 )
 
+// Lat is the resolver for the lat field.
+func (r *geoResolver) Lat(ctx context.Context, obj *model.Geo) (float64, error) {
+	return roundTo(obj.Lat, r.Precision.coordinateDecimals()), nil
+}
+
+// Lon is the resolver for the lon field.
+func (r *geoResolver) Lon(ctx context.Context, obj *model.Geo) (float64, error) {
+	return roundTo(obj.Lon, r.Precision.coordinateDecimals()), nil
+}
+
+// ParsedLocation is the resolver for the parsedLocation field.
+func (r *locationResolver) ParsedLocation(ctx context.Context, obj *model.Location) (*model.ParsedLocation, error) {
+	return parsedLocation(obj), nil
+}
+
+// Magnitude is the resolver for the magnitude field.
+func (r *measurementResolver) Magnitude(ctx context.Context, obj *model.Measurement) (float64, error) {
+	return roundTo(obj.Magnitude, r.Precision.magnitudeDecimals()), nil
+}
+
 // StormReports is the resolver for the stormReports field.
 func (r *queryResolver) StormReports(ctx context.Context, filter model.StormReportFilter) (*model.StormReportsResult, error) {
-	if err := ValidateFilter(&filter); err != nil {
+	r.observeLimit(ctx, filter.Limit)
+	applyDefaultTimeRange(&filter, r.DefaultTimeRange, r.clock().Now())
+	limits := r.limits(ctx)
+	if err := r.FieldMask.checkMaskedFilters(ctx, &filter); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	if err := r.resolveNearPlace(ctx, &filter, limits); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	if err := ValidateFilter(&filter, limits); err != nil {
+		r.observeRejection(ctx, &filter, err)
 		return nil, err
 	}
 
+	granularity := byHourGranularity(*filter.TimeRange)
 	result := &model.StormReportsResult{
-		Aggregations: &model.StormAggregations{},
+		Aggregations: &model.StormAggregations{ByHourGranularity: granularity},
 		Meta:         &model.QueryMeta{},
 	}
 
-	g, gCtx := errgroup.WithContext(ctx)
 	fields := collectFields(ctx)
+	if err := r.Limits.checkAggregationDimensions(fields); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	if err := r.Limits.checkNarrowing(&filter, fields); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	g, gCtx := errgroup.WithContext(ctx)
+	if r.QueryConcurrency > 0 {
+		g.SetLimit(r.QueryConcurrency)
+	}
 
 	// Reports + count
 	g.Go(func() error {
-		reports, count, err := r.Store.ListStormReports(gCtx, &filter)
+		reports, count, err := r.Store.ListStormReports(gCtx, &filter, requestedReportFields(fields)...)
 		if err != nil {
 			return err
 		}
 		result.Reports = reports
 		result.TotalCount = count
 		result.Aggregations.TotalCount = count
+		result.Meta.Density = reportDensity(&filter, count)
 		offset := 0
 		if filter.Offset != nil {
 			offset = *filter.Offset
@@ -43,13 +90,24 @@ func (r *queryResolver) StormReports(ctx context.Context, filter model.StormRepo
 		return nil
 	})
 
-	// Aggregations (if requested)
-	if fields["aggregations"] {
+	// Aggregations (if any group breakdown is requested)
+	if needsAggregationQuery(fields) {
 		g.Go(func() error {
-			agg, err := r.Store.Aggregations(gCtx, &filter)
+			agg, err := r.Store.Aggregations(gCtx, &filter, granularity)
+			if store.IsStatementTimeout(err) {
+				// Keep the reports usable; the client can retry aggregations
+				// over a narrower window.
+				graphql.AddErrorf(ctx, "aggregations timed out; retry with a narrower timeRange")
+				result.Meta.AggregationsTimedOut = true
+				return nil
+			}
 			if err != nil {
 				return err
 			}
+			if agg.SampleFraction > 0 {
+				result.Aggregations.Sampled = true
+				result.Aggregations.SampleFraction = &agg.SampleFraction
+			}
 			if fields["aggregations.byEventType"] {
 				result.Aggregations.ByEventType = agg.ByEventType
 			}
@@ -59,6 +117,18 @@ func (r *queryResolver) StormReports(ctx context.Context, filter model.StormRepo
 			if fields["aggregations.byHour"] {
 				result.Aggregations.ByHour = agg.ByHour
 			}
+			if fields["aggregations.bySeverity"] {
+				result.Aggregations.BySeverity = agg.BySeverity
+			}
+			if fields["aggregations.byDayOfWeek"] {
+				result.Aggregations.ByDayOfWeek = agg.ByDayOfWeek
+			}
+			if fields["aggregations.byMagnitudeBucket"] {
+				result.Aggregations.ByMagnitudeBucket = agg.ByMagnitudeBucket
+			}
+			if filter.MaxAggregationRows != nil {
+				result.Aggregations.Truncated = truncateAggregationRows(result.Aggregations, *filter.MaxAggregationRows)
+			}
 			return nil
 		})
 	}
@@ -66,26 +136,109 @@ func (r *queryResolver) StormReports(ctx context.Context, filter model.StormRepo
 	// Meta (if requested)
 	if fields["meta"] {
 		g.Go(func() error {
-			return applyMeta(gCtx, r.Store, result.Meta)
+			return applyMeta(gCtx, r.Store, result.Meta, r.clock())
+		})
+	}
+	if fields["meta.centroid"] {
+		g.Go(func() error {
+			ext, err := r.Store.Extent(gCtx, &filter)
+			if err != nil {
+				return err
+			}
+			result.Meta.Centroid = ext.Centroid
+			return nil
 		})
 	}
 
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+	r.cacheStormReports(ctx, &filter, fields, result.Meta)
 	return result, nil
 }
 
+// StormReport is the resolver for the stormReport field.
+func (r *queryResolver) StormReport(ctx context.Context, id string) (*model.StormReport, error) {
+	return r.Store.StormReportByID(ctx, id)
+}
+
+// StormReportsBounds is the resolver for the stormReportsBounds field.
+func (r *queryResolver) StormReportsBounds(ctx context.Context, filter model.StormReportFilter) (*model.GeoBounds, error) {
+	applyDefaultTimeRange(&filter, r.DefaultTimeRange, r.clock().Now())
+	limits := r.limits(ctx)
+	if err := r.FieldMask.checkMaskedFilters(ctx, &filter); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	if err := r.resolveNearPlace(ctx, &filter, limits); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	if err := ValidateFilter(&filter, limits); err != nil {
+		r.observeRejection(ctx, &filter, err)
+		return nil, err
+	}
+	ext, err := r.Store.Extent(ctx, &filter)
+	if err != nil {
+		return nil, err
+	}
+	return ext.Bounds, nil
+}
+
+// DataTimeExtent is the resolver for the dataTimeExtent field.
+func (r *queryResolver) DataTimeExtent(ctx context.Context) (*model.DataTimeExtent, error) {
+	return r.Store.EventTimeExtent(ctx)
+}
+
+// DistinctEventTypes is the resolver for the distinctEventTypes field.
+func (r *queryResolver) DistinctEventTypes(ctx context.Context, timeRange model.TimeRange) ([]model.EventType, error) {
+	if err := validateEventTypesRange(&timeRange, r.limits(ctx)); err != nil {
+		r.observeRejection(ctx, &model.StormReportFilter{TimeRange: &timeRange}, err)
+		return nil, err
+	}
+	return r.Store.DistinctEventTypes(ctx, timeRange)
+}
+
+// IngestionGap is the resolver for the ingestionGap field.
+func (r *queryResolver) IngestionGap(ctx context.Context, withinHours *int) (*model.IngestionGap, error) {
+	window, err := ingestionGapWindow(withinHours)
+	if err != nil {
+		r.observeRejection(ctx, &model.StormReportFilter{}, err)
+		return nil, err
+	}
+	return r.Store.LargestIngestionGap(ctx, r.clock().Now().Add(-window))
+}
+
 // EventType is the resolver for the eventType field.
 func (r *stormReportResolver) EventType(ctx context.Context, obj *model.StormReport) (string, error) {
 	return obj.EventType, nil
 }
 
+// MatchedImpactKeyword is the resolver for the matchedImpactKeyword field.
+func (r *stormReportResolver) MatchedImpactKeyword(ctx context.Context, obj *model.StormReport) (*string, error) {
+	if r.FieldMask.hides(ctx, commentsField) {
+		return nil, nil
+	}
+	return matchImpactKeyword(obj.Comments, impactKeywords(ctx)), nil
+}
+
+// Geo returns GeoResolver implementation.
+func (r *Resolver) Geo() GeoResolver { return &geoResolver{r} }
+
+// Location returns LocationResolver implementation.
+func (r *Resolver) Location() LocationResolver { return &locationResolver{r} }
+
+// Measurement returns MeasurementResolver implementation.
+func (r *Resolver) Measurement() MeasurementResolver { return &measurementResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 // StormReport returns StormReportResolver implementation.
 func (r *Resolver) StormReport() StormReportResolver { return &stormReportResolver{r} }
 
+type geoResolver struct{ *Resolver }
+type locationResolver struct{ *Resolver }
+type measurementResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type stormReportResolver struct{ *Resolver }
@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// NewServer returns a GraphQL handler for es. Besides POST it accepts
+// GraphQL-over-GET (query, variables, and operationName as URL parameters),
+// which gqlgen restricts to query operations, so CDNs can cache responses
+// carrying CacheHeaders. Automatic persisted queries keep GET URLs short;
+// large ad-hoc queries should still use POST.
+func NewServer(es graphql.ExecutableSchema) *handler.Server {
+	srv := handler.New(es)
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+	return srv
+}
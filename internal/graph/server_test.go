@@ -0,0 +1,56 @@
+package graph
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServer_GET(t *testing.T) {
+	srv := NewServer(NewExecutableSchema(Config{Resolvers: &Resolver{}}))
+
+	params := url.Values{
+		"query":         {"query Typename($skip: Boolean!) { __typename @skip(if: $skip) }"},
+		"variables":     {`{"skip":false}`},
+		"operationName": {"Typename"},
+	}
+	req := httptest.NewRequest(http.MethodGet, "/query?"+params.Encode(), nil)
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp struct {
+		Data map[string]string `json:"data"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, "Query", resp.Data["__typename"])
+}
+
+func TestNewServer_POST(t *testing.T) {
+	srv := NewServer(NewExecutableSchema(Config{Resolvers: &Resolver{}}))
+
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(`{"query":"{ __typename }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":{"__typename":"Query"}}`, rec.Body.String())
+}
+
+func TestNewServer_UnsupportedMethod(t *testing.T) {
+	srv := NewServer(NewExecutableSchema(Config{Resolvers: &Resolver{}}))
+
+	req := httptest.NewRequest(http.MethodPut, "/query", strings.NewReader(`{"query":"{ __typename }"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,25 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+)
+
+// stateCodes lists the two-letter USPS codes stored in location_state: the 50
+// states, DC, and the inhabited territories.
+var stateCodes = []string{
+	"AK", "AL", "AR", "AS", "AZ", "CA", "CO", "CT", "DC", "DE",
+	"FL", "GA", "GU", "HI", "IA", "ID", "IL", "IN", "KS", "KY",
+	"LA", "MA", "MD", "ME", "MI", "MN", "MO", "MP", "MS", "MT",
+	"NC", "ND", "NE", "NH", "NJ", "NM", "NV", "NY", "OH", "OK",
+	"OR", "PA", "PR", "RI", "SC", "SD", "TN", "TX", "UT", "VA",
+	"VI", "VT", "WA", "WI", "WV", "WY",
+}
+
+// normalizeStateCode trims and uppercases code, reporting whether the result
+// is a known state or territory.
+func normalizeStateCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	_, found := slices.BinarySearch(stateCodes, code)
+	return code, found
+}
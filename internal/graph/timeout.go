@@ -0,0 +1,43 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// OperationTimeout cancels the resolver context once an operation has run for
+// Timeout. Unlike http.TimeoutHandler, which only stops waiting for the
+// handler, the cancellation reaches pgx so in-flight queries are aborted and
+// their pool connections released.
+type OperationTimeout struct {
+	Timeout time.Duration
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.ResponseInterceptor
+} = OperationTimeout{}
+
+// ExtensionName implements graphql.HandlerExtension.
+func (t OperationTimeout) ExtensionName() string {
+	return "OperationTimeout"
+}
+
+// Validate implements graphql.HandlerExtension.
+func (t OperationTimeout) Validate(graphql.ExecutableSchema) error {
+	if t.Timeout <= 0 {
+		return fmt.Errorf("OperationTimeout: Timeout must be > 0")
+	}
+	return nil
+}
+
+// InterceptResponse implements graphql.ResponseInterceptor. Resolvers run
+// inside next, so they all share the deadline set here.
+func (t OperationTimeout) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	ctx, cancel := context.WithTimeout(ctx, t.Timeout)
+	defer cancel()
+	return next(ctx)
+}
@@ -0,0 +1,34 @@
+package graph
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperationTimeout_Validate(t *testing.T) {
+	require.Error(t, OperationTimeout{}.Validate(nil))
+	require.NoError(t, OperationTimeout{Timeout: time.Second}.Validate(nil))
+}
+
+func TestOperationTimeout_CancelsResolverContext(t *testing.T) {
+	var resolverCtx context.Context
+	ext := OperationTimeout{Timeout: 10 * time.Millisecond}
+
+	ext.InterceptResponse(context.Background(), func(ctx context.Context) *graphql.Response {
+		resolverCtx = ctx
+		deadline, ok := ctx.Deadline()
+		require.True(t, ok, "resolver context should carry a deadline")
+		assert.WithinDuration(t, time.Now().Add(10*time.Millisecond), deadline, 10*time.Millisecond)
+
+		<-ctx.Done()
+		assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+		return &graphql.Response{}
+	})
+
+	assert.Error(t, resolverCtx.Err(), "context is released once the response is built")
+}
@@ -1,61 +1,580 @@
 package graph
 
 import (
+	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/config"
 	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/store"
 )
 
 // Query protection limits.
 const (
 	MaxEventTypeFilters = 3
 	MaxPageSize         = 20
-	MaxRadiusMiles      = 200.0
+	MaxRadiusMiles      = config.MaxRadiusMiles
 	DefaultRadiusMiles  = 20.0
+
+	MaxIngestedWithinMinutes = 24 * 60
+
+	// DefaultIngestionGapHours and MaxIngestionGapHours bound the window
+	// ingestionGap scans.
+	DefaultIngestionGapHours = 24
+	MaxIngestionGapHours     = 7 * 24
+
+	// MaxDistinctEventTypesRange caps distinctEventTypes' timeRange; the
+	// query scans every report in the window.
+	MaxDistinctEventTypesRange = 366 * 24 * time.Hour
+
+	// MinIDPrefixLength keeps idPrefix selective: IDs are "<type>-<hash>",
+	// so a short prefix would match every report of a type.
+	MinIDPrefixLength = 10
+
+	// MaxNearPlaceLength caps nearPlace, which is looked up in the database
+	// before the rest of the filter is validated. Real place names are far
+	// shorter.
+	MaxNearPlaceLength = 100
+
+	// MaxImpactKeywords caps impactKeywords; each adds a trigram index scan.
+	MaxImpactKeywords = 10
+	// MinImpactKeywordLength is the shortest keyword the trigram index can
+	// serve; shorter patterns force a sequential scan of comments.
+	MinImpactKeywordLength = 3
+
+	// MaxMagnitudeBucketEdges caps magnitudeBucketEdges, bounding
+	// byMagnitudeBucket to MaxMagnitudeBucketEdges+1 ranges per event type.
+	MaxMagnitudeBucketEdges = 10
+
+	DefaultMaxFilterCost = 100
+
+	// DefaultMaxQueryParams keeps a filter's SQL well under PostgreSQL's
+	// 65535 bind parameter limit.
+	DefaultMaxQueryParams = 500
+
+	// DefaultMaxFutureSkew is how far timeRange.from may lie ahead of the
+	// server clock before it is treated as a client bug.
+	DefaultMaxFutureSkew = time.Minute
 )
 
+// Filter cost weights. Each list entry adds one predicate to the WHERE clause;
+// per-type groups and distance checks are weighted higher because they expand
+// into several predicates and are harder for the planner to estimate.
+const (
+	costPerListValue      = 1
+	costPerEventTypeGroup = 2
+	costPerGeoClause      = 10
+	costPerImpactKeyword  = 3
+)
+
+// Validation rules, reported as ValidationError.Rule.
+const (
+	ruleTimeRange             = "time_range"
+	ruleHourOfDayRange        = "hour_of_day_range"
+	ruleIngestedWithin        = "ingested_within"
+	ruleIngestionGapWindow    = "ingestion_gap_window"
+	ruleIDPrefix              = "id_prefix"
+	ruleImpactKeywords        = "impact_keywords"
+	ruleLocationConflict      = "location_conflict"
+	ruleNearPlace             = "near_place"
+	ruleStateCode             = "state_code"
+	ruleRadius                = "radius"
+	ruleEventTypeFilters      = "event_type_filters"
+	ruleSortField             = "sort_field"
+	ruleFilterCost            = "filter_cost"
+	ruleQueryParams           = "query_params"
+	ruleLimit                 = "limit"
+	ruleAggregationDimensions = "aggregation_dimensions"
+	ruleMagnitudeBucketEdges  = "magnitude_bucket_edges"
+	ruleMaxAggregationRows    = "max_aggregation_rows"
+	ruleNarrowingFilter       = "narrowing_filter"
+)
+
+// ValidationError is a rejected filter or selection. The message is returned
+// to the client as-is; Rule identifies the check that failed so rejections
+// can be counted and logged without parsing messages.
+type ValidationError struct {
+	Rule string
+	msg  string
+}
+
+func (e *ValidationError) Error() string { return e.msg }
+
+// reject returns a ValidationError for rule with a formatted message.
+func reject(rule, format string, args ...any) error {
+	return &ValidationError{Rule: rule, msg: fmt.Sprintf(format, args...)}
+}
+
+// Limits holds configurable query protection limits. The zero value enforces
+// the package defaults above.
+type Limits struct {
+	// MaxRadiusByType overrides MaxRadiusMiles for specific event types, e.g.
+	// a wider cap for tornado damage surveys than for hail.
+	MaxRadiusByType map[model.EventType]float64
+
+	// DefaultRadiusByType overrides DefaultRadiusMiles for near filters
+	// without a radiusMiles, e.g. a wider default for tornado searches.
+	DefaultRadiusByType map[model.EventType]float64
+
+	// MaxFilterCost caps the combined filterCost of a single filter.
+	// Zero means DefaultMaxFilterCost.
+	MaxFilterCost int
+
+	// MaxQueryParams caps the positional parameters a filter's queries may
+	// bind. Zero means DefaultMaxQueryParams.
+	MaxQueryParams int
+
+	// MaxEventTypeFilters caps eventTypeFilters entries. Zero means the
+	// MaxEventTypeFilters constant.
+	MaxEventTypeFilters int
+
+	// MaxFutureSkew is how far timeRange.from may be ahead of now, allowing
+	// for client clock skew. Zero means DefaultMaxFutureSkew. timeRange.to
+	// may be any time in the future.
+	MaxFutureSkew time.Duration
+
+	// MaxAggregationDimensions caps how many aggregation breakdowns
+	// (byEventType, byState, ...) one stormReports selection may request.
+	// Zero leaves them to the complexity limit alone.
+	MaxAggregationDimensions int
+
+	// MaxUnfilteredTimeRange is the widest timeRange a stormReports
+	// selection of reports may use without also narrowing by state, county,
+	// event type, location or ID prefix. Zero allows any range.
+	MaxUnfilteredTimeRange time.Duration
+
+	// SortFields restricts which sortBy values callers may use, keeping
+	// sorts on columns without a suitable index out of the public API.
+	// Nil allows every SortField.
+	SortFields []model.SortField
+
+	// AuthenticatedSortFields are allowed in addition to SortFields for
+	// callers that send an API key. Ignored when SortFields is nil.
+	AuthenticatedSortFields []model.SortField
+
+	// now tells the time for timeRange checks; nil means time.Now. Resolvers
+	// set it from their Clock (see Resolver.limits).
+	now func() time.Time
+}
+
+// forCaller returns the limits that apply to the caller in ctx. Callers with
+// a configured API key (see WithAPIKey) may also sort by
+// AuthenticatedSortFields.
+func (l Limits) forCaller(ctx context.Context) Limits {
+	if l.SortFields != nil && apiKeyFromContext(ctx) != "" {
+		l.SortFields = slices.Concat(l.SortFields, l.AuthenticatedSortFields)
+	}
+	return l
+}
+
+// currentTime returns the time timeRange checks measure against.
+func (l Limits) currentTime() time.Time {
+	if l.now != nil {
+		return l.now()
+	}
+	return time.Now()
+}
+
+// maxFutureSkew returns the configured allowance for a future timeRange.from.
+func (l Limits) maxFutureSkew() time.Duration {
+	if l.MaxFutureSkew > 0 {
+		return l.MaxFutureSkew
+	}
+	return DefaultMaxFutureSkew
+}
+
+// checkAggregationDimensions reports an error if fields select more
+// aggregation breakdowns than MaxAggregationDimensions allows.
+func (l Limits) checkAggregationDimensions(fields map[string]bool) error {
+	if l.MaxAggregationDimensions <= 0 {
+		return nil
+	}
+	if dims := requestedDimensions(fields); len(dims) > l.MaxAggregationDimensions {
+		return reject(ruleAggregationDimensions, "too many aggregation dimensions: requested %d (%s), maximum is %d",
+			len(dims), strings.Join(dims, ", "), l.MaxAggregationDimensions)
+	}
+	return nil
+}
+
+// checkNarrowing reports an error if filter selects reports over a timeRange
+// wider than MaxUnfilteredTimeRange without any other narrowing dimension.
+// Aggregation-only selections are exempt: they return a bounded number of
+// rows however wide the range.
+func (l Limits) checkNarrowing(filter *model.StormReportFilter, fields map[string]bool) error {
+	if l.MaxUnfilteredTimeRange <= 0 || !fields["reports"] || isNarrowed(filter) {
+		return nil
+	}
+	if span := filter.TimeRange.To.Sub(filter.TimeRange.From); span > l.MaxUnfilteredTimeRange {
+		return reject(ruleNarrowingFilter, "timeRange spans %s, more than %s without a narrowing filter; "+
+			"add states, counties, eventTypes, near or idPrefix, shorten timeRange, or select only aggregations",
+			span, l.MaxUnfilteredTimeRange)
+	}
+	return nil
+}
+
+// isNarrowed reports whether filter restricts results by anything other than
+// time. Exclusions and severity don't count: they still leave most rows.
+func isNarrowed(filter *model.StormReportFilter) bool {
+	return len(filter.States) > 0 || len(filter.Counties) > 0 ||
+		len(filter.EventTypes) > 0 || len(filter.EventTypeFilters) > 0 ||
+		filter.Near != nil || filter.IDPrefix != nil
+}
+
+// checkSortField reports an error if sortBy is not allowed by l.
+func (l Limits) checkSortField(sf model.SortField) error {
+	if l.SortFields == nil || slices.Contains(l.SortFields, sf) {
+		return nil
+	}
+	if slices.Contains(l.AuthenticatedSortFields, sf) {
+		return reject(ruleSortField, "sortBy %s requires an API key", sf)
+	}
+	allowed := make([]string, len(l.SortFields))
+	for i, f := range l.SortFields {
+		allowed[i] = f.String()
+	}
+	return reject(ruleSortField, "sortBy %s is not allowed; allowed fields: %s", sf, strings.Join(allowed, ", "))
+}
+
+// maxEventTypeFilters returns the configured cap on eventTypeFilters.
+func (l Limits) maxEventTypeFilters() int {
+	if l.MaxEventTypeFilters > 0 {
+		return l.MaxEventTypeFilters
+	}
+	return MaxEventTypeFilters
+}
+
+// maxFilterCost returns the configured filter cost budget.
+func (l Limits) maxFilterCost() int {
+	if l.MaxFilterCost > 0 {
+		return l.MaxFilterCost
+	}
+	return DefaultMaxFilterCost
+}
+
+// maxQueryParams returns the configured bind parameter cap.
+func (l Limits) maxQueryParams() int {
+	if l.MaxQueryParams > 0 {
+		return l.MaxQueryParams
+	}
+	return DefaultMaxQueryParams
+}
+
+// filterCost estimates how expensive a filter's WHERE clause is to plan. Each
+// filter may be within its own caps while their combination is not, so the
+// total is checked against a single budget.
+func filterCost(filter *model.StormReportFilter) int {
+	cost := costPerListValue * (len(filter.States) + len(filter.Counties) + len(filter.ExcludeStates) + len(filter.ExcludeCounties) +
+		len(filter.Pipelines) + len(filter.EventTypes) + len(filter.Severity))
+	if filter.MinSeverity != nil {
+		cost += costPerListValue
+	}
+	if filter.IDPrefix != nil {
+		cost += costPerListValue
+	}
+	cost += costPerImpactKeyword * len(filter.ImpactKeywords)
+	if filter.Near != nil {
+		cost += costPerGeoClause
+	}
+	for _, typeFilter := range filter.EventTypeFilters {
+		cost += costPerEventTypeGroup + costPerListValue*len(typeFilter.Severity)
+		if filter.Near != nil && typeFilter.RadiusMiles != nil {
+			cost += costPerGeoClause
+		}
+	}
+	return cost
+}
+
+// maxRadius returns the radius cap for a single event type.
+func (l Limits) maxRadius(et model.EventType) float64 {
+	if r, ok := l.MaxRadiusByType[et]; ok {
+		return r
+	}
+	return MaxRadiusMiles
+}
+
+// defaultNearRadius returns the radius applied when near.radiusMiles is
+// omitted: the configured default shared by every type near applies to, or
+// DefaultRadiusMiles when those types' defaults differ.
+func (l Limits) defaultNearRadius(filter *model.StormReportFilter) float64 {
+	types := nearRadiusTypes(filter)
+	if len(l.DefaultRadiusByType) == 0 || len(types) == 0 {
+		return DefaultRadiusMiles
+	}
+	radius := l.defaultRadius(types[0])
+	for _, et := range types[1:] {
+		if l.defaultRadius(et) != radius {
+			return DefaultRadiusMiles
+		}
+	}
+	return radius
+}
+
+// defaultRadius returns the default radius for a single event type.
+func (l Limits) defaultRadius(et model.EventType) float64 {
+	if r, ok := l.DefaultRadiusByType[et]; ok {
+		return r
+	}
+	return DefaultRadiusMiles
+}
+
+// nearRadiusCap returns the strictest radius cap across the event types that
+// near.radiusMiles applies to, along with the type imposing it. The type is
+// empty when the global MaxRadiusMiles applies.
+func (l Limits) nearRadiusCap(filter *model.StormReportFilter) (float64, model.EventType) {
+	types := nearRadiusTypes(filter)
+	if len(l.MaxRadiusByType) == 0 || len(types) == 0 {
+		return MaxRadiusMiles, ""
+	}
+	limit, limitType := l.maxRadius(types[0]), types[0]
+	for _, et := range types[1:] {
+		if r := l.maxRadius(et); r < limit {
+			limit, limitType = r, et
+		}
+	}
+	if _, ok := l.MaxRadiusByType[limitType]; !ok {
+		limitType = ""
+	}
+	return limit, limitType
+}
+
+// nearRadiusTypes returns the event types whose search radius comes from
+// near.radiusMiles. In simple mode that is eventTypes (or every type when
+// unset); in per-type mode it is every type without its own radiusMiles.
+func nearRadiusTypes(filter *model.StormReportFilter) []model.EventType {
+	if len(filter.EventTypeFilters) == 0 {
+		if len(filter.EventTypes) == 0 {
+			return model.AllEventTypes
+		}
+		return filter.EventTypes
+	}
+	overridden := make(map[model.EventType]bool, len(filter.EventTypeFilters))
+	var types []model.EventType
+	for _, typeFilter := range filter.EventTypeFilters {
+		overridden[typeFilter.EventType] = true
+		if typeFilter.RadiusMiles == nil {
+			types = append(types, typeFilter.EventType)
+		}
+	}
+	for _, et := range filter.EventTypes {
+		if !overridden[et] {
+			types = append(types, et)
+		}
+	}
+	return types
+}
+
+// applyDefaultTimeRange sets filter.TimeRange to the window ending at now
+// when the client omitted it. A zero window leaves it unset for
+// ValidateFilter to reject.
+func applyDefaultTimeRange(filter *model.StormReportFilter, window time.Duration, now time.Time) {
+	if filter.TimeRange != nil || window <= 0 {
+		return
+	}
+	filter.TimeRange = &model.TimeRange{From: now.Add(-window), To: now}
+}
+
+// validateTimeRange checks that tr ends after it starts and doesn't start in
+// the future.
+func validateTimeRange(tr *model.TimeRange, limits Limits) error {
+	if !tr.To.After(tr.From) {
+		return reject(ruleTimeRange, "timeRange.to must be after timeRange.from")
+	}
+	// A future from can never match; to may be open-ended ("up to now").
+	if skew := limits.maxFutureSkew(); tr.From.After(limits.currentTime().Add(skew)) {
+		return reject(ruleTimeRange, "timeRange.from is in the future (more than %s ahead of server time)", skew)
+	}
+	return nil
+}
+
+// validateEventTypesRange validates distinctEventTypes' timeRange like a
+// filter's, and caps it at MaxDistinctEventTypesRange or, when tighter,
+// MaxUnfilteredTimeRange, since the window is the query's only narrowing.
+func validateEventTypesRange(tr *model.TimeRange, limits Limits) error {
+	if err := validateTimeRange(tr, limits); err != nil {
+		return err
+	}
+	limit := MaxDistinctEventTypesRange
+	if m := limits.MaxUnfilteredTimeRange; m > 0 && m < limit {
+		limit = m
+	}
+	if span := tr.To.Sub(tr.From); span > limit {
+		return reject(ruleTimeRange, "timeRange spans %s, more than the %s allowed for distinctEventTypes", span, limit)
+	}
+	return nil
+}
+
+// ingestionGapWindow resolves ingestionGap's withinHours argument, applying
+// the default and rejecting values outside 1..MaxIngestionGapHours.
+func ingestionGapWindow(withinHours *int) (time.Duration, error) {
+	if withinHours == nil {
+		return DefaultIngestionGapHours * time.Hour, nil
+	}
+	if h := *withinHours; h < 1 || h > MaxIngestionGapHours {
+		return 0, reject(ruleIngestionGapWindow, "withinHours must be between 1 and %d", MaxIngestionGapHours)
+	}
+	return time.Duration(*withinHours) * time.Hour, nil
+}
+
 // ValidateFilter validates a single filter, enforcing limits and applying defaults.
-func ValidateFilter(filter *model.StormReportFilter) error {
-	// Time range: to must be after from
-	if !filter.TimeRange.To.After(filter.TimeRange.From) {
-		return fmt.Errorf("timeRange.to must be after timeRange.from")
+func ValidateFilter(filter *model.StormReportFilter, limits Limits) error {
+	// Time range: required, and to must be after from
+	if filter.TimeRange == nil {
+		return reject(ruleTimeRange, "timeRange is required")
+	}
+	if err := validateTimeRange(filter.TimeRange, limits); err != nil {
+		return err
+	}
+
+	// Hour of day: 0-23 and a known IANA time zone
+	if hr := filter.HourOfDayRange; hr != nil {
+		if hr.From < 0 || hr.From > 23 || hr.To < 0 || hr.To > 23 {
+			return reject(ruleHourOfDayRange, "hourOfDayRange hours must be between 0 and 23")
+		}
+		if hr.TimeZone != nil && !isKnownTimeZone(*hr.TimeZone) {
+			return reject(ruleHourOfDayRange, "hourOfDayRange.timeZone %q is not a known time zone", *hr.TimeZone)
+		}
+	}
+
+	// Ingestion window: 1 minute to 24 hours
+	if m := filter.IngestedWithinMinutes; m != nil && (*m < 1 || *m > MaxIngestedWithinMinutes) {
+		return reject(ruleIngestedWithin, "ingestedWithinMinutes must be between 1 and %d", MaxIngestedWithinMinutes)
+	}
+
+	// ID prefix: long enough to narrow to a handful of reports
+	if p := filter.IDPrefix; p != nil && len(*p) < MinIDPrefixLength {
+		return reject(ruleIDPrefix, "idPrefix must be at least %d characters", MinIDPrefixLength)
+	}
+
+	// Impact keywords: capped, each long enough for the trigram index
+	if len(filter.ImpactKeywords) > MaxImpactKeywords {
+		return reject(ruleImpactKeywords, "at most %d impactKeywords allowed", MaxImpactKeywords)
+	}
+	for i, keyword := range filter.ImpactKeywords {
+		keyword = strings.TrimSpace(keyword)
+		if len([]rune(keyword)) < MinImpactKeywordLength {
+			return reject(ruleImpactKeywords, "impactKeywords[%d] must be at least %d characters", i, MinImpactKeywordLength)
+		}
+		filter.ImpactKeywords[i] = keyword
+	}
+
+	// States and counties: include or exclude per dimension, not both
+	if len(filter.States) > 0 && len(filter.ExcludeStates) > 0 {
+		return reject(ruleLocationConflict, "states and excludeStates cannot both be set")
+	}
+	if len(filter.Counties) > 0 && len(filter.ExcludeCounties) > 0 {
+		return reject(ruleLocationConflict, "counties and excludeCounties cannot both be set")
+	}
+
+	// States: normalize case and reject codes that could never match
+	if err := normalizeStateCodes("states", filter.States); err != nil {
+		return err
+	}
+	if err := normalizeStateCodes("excludeStates", filter.ExcludeStates); err != nil {
+		return err
 	}
 
 	// Geo radius: default and cap
 	if filter.Near != nil {
 		if filter.Near.RadiusMiles == nil {
-			d := DefaultRadiusMiles
+			d := limits.defaultNearRadius(filter)
 			filter.Near.RadiusMiles = &d
 		}
-		if *filter.Near.RadiusMiles > MaxRadiusMiles {
-			return fmt.Errorf("near.radiusMiles exceeds maximum of %.0f", MaxRadiusMiles)
+		if maxRadius, et := limits.nearRadiusCap(filter); *filter.Near.RadiusMiles > maxRadius {
+			if et != "" {
+				return reject(ruleRadius, "near.radiusMiles exceeds maximum of %.0f for %s", maxRadius, et)
+			}
+			return reject(ruleRadius, "near.radiusMiles exceeds maximum of %.0f", maxRadius)
 		}
 	}
 
-	// EventTypeFilters: max 3, no duplicate types
-	if len(filter.EventTypeFilters) > MaxEventTypeFilters {
-		return fmt.Errorf("at most %d eventTypeFilters allowed", MaxEventTypeFilters)
+	// EventTypeFilters: capped (3 by default), no duplicate types
+	if maxFilters := limits.maxEventTypeFilters(); len(filter.EventTypeFilters) > maxFilters {
+		return reject(ruleEventTypeFilters, "at most %d eventTypeFilters allowed", maxFilters)
 	}
 	seen := make(map[model.EventType]bool)
 	for i, typeFilter := range filter.EventTypeFilters {
 		if seen[typeFilter.EventType] {
-			return fmt.Errorf("eventTypeFilters[%d]: duplicate eventType %s", i, typeFilter.EventType)
+			return reject(ruleEventTypeFilters, "eventTypeFilters[%d]: duplicate eventType %s", i, typeFilter.EventType)
 		}
 		seen[typeFilter.EventType] = true
 
+		// When eventTypes is set it is the full set of types returned;
+		// overrides refine those types rather than adding new ones.
+		if len(filter.EventTypes) > 0 && !slices.Contains(filter.EventTypes, typeFilter.EventType) {
+			return reject(ruleEventTypeFilters, "eventTypeFilters[%d]: eventType %s is not in eventTypes; add it to eventTypes or leave eventTypes unset", i, typeFilter.EventType)
+		}
+
 		// Per-type radius cap
-		if typeFilter.RadiusMiles != nil && *typeFilter.RadiusMiles > MaxRadiusMiles {
-			return fmt.Errorf("eventTypeFilters[%d]: radiusMiles exceeds maximum of %.0f", i, MaxRadiusMiles)
+		if maxRadius := limits.maxRadius(typeFilter.EventType); typeFilter.RadiusMiles != nil && *typeFilter.RadiusMiles > maxRadius {
+			return reject(ruleRadius, "eventTypeFilters[%d]: radiusMiles exceeds maximum of %.0f", i, maxRadius)
+		}
+	}
+
+	// Magnitude bucket edges: capped and strictly ascending
+	if len(filter.MagnitudeBucketEdges) > MaxMagnitudeBucketEdges {
+		return reject(ruleMagnitudeBucketEdges, "at most %d magnitudeBucketEdges allowed", MaxMagnitudeBucketEdges)
+	}
+	for i := 1; i < len(filter.MagnitudeBucketEdges); i++ {
+		if filter.MagnitudeBucketEdges[i] <= filter.MagnitudeBucketEdges[i-1] {
+			return reject(ruleMagnitudeBucketEdges, "magnitudeBucketEdges must be strictly ascending")
+		}
+	}
+
+	if filter.MaxAggregationRows != nil && *filter.MaxAggregationRows < 1 {
+		return reject(ruleMaxAggregationRows, "maxAggregationRows must be at least 1")
+	}
+
+	// Sort field: restricted to indexed columns when configured
+	if filter.SortBy != nil {
+		if err := limits.checkSortField(*filter.SortBy); err != nil {
+			return err
 		}
 	}
 
+	// Combined filter cost
+	if cost, budget := filterCost(filter), limits.maxFilterCost(); cost > budget {
+		return reject(ruleFilterCost, "filter too complex: cost %d exceeds maximum of %d", cost, budget)
+	}
+
+	// Bind parameters: counted on the built SQL, after defaults above
+	if n, maxParams := store.QueryParamCount(filter), limits.maxQueryParams(); n > maxParams {
+		return reject(ruleQueryParams, "filter too large: needs %d query parameters, maximum is %d; use fewer eventTypeFilters or radius overrides", n, maxParams)
+	}
+
 	// Pagination defaults and caps
 	if filter.Limit == nil {
 		d := MaxPageSize
 		filter.Limit = &d
 	} else if *filter.Limit > MaxPageSize {
-		return fmt.Errorf("limit exceeds maximum of %d", MaxPageSize)
+		return reject(ruleLimit, "limit exceeds maximum of %d", MaxPageSize)
+	}
+
+	return nil
+}
+
+// isKnownTimeZone reports whether name is an IANA time zone that PostgreSQL
+// will also accept. time.LoadLocation treats "" and "Local" specially, so
+// those are rejected explicitly.
+func isKnownTimeZone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
 	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
 
+// normalizeStateCodes normalizes codes in place, naming the offending entry
+// of the given filter field when one is unknown.
+func normalizeStateCodes(field string, codes []string) error {
+	for i, state := range codes {
+		code, ok := normalizeStateCode(state)
+		if !ok {
+			return reject(ruleStateCode, "%s[%d]: unknown state code %q; valid codes: %s", field, i, state, strings.Join(stateCodes, ", "))
+		}
+		codes[i] = code
+	}
 	return nil
 }
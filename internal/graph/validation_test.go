@@ -1,9 +1,15 @@
 package graph
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -11,7 +17,7 @@ import (
 
 func validFilter() *model.StormReportFilter {
 	return &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -20,7 +26,7 @@ func validFilter() *model.StormReportFilter {
 
 func TestValidateFilter_Valid(t *testing.T) {
 	f := validFilter()
-	require.NoError(t, ValidateFilter(f))
+	require.NoError(t, ValidateFilter(f, Limits{}))
 
 	// Limit should be defaulted
 	require.NotNil(t, f.Limit)
@@ -31,7 +37,7 @@ func TestValidateFilter_TimeRangeInvalid(t *testing.T) {
 	f := validFilter()
 	f.TimeRange.From, f.TimeRange.To = f.TimeRange.To, f.TimeRange.From
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "timeRange.to must be after timeRange.from")
 }
@@ -40,26 +46,122 @@ func TestValidateFilter_TimeRangeEqual(t *testing.T) {
 	f := validFilter()
 	f.TimeRange.To = f.TimeRange.From
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "timeRange.to must be after timeRange.from")
 }
 
+func TestValidateFilter_TimeRangeMissing(t *testing.T) {
+	f := validFilter()
+	f.TimeRange = nil
+
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeRange is required")
+}
+
+func TestApplyDefaultTimeRange(t *testing.T) {
+	now := time.Date(2024, 4, 27, 12, 0, 0, 0, time.UTC)
+
+	f := validFilter()
+	f.TimeRange = nil
+	applyDefaultTimeRange(f, 24*time.Hour, now)
+	require.NotNil(t, f.TimeRange)
+	assert.Equal(t, model.TimeRange{From: now.Add(-24 * time.Hour), To: now}, *f.TimeRange)
+
+	// An explicit timeRange is kept as-is.
+	f = validFilter()
+	want := *f.TimeRange
+	applyDefaultTimeRange(f, 24*time.Hour, now)
+	assert.Equal(t, want, *f.TimeRange)
+
+	// A zero window leaves timeRange required.
+	f.TimeRange = nil
+	applyDefaultTimeRange(f, 0, now)
+	assert.Nil(t, f.TimeRange)
+}
+
+func TestValidateFilter_FutureTimeRange(t *testing.T) {
+	now := time.Now()
+
+	// to may run into the future for open-ended queries.
+	f := validFilter()
+	f.TimeRange.To = now.Add(24 * time.Hour)
+	require.NoError(t, ValidateFilter(f, Limits{}))
+
+	// from within the skew allowance is tolerated.
+	f = validFilter()
+	f.TimeRange = &model.TimeRange{From: now.Add(30 * time.Second), To: now.Add(time.Hour)}
+	require.NoError(t, ValidateFilter(f, Limits{}))
+
+	f = validFilter()
+	f.TimeRange = &model.TimeRange{From: now.Add(2 * time.Minute), To: now.Add(time.Hour)}
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeRange.from is in the future (more than 1m0s ahead of server time)")
+
+	f = validFilter()
+	f.TimeRange = &model.TimeRange{From: now.Add(2 * time.Minute), To: now.Add(time.Hour)}
+	require.NoError(t, ValidateFilter(f, Limits{MaxFutureSkew: 5 * time.Minute}))
+}
+
+func TestResolverLimits_UseClock(t *testing.T) {
+	clk := clock.NewFake(time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC))
+	r := &Resolver{Clock: clk}
+
+	// A 2024 window is in the future by the resolver's clock, whatever the
+	// wall clock says.
+	f := validFilter()
+	f.TimeRange.From = f.TimeRange.From.Add(time.Hour)
+	err := ValidateFilter(f, r.limits(context.Background()))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeRange.from is in the future")
+}
+
 func TestValidateFilter_NearDefaultsRadius(t *testing.T) {
 	f := validFilter()
 	f.Near = &model.GeoRadiusFilter{Lat: 32.0, Lon: -97.0}
 
-	require.NoError(t, ValidateFilter(f))
+	require.NoError(t, ValidateFilter(f, Limits{}))
 	require.NotNil(t, f.Near.RadiusMiles)
 	assert.InDelta(t, DefaultRadiusMiles, *f.Near.RadiusMiles, 0.0001)
 }
 
+func TestValidateFilter_DefaultRadiusByType(t *testing.T) {
+	limits := Limits{DefaultRadiusByType: map[model.EventType]float64{
+		model.EventTypeTornado: 50,
+		model.EventTypeWind:    50,
+	}}
+
+	tests := []struct {
+		name  string
+		types []model.EventType
+		want  float64
+	}{
+		{"configured type", []model.EventType{model.EventTypeTornado}, 50},
+		{"types sharing a default", []model.EventType{model.EventTypeTornado, model.EventTypeWind}, 50},
+		{"mixed defaults", []model.EventType{model.EventTypeTornado, model.EventTypeHail}, DefaultRadiusMiles},
+		{"unset eventTypes covers all types", nil, DefaultRadiusMiles},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validFilter()
+			f.EventTypes = tt.types
+			f.Near = &model.GeoRadiusFilter{Lat: 32.0, Lon: -97.0}
+
+			require.NoError(t, ValidateFilter(f, limits))
+			require.NotNil(t, f.Near.RadiusMiles)
+			assert.InDelta(t, tt.want, *f.Near.RadiusMiles, 0.0001)
+		})
+	}
+}
+
 func TestValidateFilter_NearRadiusExceedsMax(t *testing.T) {
 	f := validFilter()
 	radius := 250.0
 	f.Near = &model.GeoRadiusFilter{Lat: 32.0, Lon: -97.0, RadiusMiles: &radius}
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "near.radiusMiles exceeds maximum of 200")
 }
@@ -69,7 +171,7 @@ func TestValidateFilter_NearRadiusAtMax(t *testing.T) {
 	radius := MaxRadiusMiles
 	f.Near = &model.GeoRadiusFilter{Lat: 32.0, Lon: -97.0, RadiusMiles: &radius}
 
-	require.NoError(t, ValidateFilter(f))
+	require.NoError(t, ValidateFilter(f, Limits{}))
 }
 
 func TestValidateFilter_EventTypeFiltersTooMany(t *testing.T) {
@@ -81,11 +183,31 @@ func TestValidateFilter_EventTypeFiltersTooMany(t *testing.T) {
 		{EventType: model.EventTypeHail}, // 4th — exceeds max
 	}
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "at most 3 eventTypeFilters")
 }
 
+func TestValidateFilter_EventTypeFiltersConfiguredMax(t *testing.T) {
+	f := validFilter()
+	f.EventTypeFilters = []*model.EventTypeFilter{
+		{EventType: model.EventTypeHail},
+		{EventType: model.EventTypeWind},
+	}
+
+	err := ValidateFilter(f, Limits{MaxEventTypeFilters: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 1 eventTypeFilters")
+
+	// A higher cap lets the check fall through to the duplicate rule.
+	f.EventTypeFilters = append(f.EventTypeFilters,
+		&model.EventTypeFilter{EventType: model.EventTypeTornado},
+		&model.EventTypeFilter{EventType: model.EventTypeHail})
+	err = ValidateFilter(f, Limits{MaxEventTypeFilters: 5})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "eventTypeFilters[3]: duplicate eventType HAIL")
+}
+
 func TestValidateFilter_EventTypeFiltersDuplicate(t *testing.T) {
 	f := validFilter()
 	f.EventTypeFilters = []*model.EventTypeFilter{
@@ -93,11 +215,27 @@ func TestValidateFilter_EventTypeFiltersDuplicate(t *testing.T) {
 		{EventType: model.EventTypeHail}, // duplicate
 	}
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "duplicate eventType HAIL")
 }
 
+func TestValidateFilter_EventTypeFiltersOutsideEventTypes(t *testing.T) {
+	f := validFilter()
+	f.EventTypes = []model.EventType{model.EventTypeHail}
+	f.EventTypeFilters = []*model.EventTypeFilter{
+		{EventType: model.EventTypeHail},
+		{EventType: model.EventTypeTornado},
+	}
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "eventTypeFilters[1]: eventType TORNADO is not in eventTypes")
+
+	// Without eventTypes the overrides choose the types themselves.
+	f.EventTypes = nil
+	require.NoError(t, ValidateFilter(f, Limits{}))
+}
+
 func TestValidateFilter_EventTypeFilterPerTypeRadiusCap(t *testing.T) {
 	f := validFilter()
 	bigRadius := 300.0
@@ -105,7 +243,7 @@ func TestValidateFilter_EventTypeFilterPerTypeRadiusCap(t *testing.T) {
 		{EventType: model.EventTypeHail, RadiusMiles: &bigRadius},
 	}
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "eventTypeFilters[0]: radiusMiles exceeds maximum of 200")
 }
@@ -119,7 +257,7 @@ func TestValidateFilter_EventTypeFiltersValid(t *testing.T) {
 		{EventType: model.EventTypeTornado, RadiusMiles: &r2},
 	}
 
-	require.NoError(t, ValidateFilter(f))
+	require.NoError(t, ValidateFilter(f, Limits{}))
 }
 
 func TestValidateFilter_LimitExceedsMax(t *testing.T) {
@@ -127,7 +265,7 @@ func TestValidateFilter_LimitExceedsMax(t *testing.T) {
 	limit := 100
 	f.Limit = &limit
 
-	err := ValidateFilter(f)
+	err := ValidateFilter(f, Limits{})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "limit exceeds maximum of 20")
 }
@@ -137,6 +275,466 @@ func TestValidateFilter_LimitPreservedWhenValid(t *testing.T) {
 	limit := 10
 	f.Limit = &limit
 
-	require.NoError(t, ValidateFilter(f))
+	require.NoError(t, ValidateFilter(f, Limits{}))
 	assert.Equal(t, 10, *f.Limit)
 }
+
+func TestValidateFilter_MaxRadiusByType(t *testing.T) {
+	limits := Limits{MaxRadiusByType: map[model.EventType]float64{
+		model.EventTypeHail:    100,
+		model.EventTypeTornado: 300,
+	}}
+	r := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name    string
+		mutate  func(f *model.StormReportFilter)
+		wantErr string
+	}{
+		{
+			name: "tornado only allows wider radius",
+			mutate: func(f *model.StormReportFilter) {
+				f.EventTypes = []model.EventType{model.EventTypeTornado}
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(250)}
+			},
+		},
+		{
+			name: "mixed types use strictest cap",
+			mutate: func(f *model.StormReportFilter) {
+				f.EventTypes = []model.EventType{model.EventTypeTornado, model.EventTypeHail}
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(150)}
+			},
+			wantErr: "near.radiusMiles exceeds maximum of 100 for HAIL",
+		},
+		{
+			name: "unset eventTypes covers all types",
+			mutate: func(f *model.StormReportFilter) {
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(150)}
+			},
+			wantErr: "near.radiusMiles exceeds maximum of 100 for HAIL",
+		},
+		{
+			name: "unconfigured type falls back to global cap",
+			mutate: func(f *model.StormReportFilter) {
+				f.EventTypes = []model.EventType{model.EventTypeWind, model.EventTypeTornado}
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(250)}
+			},
+			wantErr: "near.radiusMiles exceeds maximum of 200",
+		},
+		{
+			name: "per-type radius override uses its own cap",
+			mutate: func(f *model.StormReportFilter) {
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(50)}
+				f.EventTypeFilters = []*model.EventTypeFilter{
+					{EventType: model.EventTypeTornado, RadiusMiles: r(300)},
+					{EventType: model.EventTypeHail, RadiusMiles: r(150)},
+				}
+			},
+			wantErr: "eventTypeFilters[1]: radiusMiles exceeds maximum of 100",
+		},
+		{
+			name: "near radius ignores types with their own radius",
+			mutate: func(f *model.StormReportFilter) {
+				f.EventTypes = []model.EventType{model.EventTypeTornado, model.EventTypeHail}
+				f.Near = &model.GeoRadiusFilter{Lat: 35, Lon: -97, RadiusMiles: r(250)}
+				f.EventTypeFilters = []*model.EventTypeFilter{
+					{EventType: model.EventTypeHail, RadiusMiles: r(50)},
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validFilter()
+			tt.mutate(f)
+			err := ValidateFilter(f, limits)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateFilter_IngestedWithinMinutes(t *testing.T) {
+	for _, tt := range []struct {
+		minutes int
+		wantErr bool
+	}{
+		{1, false},
+		{MaxIngestedWithinMinutes, false},
+		{0, true},
+		{MaxIngestedWithinMinutes + 1, true},
+	} {
+		f := validFilter()
+		f.IngestedWithinMinutes = &tt.minutes
+		err := ValidateFilter(f, Limits{})
+		if tt.wantErr {
+			require.Error(t, err, "minutes=%d", tt.minutes)
+			assert.Contains(t, err.Error(), "ingestedWithinMinutes must be between 1 and 1440")
+		} else {
+			require.NoError(t, err, "minutes=%d", tt.minutes)
+		}
+	}
+}
+
+func TestIngestionGapWindow(t *testing.T) {
+	window, err := ingestionGapWindow(nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultIngestionGapHours*time.Hour, window)
+
+	for _, tt := range []struct {
+		hours   int
+		wantErr bool
+	}{
+		{1, false},
+		{MaxIngestionGapHours, false},
+		{0, true},
+		{MaxIngestionGapHours + 1, true},
+	} {
+		window, err := ingestionGapWindow(&tt.hours)
+		if tt.wantErr {
+			require.Error(t, err, "hours=%d", tt.hours)
+			assert.Contains(t, err.Error(), "withinHours must be between 1 and 168")
+		} else {
+			require.NoError(t, err, "hours=%d", tt.hours)
+			assert.Equal(t, time.Duration(tt.hours)*time.Hour, window)
+		}
+	}
+}
+
+func TestValidateEventTypesRange(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	year := &model.TimeRange{From: from, To: from.Add(MaxDistinctEventTypesRange)}
+	require.NoError(t, validateEventTypesRange(year, Limits{}))
+
+	tooWide := &model.TimeRange{From: from, To: from.Add(MaxDistinctEventTypesRange + time.Hour)}
+	err := validateEventTypesRange(tooWide, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "allowed for distinctEventTypes")
+
+	err = validateEventTypesRange(year, Limits{MaxUnfilteredTimeRange: 7 * 24 * time.Hour})
+	require.Error(t, err, "MaxUnfilteredTimeRange applies when tighter")
+}
+
+func TestValidateFilter_MagnitudeBucketEdges(t *testing.T) {
+	tests := []struct {
+		name    string
+		edges   []float64
+		wantErr string
+	}{
+		{"ascending", []float64{0.5, 1, 2}, ""},
+		{"single edge", []float64{3}, ""},
+		{"descending", []float64{2, 1}, "magnitudeBucketEdges must be strictly ascending"},
+		{"duplicate", []float64{1, 1}, "magnitudeBucketEdges must be strictly ascending"},
+		{"too many", make([]float64, MaxMagnitudeBucketEdges+1), "at most 10 magnitudeBucketEdges allowed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validFilter()
+			f.MagnitudeBucketEdges = tt.edges
+			err := ValidateFilter(f, Limits{})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestValidateFilter_MaxAggregationRows(t *testing.T) {
+	f := validFilter()
+	rows := 1
+	f.MaxAggregationRows = &rows
+	require.NoError(t, ValidateFilter(f, Limits{}))
+
+	rows = 0
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "maxAggregationRows must be at least 1")
+}
+
+func TestValidateFilter_IDPrefix(t *testing.T) {
+	prefix, short := "hail-5d91d", "hail-"
+	f := validFilter()
+	f.IDPrefix = &prefix
+	require.NoError(t, ValidateFilter(f, Limits{}))
+
+	f.IDPrefix = &short
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "idPrefix must be at least 10 characters")
+}
+
+func TestValidateFilter_ImpactKeywords(t *testing.T) {
+	f := validFilter()
+	f.ImpactKeywords = []string{" roof damage ", "trees down"}
+	require.NoError(t, ValidateFilter(f, Limits{}))
+	assert.Equal(t, []string{"roof damage", "trees down"}, f.ImpactKeywords, "keywords are trimmed")
+
+	f.ImpactKeywords = []string{"trees down", " ok "}
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "impactKeywords[1] must be at least 3 characters")
+
+	f.ImpactKeywords = make([]string, MaxImpactKeywords+1)
+	for i := range f.ImpactKeywords {
+		f.ImpactKeywords[i] = "damage"
+	}
+	err = ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at most 10 impactKeywords allowed")
+}
+
+func TestLimits_CheckAggregationDimensions(t *testing.T) {
+	fields := map[string]bool{
+		"aggregations.byEventType": true,
+		"aggregations.byState":     true,
+		"aggregations.byHour":      true,
+	}
+	require.NoError(t, Limits{}.checkAggregationDimensions(fields), "uncapped by default")
+	require.NoError(t, Limits{MaxAggregationDimensions: 3}.checkAggregationDimensions(fields))
+
+	err := Limits{MaxAggregationDimensions: 2}.checkAggregationDimensions(fields)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "too many aggregation dimensions: requested 3 (byEventType, byState, byHour), maximum is 2")
+}
+
+func TestLimits_CheckNarrowing(t *testing.T) {
+	reports := map[string]bool{"reports": true}
+	wide := validFilter()
+	wide.TimeRange.To = wide.TimeRange.From.Add(30 * 24 * time.Hour)
+
+	require.NoError(t, Limits{}.checkNarrowing(wide, reports), "any range allowed by default")
+
+	limits := Limits{MaxUnfilteredTimeRange: 7 * 24 * time.Hour}
+	require.NoError(t, limits.checkNarrowing(validFilter(), reports), "narrow time range")
+	require.NoError(t, limits.checkNarrowing(wide, map[string]bool{"aggregations": true, "aggregations.byState": true}), "aggregation-only")
+
+	err := limits.checkNarrowing(wide, reports)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timeRange spans 720h0m0s, more than 168h0m0s without a narrowing filter")
+	var vErr *ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, ruleNarrowingFilter, vErr.Rule)
+
+	prefix := "hail-0123456789"
+	for name, narrow := range map[string]func(*model.StormReportFilter){
+		"states":     func(f *model.StormReportFilter) { f.States = []string{"OK"} },
+		"counties":   func(f *model.StormReportFilter) { f.Counties = []string{"Cleveland"} },
+		"eventTypes": func(f *model.StormReportFilter) { f.EventTypes = []model.EventType{model.EventTypeHail} },
+		"eventTypeFilters": func(f *model.StormReportFilter) {
+			f.EventTypeFilters = []*model.EventTypeFilter{{EventType: model.EventTypeHail}}
+		},
+		"near":     func(f *model.StormReportFilter) { f.Near = &model.GeoRadiusFilter{Lat: 35.2, Lon: -97.4} },
+		"idPrefix": func(f *model.StormReportFilter) { f.IDPrefix = &prefix },
+	} {
+		f := *wide
+		narrow(&f)
+		require.NoError(t, limits.checkNarrowing(&f, reports), name)
+	}
+
+	excluded := *wide
+	excluded.ExcludeStates = []string{"TX"}
+	require.Error(t, limits.checkNarrowing(&excluded, reports), "exclusions don't narrow")
+}
+
+func TestValidateFilter_SortFields(t *testing.T) {
+	limits := Limits{
+		SortFields:              []model.SortField{model.SortFieldEventTime, model.SortFieldMagnitude},
+		AuthenticatedSortFields: []model.SortField{model.SortFieldLocationState},
+	}
+	sortBy := func(sf model.SortField) *model.StormReportFilter {
+		f := validFilter()
+		f.SortBy = &sf
+		return f
+	}
+
+	require.NoError(t, ValidateFilter(sortBy(model.SortFieldMagnitude), limits))
+	require.NoError(t, ValidateFilter(sortBy(model.SortFieldEventType), Limits{}), "unrestricted by default")
+
+	err := ValidateFilter(sortBy(model.SortFieldEventType), limits)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sortBy EVENT_TYPE is not allowed; allowed fields: EVENT_TIME, MAGNITUDE")
+
+	err = ValidateFilter(sortBy(model.SortFieldLocationState), limits)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sortBy LOCATION_STATE requires an API key")
+
+	callerCtx := func(key string) context.Context {
+		req := httptest.NewRequest(http.MethodPost, "/query", nil)
+		req.Header.Set(APIKeyHeader, key)
+		var ctx context.Context
+		WithAPIKey([]string{"partner-a"})(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) { ctx = r.Context() })).ServeHTTP(httptest.NewRecorder(), req)
+		return ctx
+	}
+	require.NoError(t, ValidateFilter(sortBy(model.SortFieldLocationState), limits.forCaller(callerCtx("partner-a"))))
+	assert.Len(t, limits.SortFields, 2, "forCaller must not modify the shared limits")
+
+	err = ValidateFilter(sortBy(model.SortFieldLocationState), limits.forCaller(callerCtx("made-up")))
+	require.Error(t, err, "an unconfigured key must not unlock authenticated sorts")
+	assert.Contains(t, err.Error(), "requires an API key")
+}
+
+func TestValidateFilter_HourOfDayRange(t *testing.T) {
+	tz := func(s string) *string { return &s }
+	tests := []struct {
+		name    string
+		hr      *model.HourOfDayRange
+		wantErr string
+	}{
+		{"valid UTC default", &model.HourOfDayRange{From: 18, To: 23}, ""},
+		{"valid wrap-around", &model.HourOfDayRange{From: 22, To: 2, TimeZone: tz("America/Chicago")}, ""},
+		{"hour too large", &model.HourOfDayRange{From: 18, To: 24}, "hourOfDayRange hours must be between 0 and 23"},
+		{"negative hour", &model.HourOfDayRange{From: -1, To: 5}, "hourOfDayRange hours must be between 0 and 23"},
+		{"unknown zone", &model.HourOfDayRange{From: 1, To: 5, TimeZone: tz("Mars/Olympus")}, `timeZone "Mars/Olympus" is not a known time zone`},
+		{"local zone", &model.HourOfDayRange{From: 1, To: 5, TimeZone: tz("Local")}, `timeZone "Local" is not a known time zone`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := validFilter()
+			f.HourOfDayRange = tt.hr
+			err := ValidateFilter(f, Limits{})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFilterCost(t *testing.T) {
+	radius := 50.0
+	f := validFilter()
+	assert.Equal(t, 0, filterCost(f))
+
+	f.States = []string{"TX", "OK"}
+	f.Counties = []string{"Dallas"}
+	f.EventTypes = []model.EventType{model.EventTypeHail}
+	f.Severity = []model.Severity{model.SeveritySevere}
+	assert.Equal(t, 5, filterCost(f))
+
+	f.ExcludeCounties = []string{"Tarrant", "Collin"}
+	assert.Equal(t, 7, filterCost(f))
+	f.ExcludeCounties = nil
+
+	f.Pipelines = []string{"etl-v2"}
+	assert.Equal(t, 6, filterCost(f))
+	f.Pipelines = nil
+
+	f.ImpactKeywords = []string{"roof damage", "trees down"}
+	assert.Equal(t, 11, filterCost(f))
+	f.ImpactKeywords = nil
+
+	f.Near = &model.GeoRadiusFilter{Lat: 32.7, Lon: -96.8}
+	assert.Equal(t, 15, filterCost(f))
+
+	f.EventTypeFilters = []*model.EventTypeFilter{
+		{EventType: model.EventTypeHail, Severity: []model.Severity{model.SeveritySevere}},
+		{EventType: model.EventTypeWind, RadiusMiles: &radius},
+	}
+	assert.Equal(t, 15+3+12, filterCost(f))
+}
+
+func TestValidateFilter_FilterCost(t *testing.T) {
+	counties := make([]string, DefaultMaxFilterCost)
+	for i := range counties {
+		counties[i] = "County"
+	}
+
+	f := validFilter()
+	f.Counties = counties
+	require.NoError(t, ValidateFilter(f, Limits{}), "cost at budget is allowed")
+
+	f = validFilter()
+	f.Counties = counties
+	f.States = []string{"TX"}
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "filter too complex: cost 101 exceeds maximum of 100")
+
+	f = validFilter()
+	f.States = []string{"TX", "OK"}
+	f.Near = &model.GeoRadiusFilter{Lat: 32.7, Lon: -96.8}
+	err = ValidateFilter(f, Limits{MaxFilterCost: 10})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cost 12 exceeds maximum of 10")
+}
+
+func TestValidateFilter_QueryParams(t *testing.T) {
+	// Time bounds (2), bounding box (4), haversine (4), and the aggregation
+	// query's unit, time zone, magnitude edges, near center (3) and sample
+	// percentage (7).
+	near := func() *model.StormReportFilter {
+		f := validFilter()
+		f.Near = &model.GeoRadiusFilter{Lat: 32.7, Lon: -96.8}
+		return f
+	}
+	require.NoError(t, ValidateFilter(near(), Limits{}))
+	require.NoError(t, ValidateFilter(near(), Limits{MaxQueryParams: 17}))
+
+	err := ValidateFilter(near(), Limits{MaxQueryParams: 16})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "filter too large: needs 17 query parameters, maximum is 16")
+	var vErr *ValidationError
+	require.ErrorAs(t, err, &vErr)
+	assert.Equal(t, ruleQueryParams, vErr.Rule)
+}
+
+func TestValidateFilter_StatesNormalized(t *testing.T) {
+	f := validFilter()
+	f.States = []string{"tx", " ok ", "PR"}
+	require.NoError(t, ValidateFilter(f, Limits{}))
+	assert.Equal(t, []string{"TX", "OK", "PR"}, f.States)
+}
+
+func TestValidateFilter_UnknownState(t *testing.T) {
+	for _, state := range []string{"Texas", "XX", ""} {
+		f := validFilter()
+		f.States = []string{"TX", state}
+		err := ValidateFilter(f, Limits{})
+		require.Error(t, err, "state=%q", state)
+		assert.Contains(t, err.Error(), fmt.Sprintf("states[1]: unknown state code %q", state))
+		assert.Contains(t, err.Error(), "valid codes: AK, AL, AR")
+	}
+}
+
+func TestValidateFilter_ExcludeLocations(t *testing.T) {
+	f := validFilter()
+	f.ExcludeStates = []string{"tx", "ok"}
+	f.Counties = []string{"Cook"}
+	require.NoError(t, ValidateFilter(f, Limits{}))
+	assert.Equal(t, []string{"TX", "OK"}, f.ExcludeStates)
+
+	f = validFilter()
+	f.ExcludeStates = []string{"Texas"}
+	err := ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `excludeStates[0]: unknown state code "Texas"`)
+
+	f = validFilter()
+	f.States = []string{"TX"}
+	f.ExcludeStates = []string{"OK"}
+	err = ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "states and excludeStates cannot both be set")
+
+	f = validFilter()
+	f.Counties = []string{"Dallas"}
+	f.ExcludeCounties = []string{"Tarrant"}
+	err = ValidateFilter(f, Limits{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "counties and excludeCounties cannot both be set")
+}
+
+func TestStateCodesSorted(t *testing.T) {
+	// normalizeStateCode relies on binary search.
+	assert.True(t, slices.IsSorted(stateCodes))
+	assert.Len(t, stateCodes, 56)
+}
@@ -7,7 +7,6 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/couchcryptid/storm-data-api/internal/database"
 	"github.com/couchcryptid/storm-data-api/internal/graph"
@@ -20,7 +19,7 @@ import (
 
 func startGraphQLServer(t *testing.T, s *store.Store) *httptest.Server {
 	t.Helper()
-	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{
+	srv := graph.NewServer(graph.NewExecutableSchema(graph.Config{
 		Resolvers:  &graph.Resolver{Store: s},
 		Complexity: graph.NewComplexityRoot(),
 	}))
@@ -15,10 +15,10 @@ import (
 	"testing"
 	"time"
 
-	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/couchcryptid/storm-data-api/internal/database"
 	"github.com/couchcryptid/storm-data-api/internal/graph"
+	"github.com/couchcryptid/storm-data-api/internal/kafka"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
 	"github.com/couchcryptid/storm-data-api/internal/store"
@@ -43,12 +43,21 @@ func discardLogger() *slog.Logger {
 	return slog.New(slog.NewTextHandler(io.Discard, nil))
 }
 
+// groupCounts returns count applied to each aggregation group, in order.
+func groupCounts[G any](groups []G, count func(G) int) []int {
+	counts := make([]int, len(groups))
+	for i, g := range groups {
+		counts[i] = count(g)
+	}
+	return counts
+}
+
 // wideFilter returns a filter with a time window that covers all mock data
 // and a default page size matching the GraphQL layer's MaxPageSize.
 func wideFilter() *model.StormReportFilter {
 	limit := graph.MaxPageSize
 	return &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
 		},
@@ -141,6 +150,32 @@ func TestStoreInsertAndQuery(t *testing.T) {
 	for _, r := range txReports {
 		assert.Equal(t, "TX", r.Location.State, testReportMsg, r.ID)
 	}
+	_, txCount, err := s.ListStormReports(ctx, f)
+	require.NoError(t, err)
+
+	// Exclude state: the complement of the include filter
+	f = wideFilter()
+	f.ExcludeStates = []string{"TX"}
+	nonTXReports, nonTXCount, err := s.ListStormReports(ctx, f)
+	require.NoError(t, err)
+	assert.Equal(t, 271, txCount+nonTXCount)
+	for _, r := range nonTXReports {
+		assert.NotEqual(t, "TX", r.Location.State, testReportMsg, r.ID)
+	}
+
+	// Filter by pipeline: only the tagged report matches
+	tagged := reports[0]
+	tagged.ID += "-etl-v2"
+	tagged.Pipeline = "etl-v2"
+	require.NoError(t, s.InsertStormReport(ctx, &tagged))
+	f = wideFilter()
+	f.Pipelines = []string{"etl-v2"}
+	pipelineReports, pipelineCount, err := s.ListStormReports(ctx, f)
+	require.NoError(t, err)
+	assert.Equal(t, 1, pipelineCount)
+	require.Len(t, pipelineReports, 1)
+	assert.Equal(t, tagged.ID, pipelineReports[0].ID)
+	assert.Equal(t, "etl-v2", pipelineReports[0].Pipeline)
 
 	// Filter by geo radius (around Fort Worth, TX area)
 	f = wideFilter()
@@ -158,12 +193,134 @@ func TestStoreInsertAndQuery(t *testing.T) {
 	}
 }
 
+func TestStoreProcessedOffsets(t *testing.T) {
+	ctx := context.Background()
+
+	dsn, pg := startPostgres(ctx, t)
+	defer func() { _ = pg.Terminate(ctx) }()
+
+	require.NoError(t, database.RunMigrations(dsn))
+
+	pool, err := database.NewPool(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	s := store.New(pool, observability.NewTestMetrics())
+	reports := loadMockReports(t)
+
+	require.NoError(t, s.InsertStormReportsWithOffsets(ctx, "t", []*model.StormReport{&reports[0], &reports[1]}, map[int]int64{0: 7, 1: 3}))
+	// A lower offset for an existing partition must not move it backwards.
+	require.NoError(t, s.InsertStormReportsWithOffsets(ctx, "t", []*model.StormReport{&reports[2]}, map[int]int64{0: 5}))
+
+	offsets, err := s.ProcessedOffsets(ctx, "t")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int64{0: 7, 1: 3}, offsets)
+
+	_, count, err := s.ListStormReports(ctx, wideFilter())
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	other, err := s.ProcessedOffsets(ctx, "other-topic")
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestStoreLargestIngestionGap(t *testing.T) {
+	ctx := context.Background()
+
+	dsn, pg := startPostgres(ctx, t)
+	defer func() { _ = pg.Terminate(ctx) }()
+
+	require.NoError(t, database.RunMigrations(dsn))
+
+	pool, err := database.NewPool(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	s := store.New(pool, observability.NewTestMetrics())
+	reports := loadMockReports(t)
+
+	// Batches land at since-10h, since, +30m, +90m and +150m: the two 60-minute
+	// gaps tie, and the 10-hour gap starts before since.
+	since := time.Date(2024, 4, 27, 12, 0, 0, 0, time.UTC)
+	offsets := []time.Duration{-10 * time.Hour, 0, 30 * time.Minute, 90 * time.Minute, 150 * time.Minute}
+	for i, d := range offsets {
+		reports[i].ProcessedAt = since.Add(d)
+		require.NoError(t, s.InsertStormReport(ctx, &reports[i]), "insert report %s", reports[i].ID)
+	}
+
+	gap, err := s.LargestIngestionGap(ctx, since)
+	require.NoError(t, err)
+	require.NotNil(t, gap)
+	assert.True(t, gap.From.Equal(since.Add(90*time.Minute)), "ties go to the most recent gap, got from %v", gap.From)
+	assert.True(t, gap.To.Equal(since.Add(150*time.Minute)), "got to %v", gap.To)
+	assert.Equal(t, 60, gap.Minutes)
+
+	// From the start of time, the 10-hour gap wins.
+	gap, err = s.LargestIngestionGap(ctx, time.Time{})
+	require.NoError(t, err)
+	require.NotNil(t, gap)
+	assert.True(t, gap.From.Equal(since.Add(-10*time.Hour)), "got from %v", gap.From)
+	assert.True(t, gap.To.Equal(since), "got to %v", gap.To)
+	assert.Equal(t, 600, gap.Minutes)
+}
+
+func TestProbesTimeOutOnBlockedTable(t *testing.T) {
+	ctx := context.Background()
+
+	dsn, pg := startPostgres(ctx, t)
+	defer func() { _ = pg.Terminate(ctx) }()
+
+	require.NoError(t, database.RunMigrations(dsn))
+
+	pool, err := database.NewPool(ctx, dsn)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	s := store.New(pool, observability.NewTestMetrics())
+
+	// Hold an exclusive lock so every read of storm_reports blocks.
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+	_, err = tx.Exec(ctx, "LOCK TABLE storm_reports IN ACCESS EXCLUSIVE MODE")
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = s.LastUpdated(ctx)
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second, "LastUpdated should give up on its own deadline")
+
+	// The timed-out query must not leave a connection checked out.
+	assert.Eventually(t, func() bool {
+		return pool.Stat().AcquiredConns() == 1 // only the locking transaction
+	}, 2*time.Second, 50*time.Millisecond)
+
+	require.NoError(t, database.NewPoolReadiness(pool).CheckReadiness(ctx), "ping does not touch the locked table")
+}
+
+func TestPoolWarmUp(t *testing.T) {
+	ctx := context.Background()
+
+	dsn, pg := startPostgres(ctx, t)
+	defer func() { _ = pg.Terminate(ctx) }()
+
+	pool, err := database.NewPool(ctx, dsn+"&pool_max_conns=3")
+	require.NoError(t, err)
+	defer pool.Close()
+
+	warmed, err := database.WarmUp(ctx, pool, 5)
+	require.NoError(t, err)
+	assert.Equal(t, 3, warmed, "capped at pool_max_conns")
+	assert.Equal(t, int32(3), pool.Stat().IdleConns(), "warmed connections stay open in the pool")
+}
+
 func TestStoreAggregations(t *testing.T) {
 	ctx := context.Background()
 	s := setupStoreWithData(ctx, t)
 
 	t.Run("Aggregations CTE", func(t *testing.T) {
-		agg, err := s.Aggregations(ctx, wideFilter())
+		agg, err := s.Aggregations(ctx, wideFilter(), model.TimeGranularityHour)
 		require.NoError(t, err)
 
 		// ByEventType
@@ -186,6 +343,8 @@ func TestStoreAggregations(t *testing.T) {
 		assert.NotZero(t, stateCount["TX"])
 		assert.NotZero(t, stateCount["NE"])
 		assertStateCountyTotals(t, agg.ByState, "TX")
+		assert.IsNonIncreasing(t, groupCounts(agg.ByState, func(g *model.StateGroup) int { return g.Count }))
+		assert.IsNonIncreasing(t, groupCounts(agg.ByEventType, func(g *model.EventTypeGroup) int { return g.Count }))
 
 		// ByHour
 		require.NotEmpty(t, agg.ByHour)
@@ -195,6 +354,98 @@ func TestStoreAggregations(t *testing.T) {
 			assert.False(t, g.Bucket.IsZero(), "bucket should not be zero")
 		}
 		assert.Equal(t, 271, hourTotal)
+		for i := 1; i < len(agg.ByHour); i++ {
+			assert.True(t, agg.ByHour[i].Bucket.After(agg.ByHour[i-1].Bucket), "hour buckets out of order at %d", i)
+		}
+
+		// BySeverity
+		require.NotEmpty(t, agg.BySeverity)
+		severityTotal := 0
+		for _, g := range agg.BySeverity {
+			severityTotal += g.Count
+		}
+		assert.Equal(t, 271, severityTotal, "severity groups, including unclassified, cover every report")
+
+		// ByDayOfWeek: seven groups, Sunday first, covering every report.
+		// The mock reports all fall on Friday 2024-04-26 (UTC).
+		require.Len(t, agg.ByDayOfWeek, 7)
+		assert.Equal(t, "Sunday", agg.ByDayOfWeek[0].Name)
+		assert.Equal(t, 271, agg.ByDayOfWeek[int(time.Friday)].Count)
+
+		// ByMagnitudeBucket: default edges give at most four ranges per type,
+		// lowest first, with the open-ended ranges at each end.
+		require.NotEmpty(t, agg.ByMagnitudeBucket)
+		perType := map[string]int{}
+		for _, g := range agg.ByMagnitudeBucket {
+			perType[g.EventType]++
+			assert.Positive(t, g.Count)
+			if g.Min != nil && g.Max != nil {
+				assert.Less(t, *g.Min, *g.Max)
+			}
+		}
+		for et, n := range perType {
+			assert.LessOrEqual(t, n, 4, et)
+		}
+
+		// Identical queries return identical ordering.
+		again, err := s.Aggregations(ctx, wideFilter(), model.TimeGranularityHour)
+		require.NoError(t, err)
+		assert.Equal(t, agg, again)
+	})
+
+	t.Run("sampled", func(t *testing.T) {
+		exact, err := s.Aggregations(ctx, wideFilter(), model.TimeGranularityHour)
+		require.NoError(t, err)
+		assert.Zero(t, exact.SampleFraction)
+
+		// A full-table sample takes the TABLESAMPLE path but must agree with
+		// the exact result.
+		s.SetAggregationSample(100)
+		t.Cleanup(func() { s.SetAggregationSample(0) })
+		sample := true
+		filter := wideFilter()
+		filter.SampleAggregations = &sample
+		agg, err := s.Aggregations(ctx, filter, model.TimeGranularityHour)
+		require.NoError(t, err)
+		assert.InDelta(t, 1.0, agg.SampleFraction, 0)
+		agg.SampleFraction = 0
+		assert.Equal(t, exact, agg)
+	})
+
+	t.Run("daily buckets", func(t *testing.T) {
+		agg, err := s.Aggregations(ctx, wideFilter(), model.TimeGranularityDay)
+		require.NoError(t, err)
+		total := 0
+		for _, g := range agg.ByHour {
+			total += g.Count
+			assert.Equal(t, g.Bucket.UTC().Truncate(24*time.Hour), g.Bucket.UTC(), "bucket %s not at UTC midnight", g.Bucket)
+		}
+		assert.Equal(t, 271, total)
+		assert.Len(t, agg.ByHour, 1, "all mock reports fall on 2024-04-26")
+	})
+
+	t.Run("nearest distance", func(t *testing.T) {
+		exact, err := s.Aggregations(ctx, wideFilter(), model.TimeGranularityHour)
+		require.NoError(t, err)
+		for _, sg := range exact.ByState {
+			assert.Nil(t, sg.NearestMiles, "no near filter, no distance for %s", sg.State)
+		}
+
+		radius := 20.0
+		filter := wideFilter()
+		filter.Near = &model.GeoRadiusFilter{Lat: 32.75, Lon: -97.15, RadiusMiles: &radius}
+		agg, err := s.Aggregations(ctx, filter, model.TimeGranularityHour)
+		require.NoError(t, err)
+		require.NotEmpty(t, agg.ByState)
+		for _, sg := range agg.ByState {
+			require.NotNil(t, sg.NearestMiles, sg.State)
+			assert.LessOrEqual(t, *sg.NearestMiles, radius)
+			for _, cg := range sg.Counties {
+				require.NotNil(t, cg.NearestMiles, cg.County)
+				assert.GreaterOrEqual(t, *cg.NearestMiles, *sg.NearestMiles)
+				assert.LessOrEqual(t, *cg.NearestMiles, radius)
+			}
+		}
 	})
 
 	t.Run("LastUpdated", func(t *testing.T) {
@@ -204,10 +455,142 @@ func TestStoreAggregations(t *testing.T) {
 		assert.False(t, ts.IsZero())
 	})
 
+	t.Run("EventTimeExtent", func(t *testing.T) {
+		ext, err := s.EventTimeExtent(ctx)
+		require.NoError(t, err)
+		require.NotNil(t, ext)
+		day := time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC)
+		assert.False(t, ext.Earliest.Before(day))
+		assert.True(t, ext.Latest.Before(day.Add(24*time.Hour)))
+		assert.False(t, ext.Latest.Before(ext.Earliest))
+	})
+
+	t.Run("LargestIngestionGap", func(t *testing.T) {
+		// All mock reports share processed_at, so there is no gap to report.
+		gap, err := s.LargestIngestionGap(ctx, time.Time{})
+		require.NoError(t, err)
+		assert.Nil(t, gap)
+	})
+
+	t.Run("StormReportByID", func(t *testing.T) {
+		listed, _, err := s.ListStormReports(ctx, wideFilter())
+		require.NoError(t, err)
+		require.NotEmpty(t, listed)
+
+		r, err := s.StormReportByID(ctx, listed[0].ID)
+		require.NoError(t, err)
+		require.NotNil(t, r)
+		assert.Equal(t, listed[0], r)
+
+		missing, err := s.StormReportByID(ctx, "no-such-report")
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("DistinctEventTypes", func(t *testing.T) {
+		types, err := s.DistinctEventTypes(ctx, *wideFilter().TimeRange)
+		require.NoError(t, err)
+		assert.Equal(t, model.AllEventTypes, types)
+
+		empty := model.TimeRange{
+			From: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+		}
+		types, err = s.DistinctEventTypes(ctx, empty)
+		require.NoError(t, err)
+		assert.Empty(t, types)
+	})
+
+	t.Run("StreamStormReports walks the cursor in order", func(t *testing.T) {
+		f := wideFilter()
+		var prev *model.StormReport
+		count := 0
+		err := s.StreamStormReports(ctx, f.TimeRange.From, f.TimeRange.To, func(r *model.StormReport) error {
+			if prev != nil {
+				assert.False(t, r.EventTime.Before(prev.EventTime), testReportMsg, r.ID)
+			}
+			prev = r
+			count++
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 271, count)
+	})
+
+	t.Run("Extent", func(t *testing.T) {
+		f := wideFilter()
+		ext, err := s.Extent(ctx, f)
+		require.NoError(t, err)
+		b := ext.Bounds
+		require.NotNil(t, b)
+		require.NotNil(t, ext.Centroid)
+		assert.True(t, ext.Centroid.Lat >= b.MinLat && ext.Centroid.Lat <= b.MaxLat)
+		assert.True(t, ext.Centroid.Lon >= b.MinLon && ext.Centroid.Lon <= b.MaxLon)
+		assert.LessOrEqual(t, b.MinLat, b.MaxLat)
+		assert.LessOrEqual(t, b.MinLon, b.MaxLon)
+
+		// Bounds ignore pagination, so every listed report lies inside them.
+		reports, _, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		for _, r := range reports {
+			assert.True(t, r.Geo.Lat >= b.MinLat && r.Geo.Lat <= b.MaxLat, testReportMsg, r.ID)
+			assert.True(t, r.Geo.Lon >= b.MinLon && r.Geo.Lon <= b.MaxLon, testReportMsg, r.ID)
+		}
+
+		f.EventTypes = []model.EventType{model.EventType("BLIZZARD")}
+		none, err := s.Extent(ctx, f)
+		require.NoError(t, err)
+		assert.Nil(t, none.Bounds)
+		assert.Nil(t, none.Centroid)
+	})
+
+	t.Run("PlaceCandidates", func(t *testing.T) {
+		// The mock data names Omaha in both Nebraska and Texas.
+		places, err := s.PlaceCandidates(ctx, "omaha", "")
+		require.NoError(t, err)
+		require.Len(t, places, 2)
+		assert.Equal(t, "NE", places[0].State)
+		assert.Equal(t, "TX", places[1].State)
+		assert.Equal(t, "Omaha", places[0].Name)
+
+		places, err = s.PlaceCandidates(ctx, "Omaha", "NE")
+		require.NoError(t, err)
+		require.Len(t, places, 1)
+		assert.InDelta(t, 41.3, places[0].Lat, 0.5)
+		assert.InDelta(t, -96.0, places[0].Lon, 0.5)
+
+		places, err = s.PlaceCandidates(ctx, "Atlantis", "")
+		require.NoError(t, err)
+		assert.Empty(t, places)
+	})
+
+	t.Run("ListStormReports projects requested fields", func(t *testing.T) {
+		f := wideFilter()
+		full, count, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		slim, slimCount, err := s.ListStormReports(ctx, f, "geo", "eventTime")
+		require.NoError(t, err)
+
+		assert.Equal(t, count, slimCount)
+		require.Len(t, slim, len(full))
+		byID := make(map[string]*model.StormReport, len(full))
+		for _, r := range full {
+			byID[r.ID] = r
+		}
+		for _, r := range slim {
+			want := byID[r.ID]
+			require.NotNil(t, want, testReportMsg, r.ID)
+			assert.Equal(t, want.Geo, r.Geo)
+			assert.Equal(t, want.EventTime, r.EventTime)
+			assert.Empty(t, r.Comments, "unselected columns are not read")
+			assert.Empty(t, r.Location.County)
+		}
+	})
+
 	t.Run("Aggregations with event type filter", func(t *testing.T) {
 		f := wideFilter()
 		f.EventTypes = []model.EventType{model.EventTypeHail}
-		agg, err := s.Aggregations(ctx, f)
+		agg, err := s.Aggregations(ctx, f, model.TimeGranularityHour)
 		require.NoError(t, err)
 		require.Len(t, agg.ByEventType, 1)
 		assert.Equal(t, "hail", agg.ByEventType[0].EventType)
@@ -239,6 +622,24 @@ func TestStoreFilters(t *testing.T) {
 		assert.Equal(t, 81, count)
 	})
 
+	t.Run("minSeverity filter", func(t *testing.T) {
+		f := wideFilter()
+		minSeverity := model.SeverityModerate
+		f.MinSeverity = &minSeverity
+		reports, count, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+
+		f = wideFilter()
+		f.Severity = minSeverity.AtOrAbove()
+		_, explicit, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		assert.Equal(t, explicit, count, "minSeverity matches the explicit severity set")
+		for _, r := range reports {
+			require.NotNil(t, r.Measurement.Severity, testReportMsg, r.ID)
+			assert.NotEqual(t, "minor", *r.Measurement.Severity, testReportMsg, r.ID)
+		}
+	})
+
 	t.Run("counties filter", func(t *testing.T) {
 		f := wideFilter()
 		f.Counties = []string{"Tarrant"}
@@ -250,6 +651,24 @@ func TestStoreFilters(t *testing.T) {
 		}
 	})
 
+	t.Run("idPrefix filter", func(t *testing.T) {
+		f := wideFilter()
+		prefix := "hail-5d91dda"
+		f.IDPrefix = &prefix
+		reports, count, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		require.Len(t, reports, 1)
+		assert.Equal(t, "hail-5d91dda0f56ba124", reports[0].ID)
+
+		// "_" is a LIKE wildcard and must not match the "-" in real IDs.
+		wildcard := "hail_5d91dda"
+		f.IDPrefix = &wildcard
+		_, count, err = s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
 	t.Run("minMagnitude filter", func(t *testing.T) {
 		f := wideFilter()
 		min := 1.75
@@ -262,6 +681,18 @@ func TestStoreFilters(t *testing.T) {
 		}
 	})
 
+	t.Run("impact keywords", func(t *testing.T) {
+		f := wideFilter()
+		f.ImpactKeywords = []string{"ROOF", "power line"}
+		reports, count, err := s.ListStormReports(ctx, f)
+		require.NoError(t, err)
+		assert.Equal(t, 9, count)
+		for _, r := range reports {
+			comments := strings.ToLower(r.Comments)
+			assert.True(t, strings.Contains(comments, "roof") || strings.Contains(comments, "power line"), testReportMsg, r.ID)
+		}
+	})
+
 	t.Run("combined filters", func(t *testing.T) {
 		f := wideFilter()
 		f.EventTypes = []model.EventType{model.EventTypeHail}
@@ -485,16 +916,9 @@ func TestKafkaConsumerIntegration(t *testing.T) {
 
 	s := store.New(pool, observability.NewTestMetrics())
 
-	// Create topic
-	conn, err := kafkago.Dial("tcp", broker)
-	require.NoError(t, err, "dial kafka")
-	err = conn.CreateTopics(kafkago.TopicConfig{
-		Topic:             testKafkaTopic,
-		NumPartitions:     1,
-		ReplicationFactor: 1,
-	})
-	conn.Close()
-	require.NoError(t, err, "create topic")
+	// Create topic, twice to show an existing topic is not an error
+	require.NoError(t, kafka.EnsureTopic(ctx, []string{broker}, testKafkaTopic, 1), "create topic")
+	require.NoError(t, kafka.EnsureTopic(ctx, []string{broker}, testKafkaTopic, 1), "topic already exists")
 
 	// Produce mock messages
 	reports := loadMockReports(t)
@@ -602,6 +1026,32 @@ func TestGraphQLEndpoint(t *testing.T) {
 	require.NoError(t, json.NewDecoder(resp2.Body).Decode(&filtered))
 	assert.Len(t, filtered.Data.StormReports.Reports, 20) // default page size
 	assert.Equal(t, 79, filtered.Data.StormReports.TotalCount)
+
+	// Single report by ID; an unknown ID is null, not an error.
+	id := filtered.Data.StormReports.Reports[0].ID
+	body = `{"query":"{ found: stormReport(id: \"` + id + `\") { id eventType } missing: stormReport(id: \"no-such-report\") { id } }"}`
+	resp3, err := http.Post(srv.URL+graphQLPath, contentJSON, strings.NewReader(body))
+	require.NoError(t, err)
+	defer resp3.Body.Close()
+
+	var single struct {
+		Data struct {
+			Found *struct {
+				ID        string `json:"id"`
+				EventType string `json:"eventType"`
+			} `json:"found"`
+			Missing *struct {
+				ID string `json:"id"`
+			} `json:"missing"`
+		} `json:"data"`
+		Errors []any `json:"errors"`
+	}
+	require.NoError(t, json.NewDecoder(resp3.Body).Decode(&single))
+	assert.Empty(t, single.Errors)
+	require.NotNil(t, single.Data.Found)
+	assert.Equal(t, id, single.Data.Found.ID)
+	assert.Equal(t, "hail", single.Data.Found.EventType)
+	assert.Nil(t, single.Data.Missing)
 }
 
 func TestGraphQLDepthExceeded(t *testing.T) {
@@ -609,7 +1059,7 @@ func TestGraphQLDepthExceeded(t *testing.T) {
 	s := setupStoreWithData(ctx, t)
 
 	// Create server with low depth limit to test rejection.
-	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{
+	srv := graph.NewServer(graph.NewExecutableSchema(graph.Config{
 		Resolvers:  &graph.Resolver{Store: s},
 		Complexity: graph.NewComplexityRoot(),
 	}))
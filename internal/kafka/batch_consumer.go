@@ -2,10 +2,10 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
 	"github.com/couchcryptid/storm-data-shared/retry"
@@ -14,9 +14,17 @@ import (
 
 // batchItem holds a fetched Kafka message and its unmarshalled result.
 type batchItem struct {
-	msg    kafkago.Message
-	report *model.StormReport
-	err    error // non-nil if unmarshal failed (poison pill)
+	msg     kafkago.Message
+	report  *model.StormReport
+	traceID string
+	err     error // non-nil if unmarshal failed (poison pill)
+}
+
+// OffsetStore records processed Kafka offsets in the same transaction as the
+// inserted reports, so redelivered messages can be recognised and skipped.
+type OffsetStore interface {
+	InsertStormReportsWithOffsets(ctx context.Context, topic string, reports []*model.StormReport, offsets map[int]int64) error
+	ProcessedOffsets(ctx context.Context, topic string) (map[int]int64, error)
 }
 
 // BatchConsumer reads storm reports from Kafka in batches and persists them to the store.
@@ -26,48 +34,108 @@ type BatchConsumer struct {
 	topic         string
 	batchSize     int
 	flushInterval time.Duration
+	minBatchSize  int
+	maxWait       time.Duration
+	insertTimeout time.Duration
+	drainTimeout  time.Duration
+	insertHealth  *InsertHealth
+	fetchReady    *FetchReadiness
+	insertLimit   *InsertLimiter
 	logger        *slog.Logger
 	metrics       *observability.Metrics
+	clock         clock.Clock
+
+	offsets   OffsetStore
+	processed map[int]int64 // highest stored offset per partition; nil until loaded
 }
 
 // NewBatchConsumer creates a batch consumer with time-bounded fetching.
+// A batch smaller than minBatchSize at flushInterval keeps collecting for up to
+// maxWait longer; pass minBatchSize 1 to always flush at flushInterval.
 func NewBatchConsumer(
 	brokers []string,
 	topic, groupID string,
+	fetch FetchOptions,
 	batchSize int,
 	flushInterval time.Duration,
+	minBatchSize int,
+	maxWait time.Duration,
 	s StoreInserter,
 	m *observability.Metrics,
 	logger *slog.Logger,
 ) *BatchConsumer {
-	reader := kafkago.NewReader(kafkago.ReaderConfig{
-		Brokers:     brokers,
-		Topic:       topic,
-		GroupID:     groupID,
-		StartOffset: kafkago.FirstOffset,
-		MinBytes:    1,
-		MaxBytes:    10e6, // 10 MB
-	})
 	return &BatchConsumer{
-		reader:        reader,
+		reader:        newReader(brokers, topic, groupID, fetch),
 		store:         s,
 		topic:         topic,
 		batchSize:     batchSize,
 		flushInterval: flushInterval,
+		minBatchSize:  minBatchSize,
+		maxWait:       maxWait,
 		logger:        logger,
 		metrics:       m,
+		clock:         clock.Real{},
 	}
 }
 
-// Run consumes messages in batches until the context is cancelled.
+// EnableExactlyOnce makes the consumer write reports through offsets together with
+// their partition offsets, and skip any message at or below the stored offset
+// for its partition. This covers the window between the database insert and
+// the Kafka commit, which ON CONFLICT alone only papers over.
+func (bc *BatchConsumer) EnableExactlyOnce(offsets OffsetStore) {
+	bc.offsets = offsets
+}
+
+// SetInsertTimeout bounds each batch insert. Zero means inserts are limited
+// only by the Run context.
+func (bc *BatchConsumer) SetInsertTimeout(d time.Duration) {
+	bc.insertTimeout = d
+}
+
+// SetDrainTimeout lets the batch being inserted when Run's context is
+// cancelled, or fetched just before, finish and commit for up to d instead of
+// failing with the cancelled context. Zero disables draining.
+func (bc *BatchConsumer) SetDrainTimeout(d time.Duration) {
+	bc.drainTimeout = d
+}
+
+// SetInsertHealth reports every batch insert outcome to h, which readiness can
+// consult to flag a persistently failing write path.
+func (bc *BatchConsumer) SetInsertHealth(h *InsertHealth) {
+	bc.insertHealth = h
+}
+
+// SetFetchReadiness marks f verified on the first successful fetch.
+func (bc *BatchConsumer) SetFetchReadiness(f *FetchReadiness) {
+	bc.fetchReady = f
+}
+
+// SetInsertLimiter makes inserts wait for a slot in l, which may be shared
+// with other consumers so parallel readers don't exhaust the pool.
+func (bc *BatchConsumer) SetInsertLimiter(l *InsertLimiter) {
+	bc.insertLimit = l
+}
+
+// SetClock replaces the clock that times fetch retry backoff.
+func (bc *BatchConsumer) SetClock(clk clock.Clock) {
+	bc.clock = clk
+}
+
+// Run consumes messages in batches until the context is cancelled. With a
+// drain timeout set, the last batch is still flushed after cancellation.
 func (bc *BatchConsumer) Run(ctx context.Context) error {
+	processCtx, cancel := drainContext(ctx, bc.drainTimeout)
+	defer cancel()
+
 	bc.logger.Info("kafka batch consumer started",
-		"topic", bc.topic, "batch_size", bc.batchSize, "flush_interval", bc.flushInterval)
-	bc.metrics.KafkaConsumerRunning.WithLabelValues(bc.topic).Set(1)
-	defer bc.metrics.KafkaConsumerRunning.WithLabelValues(bc.topic).Set(0)
+		"topic", bc.topic, "batch_size", bc.batchSize, "flush_interval", bc.flushInterval,
+		"min_batch_size", bc.minBatchSize, "max_wait", bc.maxWait)
+	bc.metrics.KafkaConsumerRunning.WithLabelValues(bc.topic, modeBatch).Set(1)
+	defer bc.metrics.KafkaConsumerRunning.WithLabelValues(bc.topic, modeBatch).Set(0)
 
 	// Exponential backoff: start at 200ms, double each retry, cap at 5s.
 	// Keeps retry storms short while avoiding tight loops during Kafka outages.
+	// Each sleep is jittered over [0, backoff] so restarted instances spread out.
 	backoff := 200 * time.Millisecond
 	maxBackoff := 5 * time.Second
 
@@ -77,10 +145,13 @@ func (bc *BatchConsumer) Run(ctx context.Context) error {
 			if ctx.Err() != nil {
 				return nil
 			}
-			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, "fetch_batch").Inc()
-			bc.logger.Error("fetch batch", "error", err, "retry_in", backoff)
-			if !retry.SleepWithContext(ctx, backoff) {
+			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, modeBatch, "fetch_batch").Inc()
+			delay := fullJitter(backoff)
+			bc.logger.Error("fetch batch", "error", err, "retry_in", delay)
+			select {
+			case <-ctx.Done():
 				return nil
+			case <-bc.clock.After(delay):
 			}
 			backoff = retry.NextBackoff(backoff, maxBackoff)
 			continue
@@ -94,11 +165,14 @@ func (bc *BatchConsumer) Run(ctx context.Context) error {
 			continue
 		}
 
-		bc.processBatch(ctx, items)
+		bc.processBatch(processCtx, items)
 	}
 }
 
 // fetchBatch collects up to batchSize messages or until flushInterval elapses.
+// While fewer than minBatchSize messages have arrived the deadline stretches
+// by maxWait, so quiet periods produce fewer single-row inserts but latency
+// stays bounded at flushInterval+maxWait.
 func (bc *BatchConsumer) fetchBatch(ctx context.Context) ([]batchItem, error) {
 	start := time.Now()
 	defer func() {
@@ -106,9 +180,14 @@ func (bc *BatchConsumer) fetchBatch(ctx context.Context) ([]batchItem, error) {
 	}()
 
 	items := make([]batchItem, 0, bc.batchSize)
-	deadline := time.Now().Add(bc.flushInterval)
+	flushDeadline := start.Add(bc.flushInterval)
+	maxDeadline := flushDeadline.Add(bc.maxWait)
 
 	for len(items) < bc.batchSize {
+		deadline := flushDeadline
+		if len(items) < bc.minBatchSize {
+			deadline = maxDeadline
+		}
 		timeout := time.Until(deadline)
 		if timeout <= 0 {
 			break
@@ -129,13 +208,15 @@ func (bc *BatchConsumer) fetchBatch(ctx context.Context) ([]batchItem, error) {
 			}
 			return nil, err
 		}
+		bc.fetchReady.markFetched()
 
-		var report model.StormReport
-		if unmarshalErr := json.Unmarshal(msg.Value, &report); unmarshalErr != nil {
-			items = append(items, batchItem{msg: msg, err: unmarshalErr})
-		} else {
-			items = append(items, batchItem{msg: msg, report: &report})
-		}
+		report, decodeErr := decodeMessage(msg)
+		items = append(items, batchItem{
+			msg:     msg,
+			report:  report,
+			traceID: headerValue(msg, HeaderTraceID),
+			err:     decodeErr,
+		})
 	}
 
 	bc.metrics.KafkaBatchSize.WithLabelValues(bc.topic).Observe(float64(len(items)))
@@ -155,8 +236,9 @@ func (bc *BatchConsumer) processBatch(ctx context.Context, items []batchItem) {
 
 	for i := range items {
 		if items[i].err != nil {
-			bc.logger.Error("unmarshal in batch", "error", items[i].err, "offset", items[i].msg.Offset)
-			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, "unmarshal").Inc()
+			bc.logger.Error("unmarshal in batch", "error", items[i].err,
+				"offset", items[i].msg.Offset, "trace_id", items[i].traceID)
+			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, modeBatch, "unmarshal").Inc()
 			poisonMsgs = append(poisonMsgs, items[i].msg)
 		} else {
 			validReports = append(validReports, items[i].report)
@@ -170,27 +252,128 @@ func (bc *BatchConsumer) processBatch(ctx context.Context, items []batchItem) {
 	if len(poisonMsgs) > 0 {
 		if err := bc.reader.CommitMessages(ctx, poisonMsgs...); err != nil {
 			bc.logger.Error("commit poison pills", "error", err, "count", len(poisonMsgs))
+			bc.metrics.KafkaCommitErrors.WithLabelValues(bc.topic, modeBatch).Inc()
 		}
 	}
 
+	if bc.offsets != nil {
+		bc.processWithOffsets(ctx, validReports, validMsgs)
+		return
+	}
+
 	if len(validReports) == 0 {
 		return
 	}
 
-	if err := bc.store.InsertStormReports(ctx, validReports); err != nil {
-		bc.logger.Error("batch insert storm reports", "error", err, "count", len(validReports))
-		bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, "batch_insert").Inc()
+	unique := bc.dedupeByID(validReports)
+	err := bc.insertLimit.do(ctx, func() error {
+		insertCtx, cancel := withInsertTimeout(ctx, bc.insertTimeout)
+		defer cancel()
+		return bc.store.InsertStormReports(insertCtx, unique)
+	})
+	bc.insertHealth.record(err)
+	if err != nil {
+		bc.logger.Error("batch insert storm reports", "error", err, "count", len(unique))
+		bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, modeBatch, insertErrorType("batch_insert", err)).Inc()
 		return
 	}
+	observePipelineLatency(bc.metrics, unique...)
 
 	if err := bc.reader.CommitMessages(ctx, validMsgs...); err != nil {
 		bc.logger.Error("commit batch offsets", "error", err, "count", len(validMsgs))
+		bc.metrics.KafkaCommitErrors.WithLabelValues(bc.topic, modeBatch).Inc()
 	}
 
-	bc.metrics.KafkaMessagesConsumed.WithLabelValues(bc.topic).Add(float64(len(validReports)))
+	bc.metrics.KafkaMessagesConsumed.WithLabelValues(bc.topic, modeBatch).Add(float64(len(validReports)))
 	bc.logger.Debug("consumed batch", "count", len(validReports))
 }
 
+// processWithOffsets inserts reports not yet recorded in the offset table,
+// together with their offsets, and then commits every message to Kafka.
+// Messages at or below a partition's stored offset were written by an earlier
+// run that stopped before its Kafka commit; they are committed without being
+// inserted again.
+func (bc *BatchConsumer) processWithOffsets(ctx context.Context, reports []*model.StormReport, msgs []kafkago.Message) {
+	if len(msgs) == 0 {
+		return
+	}
+	if bc.processed == nil {
+		processed, err := bc.offsets.ProcessedOffsets(ctx, bc.topic)
+		if err != nil {
+			bc.logger.Error("load processed offsets", "error", err)
+			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, modeBatch, "load_offsets").Inc()
+			return
+		}
+		bc.processed = processed
+	}
+
+	// Offsets come from every fresh message, including ones whose report
+	// duplicates another partition's, so no partition's progress is lost.
+	var fresh []*model.StormReport
+	batchOffsets := make(map[int]int64)
+	for i, msg := range msgs {
+		if last, ok := bc.processed[msg.Partition]; ok && msg.Offset <= last {
+			continue
+		}
+		fresh = append(fresh, reports[i])
+		if last, ok := batchOffsets[msg.Partition]; !ok || msg.Offset > last {
+			batchOffsets[msg.Partition] = msg.Offset
+		}
+	}
+	if skipped := len(msgs) - len(fresh); skipped > 0 {
+		bc.logger.Info("skipped already processed messages", "count", skipped)
+	}
+
+	if len(fresh) > 0 {
+		unique := bc.dedupeByID(fresh)
+		err := bc.insertLimit.do(ctx, func() error {
+			insertCtx, cancel := withInsertTimeout(ctx, bc.insertTimeout)
+			defer cancel()
+			return bc.offsets.InsertStormReportsWithOffsets(insertCtx, bc.topic, unique, batchOffsets)
+		})
+		bc.insertHealth.record(err)
+		if err != nil {
+			bc.logger.Error("batch insert storm reports", "error", err, "count", len(unique))
+			bc.metrics.KafkaConsumerErrors.WithLabelValues(bc.topic, modeBatch, insertErrorType("batch_insert", err)).Inc()
+			return
+		}
+		for partition, offset := range batchOffsets {
+			bc.processed[partition] = offset
+		}
+		observePipelineLatency(bc.metrics, unique...)
+	}
+
+	if err := bc.reader.CommitMessages(ctx, msgs...); err != nil {
+		bc.logger.Error("commit batch offsets", "error", err, "count", len(msgs))
+		bc.metrics.KafkaCommitErrors.WithLabelValues(bc.topic, modeBatch).Inc()
+	}
+
+	bc.metrics.KafkaMessagesConsumed.WithLabelValues(bc.topic, modeBatch).Add(float64(len(fresh)))
+	bc.logger.Debug("consumed batch", "count", len(fresh))
+}
+
+// dedupeByID returns reports without those whose ID already appeared earlier
+// in the batch, keeping the first. A report mis-keyed upstream can arrive on
+// two partitions and land in one batch; ON CONFLICT makes the second insert a
+// no-op, but it would still be published and observed twice. Dropped
+// duplicates are counted and logged. Their messages are still committed.
+func (bc *BatchConsumer) dedupeByID(reports []*model.StormReport) []*model.StormReport {
+	seen := make(map[string]bool, len(reports))
+	unique := make([]*model.StormReport, 0, len(reports))
+	for _, r := range reports {
+		if seen[r.ID] {
+			bc.logger.Warn("duplicate report id in batch", "id", r.ID)
+			continue
+		}
+		seen[r.ID] = true
+		unique = append(unique, r)
+	}
+	if dropped := len(reports) - len(unique); dropped > 0 {
+		bc.metrics.KafkaBatchDuplicates.WithLabelValues(bc.topic).Add(float64(dropped))
+	}
+	return unique
+}
+
 // Close shuts down the underlying Kafka reader.
 func (bc *BatchConsumer) Close() error {
 	return bc.reader.Close()
@@ -4,12 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	kafkago "github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -24,11 +28,28 @@ func newTestBatchConsumer(reader *mockReader, store *mockStore) *BatchConsumer {
 		topic:         "test-topic",
 		batchSize:     50,
 		flushInterval: 500 * time.Millisecond,
+		minBatchSize:  1,
 		logger:        slog.Default(),
 		metrics:       observability.NewTestMetrics(),
+		clock:         clock.Real{},
 	}
 }
 
+// distinctItems returns n valid batch items on partition 0 at consecutive
+// offsets from first, each carrying its own report ID.
+func distinctItems(t *testing.T, first int64, n int) []batchItem {
+	t.Helper()
+	items := make([]batchItem, n)
+	for i := range items {
+		report := validReport()
+		report.ID = fmt.Sprintf("report-%d", first+int64(i))
+		data, err := json.Marshal(report)
+		require.NoError(t, err)
+		items[i] = batchItem{msg: kafkaMsg(data, first+int64(i)), report: &report}
+	}
+	return items
+}
+
 // --- fetchBatch tests ---
 
 func TestFetchBatch_FullBatch(t *testing.T) {
@@ -69,6 +90,61 @@ func TestFetchBatch_FlushTimeout(t *testing.T) {
 	assert.Len(t, items, 1)
 }
 
+func TestFetchBatch_MinBatchSizeExtendsWait(t *testing.T) {
+	// One message is below minBatchSize, so the flush is held for maxWait.
+	data := validMessageBytes(t)
+	reader := &mockReader{
+		msgs: []kafkago.Message{kafkaMsg(data, 0)},
+	}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.flushInterval = 100 * time.Millisecond
+	bc.minBatchSize = 5
+	bc.maxWait = 200 * time.Millisecond
+
+	start := time.Now()
+	items, err := bc.fetchBatch(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.GreaterOrEqual(t, time.Since(start), 300*time.Millisecond)
+}
+
+func TestFetchBatch_MinBatchSizeReachedFlushesOnInterval(t *testing.T) {
+	data := validMessageBytes(t)
+	reader := &mockReader{
+		msgs: []kafkago.Message{kafkaMsg(data, 0), kafkaMsg(data, 1)},
+	}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.flushInterval = 100 * time.Millisecond
+	bc.minBatchSize = 2
+	bc.maxWait = 5 * time.Second
+
+	start := time.Now()
+	items, err := bc.fetchBatch(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestFetchBatch_MinBatchSizeRespectsContext(t *testing.T) {
+	data := validMessageBytes(t)
+	reader := &mockReader{
+		msgs: []kafkago.Message{kafkaMsg(data, 0)},
+	}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.flushInterval = 50 * time.Millisecond
+	bc.minBatchSize = 5
+	bc.maxWait = 5 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 150*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	items, err := bc.fetchBatch(ctx)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
 func TestFetchBatch_PoisonPill(t *testing.T) {
 	reader := &mockReader{
 		msgs: []kafkago.Message{
@@ -100,18 +176,11 @@ func TestFetchBatch_FetchError(t *testing.T) {
 // --- processBatch tests ---
 
 func TestProcessBatch_HappyPath(t *testing.T) {
-	data := validMessageBytes(t)
 	reader := &mockReader{}
 	store := &mockStore{}
 	bc := newTestBatchConsumer(reader, store)
 
-	var report model.StormReport
-	require.NoError(t, json.Unmarshal(data, &report))
-
-	items := []batchItem{
-		{msg: kafkaMsg(data, 0), report: &report},
-		{msg: kafkaMsg(data, 1), report: &report},
-	}
+	items := distinctItems(t, 0, 2)
 
 	bc.processBatch(context.Background(), items)
 
@@ -124,6 +193,26 @@ func TestProcessBatch_HappyPath(t *testing.T) {
 	assert.Len(t, reader.committed, 2)
 }
 
+func TestProcessBatch_CommitError(t *testing.T) {
+	data := validMessageBytes(t)
+	reader := &mockReader{commitErr: errors.New("commit failed")}
+	store := &mockStore{}
+	bc := newTestBatchConsumer(reader, store)
+
+	var report model.StormReport
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	bc.processBatch(context.Background(), []batchItem{
+		{msg: kafkaMsg(data, 0), report: &report},
+		{msg: kafkaMsg([]byte("not json"), 1), err: errors.New("bad json")},
+	})
+
+	// Poison pill commit and batch commit both fail.
+	assert.InDelta(t, 2, testutil.ToFloat64(bc.metrics.KafkaCommitErrors.WithLabelValues("test-topic", modeBatch)), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(bc.metrics.KafkaMessagesConsumed.WithLabelValues("test-topic", modeBatch)), 0,
+		"inserted reports still count as consumed")
+}
+
 func TestProcessBatch_PoisonPillsCommitted(t *testing.T) {
 	data := validMessageBytes(t)
 	reader := &mockReader{}
@@ -171,6 +260,33 @@ func TestProcessBatch_InsertError(t *testing.T) {
 	assert.Empty(t, reader.committed)
 }
 
+// blockingStore simulates a stalled database: inserts wait for their context.
+type blockingStore struct{ mockStore }
+
+func (b *blockingStore) InsertStormReports(ctx context.Context, _ []*model.StormReport) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func TestProcessBatch_InsertTimeout(t *testing.T) {
+	data := validMessageBytes(t)
+	var report model.StormReport
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	reader := &mockReader{}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.store = &blockingStore{}
+	bc.SetInsertTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	bc.processBatch(context.Background(), []batchItem{{msg: kafkaMsg(data, 0), report: &report}})
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Empty(t, reader.committed)
+	assert.InDelta(t, 1, testutil.ToFloat64(bc.metrics.KafkaConsumerErrors.WithLabelValues("test-topic", modeBatch, "batch_insert_timeout")), 0)
+	assert.InDelta(t, 0, testutil.ToFloat64(bc.metrics.KafkaConsumerErrors.WithLabelValues("test-topic", modeBatch, "batch_insert")), 0)
+}
+
 // --- Run tests ---
 
 func TestBatchRun_ContextCancelled(t *testing.T) {
@@ -186,13 +302,40 @@ func TestBatchRun_ContextCancelled(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestBatchRun_FetchError_RetriesOnClock(t *testing.T) {
+	reader := &mockReader{fetchErr: errors.New("connection refused")}
+	store := &mockStore{}
+	bc := newTestBatchConsumer(reader, store)
+	bc.batchSize = 1
+	clk := clock.NewFake(time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC))
+	bc.SetClock(clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- bc.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	reader.mu.Lock()
+	reader.fetchErr = nil
+	reader.msgs = []kafkago.Message{kafkaMsg(validMessageBytes(t), 0)}
+	reader.mu.Unlock()
+	clk.Advance(5 * time.Second)
+
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return len(store.batchInserted) == 1
+	}, time.Second, time.Millisecond)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
 func TestBatchRun_ProcessesAndStops(t *testing.T) {
-	data := validMessageBytes(t)
+	items := distinctItems(t, 0, 2)
 	reader := &mockReader{
-		msgs: []kafkago.Message{
-			kafkaMsg(data, 0),
-			kafkaMsg(data, 1),
-		},
+		msgs: []kafkago.Message{items[0].msg, items[1].msg},
 	}
 	store := &mockStore{}
 	bc := newTestBatchConsumer(reader, store)
@@ -210,6 +353,57 @@ func TestBatchRun_ProcessesAndStops(t *testing.T) {
 	assert.Len(t, store.batchInserted, 2)
 }
 
+// ctxStore fails inserts whose context is already done, like the real store.
+type ctxStore struct{ mockStore }
+
+func (c *ctxStore) InsertStormReports(ctx context.Context, reports []*model.StormReport) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.mockStore.InsertStormReports(ctx, reports)
+}
+
+func TestBatchRun_DrainsLastBatchOnCancel(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		drain    time.Duration
+		inserted int
+	}{
+		{"drain", time.Second, 1},
+		{"no drain", 0, 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			items := distinctItems(t, 0, 1)
+			reader := &mockReader{msgs: []kafkago.Message{items[0].msg}}
+			store := &ctxStore{}
+			bc := newTestBatchConsumer(reader, &mockStore{})
+			bc.store = store
+			bc.flushInterval = 10 * time.Second
+			bc.SetDrainTimeout(tt.drain)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() { done <- bc.Run(ctx) }()
+
+			// Cancel while the fetched message is still waiting for its flush.
+			require.Eventually(t, func() bool {
+				reader.mu.Lock()
+				defer reader.mu.Unlock()
+				return reader.idx == 1
+			}, time.Second, time.Millisecond)
+			cancel()
+			require.NoError(t, <-done)
+
+			store.mu.Lock()
+			defer store.mu.Unlock()
+			assert.Len(t, store.batchInserted, tt.inserted)
+			reader.mu.Lock()
+			defer reader.mu.Unlock()
+			assert.Len(t, reader.committed, tt.inserted)
+		})
+	}
+}
+
 // --- Close test ---
 
 func TestBatchClose(t *testing.T) {
@@ -238,3 +432,111 @@ func (m *mockStore) InsertStormReports(_ context.Context, reports []*model.Storm
 	m.batchInserted = append(m.batchInserted, reports...)
 	return nil
 }
+
+// --- exactly-once tests ---
+
+type mockOffsetStore struct {
+	mu       sync.Mutex
+	inserted []*model.StormReport
+	offsets  map[int]int64
+}
+
+func (m *mockOffsetStore) InsertStormReportsWithOffsets(_ context.Context, _ string, reports []*model.StormReport, offsets map[int]int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.inserted = append(m.inserted, reports...)
+	if m.offsets == nil {
+		m.offsets = make(map[int]int64)
+	}
+	for p, o := range offsets {
+		m.offsets[p] = o
+	}
+	return nil
+}
+
+func (m *mockOffsetStore) ProcessedOffsets(_ context.Context, _ string) (map[int]int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make(map[int]int64, len(m.offsets))
+	for p, o := range m.offsets {
+		out[p] = o
+	}
+	return out, nil
+}
+
+func TestProcessBatch_ExactlyOnceSurvivesCrashBeforeCommit(t *testing.T) {
+	items := distinctItems(t, 0, 2)
+	offsets := &mockOffsetStore{}
+
+	// First run inserts, then "crashes": the Kafka commit never lands.
+	first := newTestBatchConsumer(&mockReader{commitErr: errors.New("broker gone")}, &mockStore{})
+	first.EnableExactlyOnce(offsets)
+	first.processBatch(context.Background(), items)
+	require.Len(t, offsets.inserted, 2)
+	assert.Equal(t, int64(1), offsets.offsets[0])
+
+	// After restart Kafka redelivers the same messages.
+	reader := &mockReader{}
+	second := newTestBatchConsumer(reader, &mockStore{})
+	second.EnableExactlyOnce(offsets)
+	second.processBatch(context.Background(), items)
+
+	assert.Len(t, offsets.inserted, 2, "redelivered messages must not be inserted again")
+	assert.Len(t, reader.committed, 2, "redelivered messages are still committed")
+}
+
+func TestProcessBatch_ExactlyOnceInsertsNewOffsets(t *testing.T) {
+	offsets := &mockOffsetStore{offsets: map[int]int64{0: 1}}
+
+	reader := &mockReader{}
+	store := &mockStore{}
+	bc := newTestBatchConsumer(reader, store)
+	bc.EnableExactlyOnce(offsets)
+	bc.processBatch(context.Background(), distinctItems(t, 1, 3))
+
+	assert.Len(t, offsets.inserted, 2)
+	assert.Equal(t, int64(3), offsets.offsets[0])
+	assert.Empty(t, store.batchInserted, "exactly-once writes go through the offset store")
+	assert.Len(t, reader.committed, 3)
+}
+
+func TestProcessBatch_ExactlyOnceDuplicateIDAcrossPartitions(t *testing.T) {
+	// The same report, mis-keyed upstream, arrives on partitions 0 and 1.
+	items := distinctItems(t, 4, 2)
+	dup := items[0]
+	dup.msg.Partition = 1
+	dup.msg.Offset = 9
+	items = append(items, dup)
+	offsets := &mockOffsetStore{}
+
+	reader := &mockReader{}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.EnableExactlyOnce(offsets)
+	bc.processBatch(context.Background(), items)
+
+	assert.Len(t, offsets.inserted, 2, "the duplicate is inserted once")
+	assert.Equal(t, map[int]int64{0: 5, 1: 9}, offsets.offsets, "both partitions' offsets are recorded")
+	assert.Len(t, reader.committed, 3, "every message is committed")
+	assert.Equal(t, 3.0, testutil.ToFloat64(bc.metrics.KafkaMessagesConsumed.WithLabelValues("test-topic", modeBatch)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(bc.metrics.KafkaBatchDuplicates.WithLabelValues("test-topic")))
+
+	// Redelivery of the partition 1 copy is skipped by its stored offset.
+	bc.processBatch(context.Background(), []batchItem{dup})
+	assert.Len(t, offsets.inserted, 2)
+}
+
+func TestProcessBatch_DuplicateIDInsertedOnce(t *testing.T) {
+	items := distinctItems(t, 0, 1)
+	dup := items[0]
+	dup.msg.Partition = 1
+	items = append(items, dup)
+
+	reader := &mockReader{}
+	store := &mockStore{}
+	bc := newTestBatchConsumer(reader, store)
+	bc.processBatch(context.Background(), items)
+
+	assert.Len(t, store.batchInserted, 1)
+	assert.Len(t, reader.committed, 2)
+	assert.Equal(t, 1.0, testutil.ToFloat64(bc.metrics.KafkaBatchDuplicates.WithLabelValues("test-topic")))
+}
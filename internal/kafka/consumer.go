@@ -2,12 +2,16 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"log/slog"
+	"math/rand/v2"
+	"strings"
 	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-api/internal/store"
 	kafkago "github.com/segmentio/kafka-go"
 )
 
@@ -24,6 +28,13 @@ type StoreInserter interface {
 	InsertStormReports(ctx context.Context, reports []*model.StormReport) error
 }
 
+// Consumer modes, reported as the mode label on the Kafka metrics both
+// consumers share so throughput from each path can be compared on one topic.
+const (
+	modeSingle = "single"
+	modeBatch  = "batch"
+)
+
 // Consumer reads storm reports from a Kafka topic and persists them to the store.
 type Consumer struct {
 	reader  MessageReader
@@ -31,32 +42,98 @@ type Consumer struct {
 	topic   string
 	logger  *slog.Logger
 	metrics *observability.Metrics
+	clock   clock.Clock
+
+	insertTimeout time.Duration
+	insertHealth  *InsertHealth
+	fetchReady    *FetchReadiness
+	insertLimit   *InsertLimiter
 }
 
-// NewConsumer creates a consumer that reads from the given topic and inserts into the store.
-func NewConsumer(brokers []string, topic, groupID string, s StoreInserter, m *observability.Metrics, logger *slog.Logger) *Consumer {
-	reader := kafkago.NewReader(kafkago.ReaderConfig{
+// FetchOptions tunes how the reader fetches from the broker. The broker holds
+// a fetch until MinBytes are available or MaxWait passes, so raising both
+// trades latency for fewer, larger fetches. Zero fields use the defaults.
+type FetchOptions struct {
+	MinBytes int
+	MaxBytes int
+	MaxWait  time.Duration
+}
+
+// Default fetch settings, matching the reader's previous hard-coded values
+// and kafka-go's own MaxWait default.
+const (
+	DefaultFetchMinBytes int = 1
+	DefaultFetchMaxBytes int = 10e6 // 10 MB
+	DefaultFetchMaxWait      = 10 * time.Second
+)
+
+func newReader(brokers []string, topic, groupID string, fetch FetchOptions) *kafkago.Reader {
+	cfg := kafkago.ReaderConfig{
 		Brokers:     brokers,
 		Topic:       topic,
 		GroupID:     groupID,
 		StartOffset: kafkago.FirstOffset,
-		MinBytes:    1,
-		MaxBytes:    10e6, // 10 MB
-	})
+		MinBytes:    fetch.MinBytes,
+		MaxBytes:    fetch.MaxBytes,
+		MaxWait:     fetch.MaxWait,
+	}
+	if cfg.MinBytes == 0 {
+		cfg.MinBytes = DefaultFetchMinBytes
+	}
+	if cfg.MaxBytes == 0 {
+		cfg.MaxBytes = DefaultFetchMaxBytes
+	}
+	if cfg.MaxWait == 0 {
+		cfg.MaxWait = DefaultFetchMaxWait
+	}
+	return kafkago.NewReader(cfg)
+}
+
+// NewConsumer creates a consumer that reads from the given topic and inserts into the store.
+func NewConsumer(brokers []string, topic, groupID string, fetch FetchOptions, s StoreInserter, m *observability.Metrics, logger *slog.Logger) *Consumer {
 	return &Consumer{
-		reader:  reader,
+		reader:  newReader(brokers, topic, groupID, fetch),
 		store:   s,
 		topic:   topic,
 		logger:  logger,
 		metrics: m,
+		clock:   clock.Real{},
 	}
 }
 
+// SetInsertTimeout bounds each InsertStormReport call. Zero means inserts are
+// limited only by the Run context.
+func (c *Consumer) SetInsertTimeout(d time.Duration) {
+	c.insertTimeout = d
+}
+
+// SetInsertHealth reports every insert outcome to h, which readiness can
+// consult to flag a persistently failing write path.
+func (c *Consumer) SetInsertHealth(h *InsertHealth) {
+	c.insertHealth = h
+}
+
+// SetFetchReadiness marks f verified on the first successful fetch.
+func (c *Consumer) SetFetchReadiness(f *FetchReadiness) {
+	c.fetchReady = f
+}
+
+// SetInsertLimiter makes inserts wait for a slot in l, which may be shared
+// with other consumers so parallel readers don't exhaust the pool.
+func (c *Consumer) SetInsertLimiter(l *InsertLimiter) {
+	c.insertLimit = l
+}
+
+// SetClock replaces the clock that times fetch retry backoff.
+func (c *Consumer) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
 // Run consumes messages until the context is cancelled.
 func (c *Consumer) Run(ctx context.Context) error {
 	c.logger.Info("kafka consumer started", "topic", c.topic)
-	c.metrics.KafkaConsumerRunning.WithLabelValues(c.topic).Set(1)
-	defer c.metrics.KafkaConsumerRunning.WithLabelValues(c.topic).Set(0)
+	c.metrics.KafkaConsumerRunning.WithLabelValues(c.topic, modeSingle).Set(1)
+	defer c.metrics.KafkaConsumerRunning.WithLabelValues(c.topic, modeSingle).Set(0)
 
 	backoff := 200 * time.Millisecond
 	maxBackoff := 5 * time.Second
@@ -67,17 +144,19 @@ func (c *Consumer) Run(ctx context.Context) error {
 			if ctx.Err() != nil {
 				return nil
 			}
-			c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, "fetch").Inc()
-			c.logger.Error("fetch kafka message", "error", err, "retry_in", backoff)
+			c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, modeSingle, "fetch").Inc()
+			delay := fullJitter(backoff)
+			c.logger.Error("fetch kafka message", "error", err, "retry_in", delay)
 			select {
 			case <-ctx.Done():
 				return nil
-			case <-time.After(backoff):
+			case <-c.clock.After(delay):
 			}
 			backoff = min(backoff*2, maxBackoff)
 			continue
 		}
 		backoff = 200 * time.Millisecond
+		c.fetchReady.markFetched()
 
 		if c.handleMessage(ctx, msg) {
 			return nil
@@ -88,13 +167,15 @@ func (c *Consumer) Run(ctx context.Context) error {
 // handleMessage processes a single Kafka message: unmarshal, insert, commit.
 // Returns true if the consumer should stop (context cancelled).
 func (c *Consumer) handleMessage(ctx context.Context, msg kafkago.Message) bool {
-	var report model.StormReport
-	if err := json.Unmarshal(msg.Value, &report); err != nil {
-		c.logger.Error("unmarshal kafka message", "error", err, "offset", msg.Offset)
-		c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, "unmarshal").Inc()
+	traceID := headerValue(msg, HeaderTraceID)
+	report, err := decodeMessage(msg)
+	if err != nil {
+		c.logger.Error("unmarshal kafka message", "error", err, "offset", msg.Offset, "trace_id", traceID)
+		c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, modeSingle, "unmarshal").Inc()
 		// Commit bad messages to avoid reprocessing poison pills
 		if err := c.reader.CommitMessages(ctx, msg); err != nil {
 			c.logger.Error("commit offset after unmarshal error", "error", err)
+			c.metrics.KafkaCommitErrors.WithLabelValues(c.topic, modeSingle).Inc()
 		}
 		return false
 	}
@@ -103,21 +184,97 @@ func (c *Consumer) handleMessage(ctx context.Context, msg kafkago.Message) bool
 		return true
 	}
 
-	if err := c.store.InsertStormReport(ctx, &report); err != nil {
-		c.logger.Error("insert storm report", "error", err, "id", report.ID)
-		c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, "insert").Inc()
+	err = c.insertLimit.do(ctx, func() error {
+		insertCtx, cancel := withInsertTimeout(ctx, c.insertTimeout)
+		defer cancel()
+		return c.store.InsertStormReport(insertCtx, report)
+	})
+	c.insertHealth.record(err)
+	if err != nil {
+		c.logger.Error("insert storm report", "error", err, "id", report.ID, "trace_id", traceID)
+		c.metrics.KafkaConsumerErrors.WithLabelValues(c.topic, modeSingle, insertErrorType("insert", err)).Inc()
 		return ctx.Err() != nil
 	}
+	observePipelineLatency(c.metrics, report)
 
 	if err := c.reader.CommitMessages(ctx, msg); err != nil {
-		c.logger.Error("commit offset", "error", err, "id", report.ID)
+		c.logger.Error("commit offset", "error", err, "id", report.ID, "trace_id", traceID)
+		c.metrics.KafkaCommitErrors.WithLabelValues(c.topic, modeSingle).Inc()
 	}
 
-	c.metrics.KafkaMessagesConsumed.WithLabelValues(c.topic).Inc()
-	c.logger.Debug("consumed storm report", "id", report.ID, "type", report.EventType)
+	c.metrics.KafkaMessagesConsumed.WithLabelValues(c.topic, modeSingle).Inc()
+	c.logger.Debug("consumed storm report", "id", report.ID, "type", report.EventType, "trace_id", traceID)
 	return false
 }
 
+// observePipelineLatency records processed_at - event_time for each persisted
+// report. Unknown event types share one label so a bad upstream value can't
+// grow the series count.
+func observePipelineLatency(m *observability.Metrics, reports ...*model.StormReport) {
+	for _, r := range reports {
+		if r.EventTime.IsZero() || r.ProcessedAt.IsZero() {
+			continue
+		}
+		eventType := "other"
+		if model.EventType(strings.ToUpper(r.EventType)).IsValid() {
+			eventType = strings.ToLower(r.EventType)
+		}
+		latency := max(r.ProcessedAt.Sub(r.EventTime), 0)
+		m.PipelineLatency.WithLabelValues(eventType).Observe(latency.Seconds())
+	}
+}
+
+// fullJitter returns a random delay in [0, backoff]. Instances that lost Kafka
+// at the same moment would otherwise retry in lockstep; spreading each sleep
+// over the whole backoff window keeps their reconnects from arriving together.
+func fullJitter(backoff time.Duration) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	return rand.N(backoff + 1)
+}
+
+// withInsertTimeout derives the context for a store write. The ingest path
+// gets its own budget so a slow database surfaces as insert errors instead of
+// stalling the consumer indefinitely.
+func withInsertTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// drainContext returns a context that outlives ctx by d, so a batch already
+// fetched when the consumer is stopped can still be written and committed.
+// d <= 0 returns a context cancelled together with ctx.
+func drainContext(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	if d <= 0 {
+		return context.WithCancel(ctx)
+	}
+	drain, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		time.AfterFunc(d, cancel)
+	})
+	return drain, func() {
+		stop()
+		cancel()
+	}
+}
+
+// insertErrorType returns the error_type metric label for a failed insert,
+// adding a "_timeout" suffix when the insert ran past its deadline or hit
+// statement_timeout, so ingestion-path slowness can be alerted on separately,
+// and "_lock_conflict" when it still conflicted after the store's retries.
+func insertErrorType(base string, err error) string {
+	if errors.Is(err, store.ErrTimeout) || errors.Is(err, context.DeadlineExceeded) {
+		return base + "_timeout"
+	}
+	if errors.Is(err, store.ErrLockConflict) {
+		return base + "_lock_conflict"
+	}
+	return base
+}
+
 // Close shuts down the underlying Kafka reader.
 func (c *Consumer) Close() error {
 	return c.reader.Close()
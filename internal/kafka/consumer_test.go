@@ -4,13 +4,18 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/clock"
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-api/internal/store"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	kafkago "github.com/segmentio/kafka-go"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -85,6 +90,7 @@ func newTestConsumer(reader *mockReader, store *mockStore) *Consumer {
 		topic:   "test-topic",
 		logger:  slog.Default(),
 		metrics: observability.NewTestMetrics(),
+		clock:   clock.Real{},
 	}
 }
 
@@ -144,6 +150,8 @@ func TestHandleMessage_HappyPath(t *testing.T) {
 	// Message was committed.
 	require.Len(t, reader.committed, 1)
 	assert.Equal(t, int64(42), reader.committed[0].Offset)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(c.metrics.KafkaMessagesConsumed.WithLabelValues("test-topic", modeSingle)), 0)
 }
 
 func TestHandleMessage_UnmarshalError(t *testing.T) {
@@ -194,6 +202,7 @@ func TestHandleMessage_CommitError(t *testing.T) {
 	// Insert was called successfully.
 	require.Len(t, store.inserted, 1)
 	assert.Equal(t, "abc123", store.inserted[0].ID)
+	assert.InDelta(t, 1, testutil.ToFloat64(c.metrics.KafkaCommitErrors.WithLabelValues("test-topic", modeSingle)), 0)
 }
 
 func TestHandleMessage_ContextCancelled(t *testing.T) {
@@ -229,6 +238,37 @@ func TestRun_FetchError_Backoff(t *testing.T) {
 	assert.Empty(t, store.inserted, "no messages should be inserted when fetch fails")
 }
 
+func TestRun_FetchError_RetriesOnClock(t *testing.T) {
+	reader := &mockReader{fetchErr: errors.New("connection refused")}
+	store := &mockStore{}
+	c := newTestConsumer(reader, store)
+	clk := clock.NewFake(time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC))
+	c.SetClock(clk)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- c.Run(ctx) }()
+
+	// The consumer sleeps on the fake clock, so nothing is retried until it moves.
+	require.Eventually(t, func() bool { return clk.Waiters() == 1 }, time.Second, time.Millisecond)
+	reader.mu.Lock()
+	reader.fetchErr = nil
+	reader.msgs = []kafkago.Message{kafkaMsg(validMessageBytes(t), 0)}
+	reader.mu.Unlock()
+	clk.Advance(5 * time.Second) // the backoff cap, beyond any jittered delay
+
+	require.Eventually(t, func() bool {
+		store.mu.Lock()
+		defer store.mu.Unlock()
+		return len(store.inserted) == 1
+	}, time.Second, time.Millisecond)
+	assert.GreaterOrEqual(t, testutil.ToFloat64(c.metrics.KafkaConsumerErrors.WithLabelValues("test-topic", modeSingle, "fetch")), 1.0)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
 func TestRun_ContextCancelled(t *testing.T) {
 	reader := &mockReader{} // No messages, FetchMessage will block on ctx.Done().
 	store := &mockStore{}
@@ -277,3 +317,105 @@ func TestClose(t *testing.T) {
 	require.NoError(t, err)
 	assert.True(t, reader.closeCalled, "Close should delegate to the reader")
 }
+
+func TestObservePipelineLatency(t *testing.T) {
+	m := observability.NewTestMetrics()
+	eventTime := time.Date(2025, 6, 15, 18, 30, 0, 0, time.UTC)
+	observePipelineLatency(m,
+		&model.StormReport{EventType: "hail", EventTime: eventTime, ProcessedAt: eventTime.Add(2 * time.Hour)},
+		&model.StormReport{EventType: "wind", EventTime: eventTime}, // no processed_at: skipped
+	)
+
+	want := `
+# HELP storm_api_pipeline_latency_seconds Time from a report's event_time to its processed_at, observed when it is persisted.
+# TYPE storm_api_pipeline_latency_seconds histogram
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="60"} 0
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="300"} 0
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="900"} 0
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="1800"} 0
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="3600"} 0
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="7200"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="21600"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="43200"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="86400"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="172800"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="604800"} 1
+storm_api_pipeline_latency_seconds_bucket{event_type="hail",le="+Inf"} 1
+storm_api_pipeline_latency_seconds_sum{event_type="hail"} 7200
+storm_api_pipeline_latency_seconds_count{event_type="hail"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(m.PipelineLatency, strings.NewReader(want)))
+}
+
+func TestObservePipelineLatency_EventTypeLabels(t *testing.T) {
+	m := observability.NewTestMetrics()
+	eventTime := time.Date(2025, 6, 15, 18, 30, 0, 0, time.UTC)
+	for _, eventType := range []string{"hail", "TORNADO", "dust_devil", "funnel"} {
+		observePipelineLatency(m, &model.StormReport{EventType: eventType, EventTime: eventTime, ProcessedAt: eventTime.Add(time.Hour)})
+	}
+	// hail, tornado, and one shared "other" series.
+	assert.Equal(t, 3, testutil.CollectAndCount(m.PipelineLatency))
+}
+
+func TestHandleMessage_ObservesPipelineLatency(t *testing.T) {
+	c := newTestConsumer(&mockReader{}, &mockStore{})
+	c.handleMessage(context.Background(), kafkaMsg(validMessageBytes(t), 1))
+	assert.Equal(t, 1, testutil.CollectAndCount(c.metrics.PipelineLatency))
+}
+
+func TestNewReader_FetchOptions(t *testing.T) {
+	r := newReader([]string{"localhost:9092"}, "t", "g", FetchOptions{})
+	defer r.Close()
+	cfg := r.Config()
+	assert.Equal(t, DefaultFetchMinBytes, cfg.MinBytes)
+	assert.Equal(t, DefaultFetchMaxBytes, cfg.MaxBytes)
+	assert.Equal(t, DefaultFetchMaxWait, cfg.MaxWait)
+
+	r2 := newReader([]string{"localhost:9092"}, "t", "g", FetchOptions{MinBytes: 64 << 10, MaxBytes: 1 << 20, MaxWait: 500 * time.Millisecond})
+	defer r2.Close()
+	cfg = r2.Config()
+	assert.Equal(t, 64<<10, cfg.MinBytes)
+	assert.Equal(t, 1<<20, cfg.MaxBytes)
+	assert.Equal(t, 500*time.Millisecond, cfg.MaxWait)
+}
+
+func TestFullJitter(t *testing.T) {
+	assert.Equal(t, time.Duration(0), fullJitter(0))
+
+	backoff := 200 * time.Millisecond
+	seen := make(map[time.Duration]bool)
+	for range 100 {
+		d := fullJitter(backoff)
+		assert.GreaterOrEqual(t, d, time.Duration(0))
+		assert.LessOrEqual(t, d, backoff)
+		seen[d] = true
+	}
+	assert.Greater(t, len(seen), 1, "delays should vary between calls")
+}
+
+func TestInsertErrorType(t *testing.T) {
+	assert.Equal(t, "insert", insertErrorType("insert", errors.New("connection refused")))
+	assert.Equal(t, "insert_timeout", insertErrorType("insert", context.DeadlineExceeded))
+	assert.Equal(t, "insert_timeout", insertErrorType("insert", fmt.Errorf("insert storm report: %w: canceling statement", store.ErrTimeout)))
+	assert.Equal(t, "batch_insert_lock_conflict", insertErrorType("batch_insert", fmt.Errorf("batch insert: %w: deadlock detected", store.ErrLockConflict)))
+}
+
+func TestDrainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	drain, stop := drainContext(ctx, 50*time.Millisecond)
+	defer stop()
+
+	cancel()
+	require.NoError(t, drain.Err(), "drain outlives its parent")
+	select {
+	case <-drain.Done():
+	case <-time.After(time.Second):
+		t.Fatal("drain context not cancelled after the drain timeout")
+	}
+
+	ctx, cancel = context.WithCancel(context.Background())
+	noDrain, stop := drainContext(ctx, 0)
+	defer stop()
+	cancel()
+	require.Error(t, noDrain.Err())
+}
@@ -0,0 +1,138 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// FetchReadiness reports not ready until the consumer's read side has been
+// verified, either by a successful fetch or by confirming the topic exists on
+// the brokers. The topic check covers quiet topics, where the first fetch may
+// not return for a long time. It implements observability.ReadinessChecker and
+// stays ready once verified, unless SetGracePeriod is used.
+type FetchReadiness struct {
+	topic       string
+	verified    atomic.Bool
+	topicExists func(ctx context.Context) error
+	now         func() time.Time
+
+	grace       time.Duration
+	mu          sync.Mutex
+	confirmedAt time.Time
+}
+
+// NewFetchReadiness returns a checker for topic on the given brokers.
+func NewFetchReadiness(brokers []string, topic string) *FetchReadiness {
+	return &FetchReadiness{
+		topic: topic,
+		topicExists: func(ctx context.Context) error {
+			return checkTopic(ctx, brokers, topic)
+		},
+		now: time.Now,
+	}
+}
+
+// SetGracePeriod makes readiness fail once Kafka has gone d without a
+// successful fetch or topic check, rather than staying ready after the first.
+// kafka-go retries unreachable brokers inside FetchMessage, so without this an
+// instance that loses Kafka keeps reporting ready indefinitely. Unless
+// holdUntilVerified is set, the window also starts now, giving the consumer d
+// to reach Kafka at startup before readiness fails.
+func (f *FetchReadiness) SetGracePeriod(d time.Duration, holdUntilVerified bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.grace = d
+	if !holdUntilVerified {
+		f.confirmedAt = f.now()
+	}
+}
+
+// markFetched records a successful fetch. A nil *FetchReadiness ignores it.
+func (f *FetchReadiness) markFetched() {
+	if f != nil {
+		f.confirm()
+	}
+}
+
+// confirm records that Kafka was reached.
+func (f *FetchReadiness) confirm() {
+	f.verified.Store(true)
+	f.mu.Lock()
+	f.confirmedAt = f.now()
+	f.mu.Unlock()
+}
+
+// CheckReadiness returns nil once a fetch has succeeded. Until then it asks
+// the brokers for the topic's partitions within ctx's deadline. With a grace
+// period, a confirmation older than the grace period is checked again.
+func (f *FetchReadiness) CheckReadiness(ctx context.Context) error {
+	f.mu.Lock()
+	grace, confirmedAt := f.grace, f.confirmedAt
+	f.mu.Unlock()
+	if grace > 0 {
+		return f.checkWithin(ctx, grace, confirmedAt)
+	}
+
+	if f.verified.Load() {
+		return nil
+	}
+	if err := f.topicExists(ctx); err != nil {
+		return fmt.Errorf("no successful fetch from %s yet: %w", f.topic, err)
+	}
+	f.confirm()
+	return nil
+}
+
+// checkWithin reports ready while Kafka was reached within grace of now,
+// asking the brokers again once that confirmation goes stale.
+func (f *FetchReadiness) checkWithin(ctx context.Context, grace time.Duration, confirmedAt time.Time) error {
+	since := f.now().Sub(confirmedAt)
+	if !confirmedAt.IsZero() && since < grace {
+		return nil
+	}
+	if err := f.topicExists(ctx); err != nil {
+		if confirmedAt.IsZero() {
+			return fmt.Errorf("no successful fetch from %s yet: %w", f.topic, err)
+		}
+		return fmt.Errorf("kafka not reached for %s (topic %s): %w", since.Round(time.Second), f.topic, err)
+	}
+	f.confirm()
+	return nil
+}
+
+// checkTopic asks each broker in turn for topic's partitions, succeeding on
+// the first broker that reports at least one.
+func checkTopic(ctx context.Context, brokers []string, topic string) error {
+	var dialer kafkago.Dialer
+	errs := make([]error, 0, len(brokers))
+	for _, broker := range brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		partitions, err := conn.ReadPartitions(topic)
+		_ = conn.Close()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(partitions) > 0 {
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("%s: topic has no partitions", broker))
+	}
+	if len(errs) == 0 {
+		return errors.New("no brokers configured")
+	}
+	return errors.Join(errs...)
+}
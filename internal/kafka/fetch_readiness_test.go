@@ -0,0 +1,106 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchReadiness(t *testing.T) {
+	f := NewFetchReadiness(nil, "storms")
+	f.topicExists = func(context.Context) error { return errors.New("unknown topic") }
+
+	err := f.CheckReadiness(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no successful fetch from storms yet: unknown topic")
+
+	f.markFetched()
+	require.NoError(t, f.CheckReadiness(context.Background()))
+}
+
+func TestFetchReadiness_TopicConfirmed(t *testing.T) {
+	checks := 0
+	f := NewFetchReadiness(nil, "storms")
+	f.topicExists = func(context.Context) error { checks++; return nil }
+
+	require.NoError(t, f.CheckReadiness(context.Background()))
+	require.NoError(t, f.CheckReadiness(context.Background()))
+	assert.Equal(t, 1, checks, "stays ready without asking the brokers again")
+}
+
+func TestFetchReadiness_GracePeriod(t *testing.T) {
+	now := time.Date(2024, 4, 26, 12, 0, 0, 0, time.UTC)
+	reachable := false
+	f := NewFetchReadiness(nil, "storms")
+	f.now = func() time.Time { return now }
+	f.topicExists = func(context.Context) error {
+		if reachable {
+			return nil
+		}
+		return errors.New("dial tcp: connection refused")
+	}
+	f.SetGracePeriod(time.Minute, false)
+
+	// Ready during the startup window even though Kafka is unreachable.
+	require.NoError(t, f.CheckReadiness(context.Background()))
+
+	now = now.Add(time.Minute)
+	err := f.CheckReadiness(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "kafka not reached for 1m0s (topic storms): dial tcp: connection refused")
+
+	// A successful fetch restarts the window; losing Kafka later fails again.
+	f.markFetched()
+	require.NoError(t, f.CheckReadiness(context.Background()))
+	now = now.Add(2 * time.Minute)
+	require.Error(t, f.CheckReadiness(context.Background()))
+
+	// A quiet topic is kept ready by the broker check.
+	reachable = true
+	require.NoError(t, f.CheckReadiness(context.Background()))
+}
+
+func TestFetchReadiness_GracePeriodHoldUntilVerified(t *testing.T) {
+	f := NewFetchReadiness(nil, "storms")
+	f.topicExists = func(context.Context) error { return errors.New("unknown topic") }
+	f.SetGracePeriod(time.Minute, true)
+
+	err := f.CheckReadiness(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no successful fetch from storms yet")
+
+	f.markFetched()
+	require.NoError(t, f.CheckReadiness(context.Background()))
+}
+
+func TestFetchReadiness_NoBrokers(t *testing.T) {
+	require.Error(t, NewFetchReadiness(nil, "storms").CheckReadiness(context.Background()))
+}
+
+func TestFetchReadiness_Nil(t *testing.T) {
+	var f *FetchReadiness
+	f.markFetched()
+}
+
+func TestFetchBatch_MarksFetchReadiness(t *testing.T) {
+	bc := newTestBatchConsumer(&mockReader{}, &mockStore{})
+	bc.flushInterval = 10 * time.Millisecond
+	f := NewFetchReadiness(nil, "test-topic")
+	f.topicExists = func(context.Context) error { return errors.New("unreachable") }
+	bc.SetFetchReadiness(f)
+
+	// An empty poll proves nothing about the topic.
+	_, err := bc.fetchBatch(context.Background())
+	require.NoError(t, err)
+	require.Error(t, f.CheckReadiness(context.Background()))
+
+	bc.reader = &mockReader{msgs: []kafkago.Message{kafkaMsg(validMessageBytes(t), 0)}}
+	_, err = bc.fetchBatch(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, f.CheckReadiness(context.Background()))
+}
@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Message header keys set by upstream producers and by Replay.
+const (
+	// HeaderSchemaVersion selects the decoder for the message value.
+	HeaderSchemaVersion = "schema-version"
+	// HeaderTraceID correlates a message with upstream pipeline logs.
+	HeaderTraceID = "trace-id"
+	// HeaderIngestedAt is when the report was first ingested, in RFC 3339.
+	HeaderIngestedAt = "ingested-at"
+	// HeaderPipeline names the ETL pipeline that produced the message.
+	HeaderPipeline = "pipeline"
+)
+
+// defaultSchemaVersion is assumed for messages without a schema-version
+// header, which covers everything produced before the header was introduced.
+const defaultSchemaVersion = "1"
+
+// decoders maps schema versions to the decoder for that wire format.
+var decoders = map[string]func([]byte) (*model.StormReport, error){
+	"1": decodeJSONv1,
+}
+
+// decodeJSONv1 decodes the original JSON wire format, which mirrors model.StormReport.
+func decodeJSONv1(value []byte) (*model.StormReport, error) {
+	var report model.StormReport
+	if err := json.Unmarshal(value, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// decodeMessage decodes a message value using the decoder selected by its
+// schema-version header. Unknown versions are returned as errors so they are
+// treated like any other undecodable message. A pipeline header overrides the
+// pipeline field in the value.
+func decodeMessage(msg kafkago.Message) (*model.StormReport, error) {
+	version := headerValue(msg, HeaderSchemaVersion)
+	if version == "" {
+		version = defaultSchemaVersion
+	}
+	decode, ok := decoders[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema version %q", version)
+	}
+	report, err := decode(msg.Value)
+	if err != nil {
+		return nil, err
+	}
+	if pipeline := headerValue(msg, HeaderPipeline); pipeline != "" {
+		report.Pipeline = pipeline
+	}
+	return report, nil
+}
+
+// headerValue returns the value of the first header with the given key, or "".
+func headerValue(msg kafkago.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
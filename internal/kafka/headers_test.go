@@ -0,0 +1,90 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withHeaders(msg kafkago.Message, kv ...string) kafkago.Message {
+	for i := 0; i+1 < len(kv); i += 2 {
+		msg.Headers = append(msg.Headers, kafkago.Header{Key: kv[i], Value: []byte(kv[i+1])})
+	}
+	return msg
+}
+
+func TestDecodeMessage_NoHeaderUsesDefaultVersion(t *testing.T) {
+	report, err := decodeMessage(kafkaMsg(validMessageBytes(t), 0))
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", report.ID)
+}
+
+func TestDecodeMessage_ExplicitVersion(t *testing.T) {
+	msg := withHeaders(kafkaMsg(validMessageBytes(t), 0), HeaderSchemaVersion, "1")
+	report, err := decodeMessage(msg)
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", report.ID)
+}
+
+func TestDecodeMessage_UnsupportedVersion(t *testing.T) {
+	msg := withHeaders(kafkaMsg(validMessageBytes(t), 0), HeaderSchemaVersion, "99")
+	_, err := decodeMessage(msg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported schema version "99"`)
+}
+
+func TestDecodeMessage_Pipeline(t *testing.T) {
+	report, err := decodeMessage(kafkaMsg(validMessageBytes(t), 0))
+	require.NoError(t, err)
+	assert.Empty(t, report.Pipeline, "untagged producers leave pipeline empty")
+
+	tagged := validReport()
+	tagged.Pipeline = "etl-v1"
+	data, err := json.Marshal(tagged)
+	require.NoError(t, err)
+	report, err = decodeMessage(kafkaMsg(data, 0))
+	require.NoError(t, err)
+	assert.Equal(t, "etl-v1", report.Pipeline, "field in the value")
+
+	report, err = decodeMessage(withHeaders(kafkaMsg(data, 0), HeaderPipeline, "etl-v2"))
+	require.NoError(t, err)
+	assert.Equal(t, "etl-v2", report.Pipeline, "header overrides the field")
+}
+
+func TestHeaderValue(t *testing.T) {
+	msg := withHeaders(kafkaMsg(nil, 0), HeaderTraceID, "trace-abc", HeaderTraceID, "ignored")
+	assert.Equal(t, "trace-abc", headerValue(msg, HeaderTraceID))
+	assert.Empty(t, headerValue(msg, HeaderSchemaVersion))
+}
+
+func TestHandleMessage_UnsupportedVersionCommittedAsPoisonPill(t *testing.T) {
+	store := &mockStore{}
+	reader := &mockReader{}
+	c := newTestConsumer(reader, store)
+
+	msg := withHeaders(kafkaMsg(validMessageBytes(t), 3), HeaderSchemaVersion, "2", HeaderTraceID, "trace-xyz")
+	stop := c.handleMessage(context.Background(), msg)
+
+	assert.False(t, stop)
+	assert.Empty(t, store.inserted, "undecodable message must not be inserted")
+	require.Len(t, reader.committed, 1)
+}
+
+func TestFetchBatch_CarriesTraceID(t *testing.T) {
+	reader := &mockReader{
+		msgs: []kafkago.Message{
+			withHeaders(kafkaMsg(validMessageBytes(t), 0), HeaderTraceID, "trace-1"),
+		},
+	}
+	bc := newTestBatchConsumer(reader, &mockStore{})
+	bc.batchSize = 1
+
+	items, err := bc.fetchBatch(context.Background())
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, "trace-1", items[0].traceID)
+}
@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// InsertHealth tracks consecutive insert failures on the ingest path and
+// implements observability.ReadinessChecker. Once inserts have failed for
+// longer than window, with at least minFailures failures and no success in
+// between, readiness reports the instance degraded so orchestration can route
+// traffic away from it. Slow inserts count once they run past the insert
+// timeout. A single successful insert clears the state.
+type InsertHealth struct {
+	window      time.Duration
+	minFailures int
+	now         func() time.Time
+
+	mu           sync.Mutex
+	failures     int
+	firstFailure time.Time
+	lastErr      error
+}
+
+// NewInsertHealth returns a tracker that marks the service degraded after
+// inserts have failed continuously for window and at least minFailures times.
+func NewInsertHealth(window time.Duration, minFailures int) *InsertHealth {
+	return &InsertHealth{window: window, minFailures: minFailures, now: time.Now}
+}
+
+// record notes the outcome of an insert. A nil *InsertHealth ignores it, as
+// do cancellations from shutdown.
+func (h *InsertHealth) record(err error) {
+	if h == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err == nil {
+		h.failures = 0
+		h.lastErr = nil
+		return
+	}
+	if h.failures == 0 {
+		h.firstFailure = h.now()
+	}
+	h.failures++
+	h.lastErr = err
+}
+
+// CheckReadiness returns an error while inserts are persistently failing.
+func (h *InsertHealth) CheckReadiness(context.Context) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.failures < h.minFailures {
+		return nil
+	}
+	if failingFor := h.now().Sub(h.firstFailure); failingFor >= h.window {
+		return fmt.Errorf("inserts failing for %s (%d consecutive failures): %w",
+			failingFor.Round(time.Second), h.failures, h.lastErr)
+	}
+	return nil
+}
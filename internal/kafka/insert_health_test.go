@@ -0,0 +1,68 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertHealth(t *testing.T) {
+	now := time.Now()
+	h := NewInsertHealth(time.Minute, 3)
+	h.now = func() time.Time { return now }
+	dbErr := errors.New("db connection lost")
+	ctx := context.Background()
+
+	h.record(dbErr)
+	h.record(dbErr)
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, h.CheckReadiness(ctx), "too few failures, however long")
+
+	h.record(dbErr)
+	err := h.CheckReadiness(ctx)
+	require.ErrorIs(t, err, dbErr)
+	assert.Contains(t, err.Error(), "3 consecutive failures")
+
+	h.record(context.Canceled)
+	require.Error(t, h.CheckReadiness(ctx), "shutdown cancellations don't clear the state")
+
+	h.record(nil)
+	require.NoError(t, h.CheckReadiness(ctx), "a success recovers immediately")
+
+	for range 5 {
+		h.record(dbErr)
+	}
+	require.NoError(t, h.CheckReadiness(ctx), "failing for less than the window")
+}
+
+func TestInsertHealth_Nil(t *testing.T) {
+	var h *InsertHealth
+	h.record(errors.New("ignored"))
+}
+
+func TestProcessBatch_RecordsInsertHealth(t *testing.T) {
+	data := validMessageBytes(t)
+	var report model.StormReport
+	require.NoError(t, json.Unmarshal(data, &report))
+
+	store := &mockStore{batchInsertErr: errors.New("db connection lost")}
+	bc := newTestBatchConsumer(&mockReader{}, store)
+	h := NewInsertHealth(0, 2)
+	bc.SetInsertHealth(h)
+
+	items := []batchItem{{msg: kafkaMsg(data, 0), report: &report}}
+	bc.processBatch(context.Background(), items)
+	require.NoError(t, h.CheckReadiness(context.Background()))
+	bc.processBatch(context.Background(), items)
+	require.Error(t, h.CheckReadiness(context.Background()))
+
+	store.batchInsertErr = nil
+	bc.processBatch(context.Background(), items)
+	require.NoError(t, h.CheckReadiness(context.Background()))
+}
@@ -0,0 +1,39 @@
+package kafka
+
+import "context"
+
+// InsertLimiter bounds how many inserts run at once across every consumer
+// sharing it. It is the ingest-side counterpart of graph.ConcurrencyLimit:
+// readers consuming partitions in parallel wait for a slot instead of
+// claiming more pool connections than ingest was budgeted.
+type InsertLimiter struct {
+	sem chan struct{}
+}
+
+// NewInsertLimiter returns a limiter allowing n concurrent inserts. n below 1
+// is treated as 1.
+func NewInsertLimiter(n int) *InsertLimiter {
+	return &InsertLimiter{sem: make(chan struct{}, max(n, 1))}
+}
+
+// IngestConcurrencyFor derives the insert limit from the database pool size,
+// keeping a quarter of the connections for ingest (at least one) so the
+// query path keeps the rest. A 4-connection pool gets 1.
+func IngestConcurrencyFor(poolMaxConns int) int {
+	return max(poolMaxConns/4, 1)
+}
+
+// do runs fn once a slot is free. If ctx ends first, fn is not run and the
+// context's error is returned. A nil *InsertLimiter runs fn immediately.
+func (l *InsertLimiter) do(ctx context.Context, fn func() error) error {
+	if l == nil {
+		return fn()
+	}
+	select {
+	case l.sem <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-l.sem }()
+	return fn()
+}
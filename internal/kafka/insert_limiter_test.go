@@ -0,0 +1,102 @@
+package kafka
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertLimiter_BoundsConcurrency(t *testing.T) {
+	l := NewInsertLimiter(2)
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := l.do(context.Background(), func() error {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				running.Add(-1)
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+func TestInsertLimiter_ContextCancelledWhileWaiting(t *testing.T) {
+	l := NewInsertLimiter(1)
+	hold := make(chan struct{})
+	go func() {
+		_ = l.do(context.Background(), func() error {
+			<-hold
+			return nil
+		})
+	}()
+	defer close(hold)
+	require.Eventually(t, func() bool { return len(l.sem) == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	called := false
+	err := l.do(ctx, func() error {
+		called = true
+		return nil
+	})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called)
+}
+
+func TestInsertLimiter_NilRunsImmediately(t *testing.T) {
+	var l *InsertLimiter
+	called := false
+	require.NoError(t, l.do(context.Background(), func() error {
+		called = true
+		return nil
+	}))
+	assert.True(t, called)
+}
+
+func TestIngestConcurrencyFor(t *testing.T) {
+	assert.Equal(t, 1, IngestConcurrencyFor(4))
+	assert.Equal(t, 1, IngestConcurrencyFor(1))
+	assert.Equal(t, 4, IngestConcurrencyFor(16))
+}
+
+func TestHandleMessage_WaitsForInsertSlot(t *testing.T) {
+	store := &mockStore{}
+	c := newTestConsumer(&mockReader{}, store)
+	l := NewInsertLimiter(1)
+	c.SetInsertLimiter(l)
+
+	l.sem <- struct{}{} // another reader holds the only slot
+	msg := kafkaMsg(validMessageBytes(t), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan bool)
+	go func() { done <- c.handleMessage(ctx, msg) }()
+
+	select {
+	case <-done:
+		t.Fatal("insert ran without a free slot")
+	case <-time.After(20 * time.Millisecond):
+	}
+	cancel()
+	assert.True(t, <-done, "cancellation while waiting stops the consumer")
+	assert.Empty(t, store.inserted)
+}
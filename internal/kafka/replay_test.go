@@ -0,0 +1,126 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStreamer struct {
+	reports  []*model.StormReport
+	from, to time.Time
+}
+
+func (m *mockStreamer) StreamStormReports(_ context.Context, from, to time.Time, fn func(*model.StormReport) error) error {
+	m.from, m.to = from, to
+	for _, r := range m.reports {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type mockWriter struct {
+	mu       sync.Mutex
+	written  []kafkago.Message
+	writeErr error
+}
+
+func (m *mockWriter) WriteMessages(_ context.Context, msgs ...kafkago.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.writeErr != nil {
+		return m.writeErr
+	}
+	m.written = append(m.written, msgs...)
+	return nil
+}
+
+func (m *mockWriter) Close() error { return nil }
+
+func replayReports(n int) []*model.StormReport {
+	reports := make([]*model.StormReport, n)
+	for i := range reports {
+		r := validReport()
+		r.ID = string(rune('a' + i))
+		reports[i] = &r
+	}
+	return reports
+}
+
+func TestReplay_RoundTripsThroughConsumerDecoder(t *testing.T) {
+	src := &mockStreamer{reports: replayReports(3)}
+	w := &mockWriter{}
+	from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	n, err := Replay(context.Background(), src, w, from, to, 0, "replay-trace")
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, from, src.from)
+	assert.Equal(t, to, src.to)
+
+	require.Len(t, w.written, 3)
+	for i, msg := range w.written {
+		assert.Equal(t, src.reports[i].ID, string(msg.Key))
+		assert.Equal(t, defaultSchemaVersion, headerValue(msg, HeaderSchemaVersion))
+		assert.Equal(t, "replay-trace", headerValue(msg, HeaderTraceID))
+		ingestedAt, err := time.Parse(time.RFC3339Nano, headerValue(msg, HeaderIngestedAt))
+		require.NoError(t, err)
+		assert.True(t, src.reports[i].ProcessedAt.Equal(ingestedAt))
+		decoded, err := decodeMessage(msg)
+		require.NoError(t, err)
+		assert.Equal(t, src.reports[i].ID, decoded.ID)
+		assert.Equal(t, src.reports[i].Location, decoded.Location)
+	}
+}
+
+func TestReplay_Throttles(t *testing.T) {
+	src := &mockStreamer{reports: replayReports(5)}
+	w := &mockWriter{}
+
+	start := time.Now()
+	n, err := Replay(context.Background(), src, w, time.Time{}, time.Now(), 50, "")
+	require.NoError(t, err)
+	assert.Equal(t, 5, n)
+	// 5 messages at 50/s wait for 5 ticks of 20ms.
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
+func TestReplay_RateAboveOnePerNanosecond(t *testing.T) {
+	src := &mockStreamer{reports: replayReports(2)}
+	w := &mockWriter{}
+
+	n, err := Replay(context.Background(), src, w, time.Time{}, time.Now(), 2_000_000_000, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestReplay_StopsOnWriteError(t *testing.T) {
+	src := &mockStreamer{reports: replayReports(3)}
+	w := &mockWriter{writeErr: errors.New("broker unavailable")}
+
+	n, err := Replay(context.Background(), src, w, time.Time{}, time.Now(), 0, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "broker unavailable")
+	assert.Equal(t, 0, n)
+}
+
+func TestReplay_RespectsContext(t *testing.T) {
+	src := &mockStreamer{reports: replayReports(10)}
+	w := &mockWriter{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	n, err := Replay(ctx, src, w, time.Time{}, time.Now(), 10, "")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, n, 10)
+}
@@ -0,0 +1,59 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// EnsureTopic creates topic with the given number of partitions if it does
+// not already exist. It is meant for local development, where the broker
+// starts empty: the replication factor is 1 and no other topic settings are
+// applied. Topic creation must go to the cluster controller, so the first
+// reachable broker is only used to find it.
+func EnsureTopic(ctx context.Context, brokers []string, topic string, partitions int) error {
+	var dialer kafkago.Dialer
+	errs := make([]error, 0, len(brokers))
+	for _, broker := range brokers {
+		conn, err := dialer.DialContext(ctx, "tcp", broker)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		controller, err := conn.Controller()
+		_ = conn.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: find controller: %w", broker, err))
+			continue
+		}
+
+		conn, err = dialer.DialContext(ctx, "tcp", net.JoinHostPort(controller.Host, strconv.Itoa(controller.Port)))
+		if err != nil {
+			return fmt.Errorf("dial controller: %w", err)
+		}
+		defer conn.Close()
+		if deadline, ok := ctx.Deadline(); ok {
+			_ = conn.SetDeadline(deadline)
+		}
+		// Already existing topics are not an error.
+		if err := conn.CreateTopics(kafkago.TopicConfig{
+			Topic:             topic,
+			NumPartitions:     partitions,
+			ReplicationFactor: 1,
+		}); err != nil {
+			return fmt.Errorf("create topic %s: %w", topic, err)
+		}
+		return nil
+	}
+	if len(errs) == 0 {
+		return errors.New("no brokers configured")
+	}
+	return errors.Join(errs...)
+}
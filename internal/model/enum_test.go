@@ -12,6 +12,7 @@ func TestSortFieldIsValid(t *testing.T) {
 		model.SortFieldMagnitude,
 		model.SortFieldLocationState,
 		model.SortFieldEventType,
+		model.SortFieldProcessedAt,
 	}
 	for _, sf := range valid {
 		if !sf.IsValid() {
@@ -36,6 +37,7 @@ func TestSortFieldString(t *testing.T) {
 		{model.SortFieldMagnitude, "MAGNITUDE"},
 		{model.SortFieldLocationState, "LOCATION_STATE"},
 		{model.SortFieldEventType, "EVENT_TYPE"},
+		{model.SortFieldProcessedAt, "PROCESSED_AT"},
 	}
 	for _, tt := range tests {
 		if got := tt.field.String(); got != tt.want {
@@ -68,3 +70,50 @@ func TestSortOrderString(t *testing.T) {
 		t.Errorf("SortOrderDesc.String() = %q, want DESC", got)
 	}
 }
+
+func TestTimeGranularityIsValid(t *testing.T) {
+	for _, g := range []model.TimeGranularity{model.TimeGranularityHour, model.TimeGranularityDay} {
+		if !g.IsValid() {
+			t.Errorf("expected %q to be valid", g)
+		}
+	}
+	for _, g := range []model.TimeGranularity{"WEEK", "", "hour"} {
+		if g.IsValid() {
+			t.Errorf("expected %q to be invalid", g)
+		}
+	}
+}
+
+func TestSeverityOrdinal(t *testing.T) {
+	tests := []struct {
+		sev  model.Severity
+		want int
+	}{
+		{model.SeverityMinor, 1},
+		{model.SeverityModerate, 2},
+		{model.SeveritySevere, 3},
+		{model.SeverityExtreme, 4},
+		{"INVALID", 0},
+	}
+	for _, tt := range tests {
+		if got := tt.sev.Ordinal(); got != tt.want {
+			t.Errorf("Severity(%q).Ordinal() = %d, want %d", tt.sev, got, tt.want)
+		}
+	}
+}
+
+func TestSeverityAtOrAbove(t *testing.T) {
+	got := model.SeverityModerate.AtOrAbove()
+	want := []model.Severity{model.SeverityModerate, model.SeveritySevere, model.SeverityExtreme}
+	if len(got) != len(want) {
+		t.Fatalf("AtOrAbove() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("AtOrAbove()[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if got := model.Severity("INVALID").AtOrAbove(); got != nil {
+		t.Errorf("AtOrAbove() for invalid severity = %v, want nil", got)
+	}
+}
@@ -32,6 +32,9 @@ type StormReport struct {
 	SourceOffice string      `json:"source_office"`
 	TimeBucket   time.Time   `json:"time_bucket"`
 	ProcessedAt  time.Time   `json:"processed_at"`
+	// Pipeline names the upstream ETL pipeline that produced the report, from
+	// the message's pipeline header or field. Empty when untagged.
+	Pipeline string `json:"pipeline,omitempty"`
 }
 
 // Geo holds latitude and longitude coordinates. Nested as a struct because
@@ -55,6 +58,14 @@ type Location struct {
 	County    string   `json:"county"`
 }
 
+// ParsedLocation is a location relative to a named place ("2 NW Springfield").
+// Distance and Direction are nil when the report is at the place itself.
+type ParsedLocation struct {
+	Name      string   `json:"name"`
+	Distance  *float64 `json:"distance,omitempty"`
+	Direction *string  `json:"direction,omitempty"`
+}
+
 // Measurement groups magnitude, unit, and severity for a storm report.
 // Nested on StormReport to match the Kafka wire format; gqlgen auto-resolves
 // the GraphQL Measurement type. Flattened to measurement_* DB columns.
@@ -76,6 +87,9 @@ const (
 	EventTypeTornado EventType = "TORNADO"
 )
 
+// AllEventTypes lists every known event type.
+var AllEventTypes = []EventType{EventTypeHail, EventTypeWind, EventTypeTornado}
+
 // IsValid returns true if the event type is a known value.
 func (e EventType) IsValid() bool {
 	switch e {
@@ -119,6 +133,9 @@ const (
 	SeverityExtreme  Severity = "EXTREME"
 )
 
+// AllSeverities lists every severity from least to most severe.
+var AllSeverities = []Severity{SeverityMinor, SeverityModerate, SeveritySevere, SeverityExtreme}
+
 // IsValid returns true if the severity is a known value.
 func (e Severity) IsValid() bool {
 	switch e {
@@ -133,6 +150,25 @@ func (e Severity) String() string { return string(e) }
 // DBValue returns the lowercase DB representation of the severity.
 func (e Severity) DBValue() string { return strings.ToLower(string(e)) }
 
+// Ordinal returns the severity's rank, 1 (minor) through 4 (extreme), or 0
+// for an unknown value.
+func (e Severity) Ordinal() int {
+	for i, s := range AllSeverities {
+		if s == e {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// AtOrAbove returns the severities ranked at or above e, least severe first.
+func (e Severity) AtOrAbove() []Severity {
+	if o := e.Ordinal(); o > 0 {
+		return AllSeverities[o-1:]
+	}
+	return nil
+}
+
 // UnmarshalGQL implements the graphql.Unmarshaler interface.
 func (e *Severity) UnmarshalGQL(v any) error {
 	str, ok := v.(string)
@@ -160,12 +196,13 @@ const (
 	SortFieldMagnitude     SortField = "MAGNITUDE"
 	SortFieldLocationState SortField = "LOCATION_STATE"
 	SortFieldEventType     SortField = "EVENT_TYPE"
+	SortFieldProcessedAt   SortField = "PROCESSED_AT"
 )
 
 // IsValid returns true if the sort field is a known value.
 func (e SortField) IsValid() bool {
 	switch e {
-	case SortFieldEventTime, SortFieldMagnitude, SortFieldLocationState, SortFieldEventType:
+	case SortFieldEventTime, SortFieldMagnitude, SortFieldLocationState, SortFieldEventType, SortFieldProcessedAt:
 		return true
 	}
 	return false
@@ -193,6 +230,26 @@ func (e SortOrder) IsValid() bool {
 
 func (e SortOrder) String() string { return string(e) }
 
+// TimeGranularity is the bucket width of byHour aggregations.
+type TimeGranularity string
+
+// TimeGranularity enum values.
+const (
+	TimeGranularityHour TimeGranularity = "HOUR"
+	TimeGranularityDay  TimeGranularity = "DAY"
+)
+
+// IsValid returns true if the granularity is a known value.
+func (e TimeGranularity) IsValid() bool {
+	switch e {
+	case TimeGranularityHour, TimeGranularityDay:
+		return true
+	}
+	return false
+}
+
+func (e TimeGranularity) String() string { return string(e) }
+
 // ─── Filter inputs ──────────────────────────────────────────
 
 // TimeRange specifies a time window for filtering.
@@ -208,6 +265,14 @@ type GeoRadiusFilter struct {
 	RadiusMiles *float64 `json:"radiusMiles,omitempty"`
 }
 
+// HourOfDayRange selects reports whose local hour of day falls within
+// [From, To], inclusive. From > To wraps past midnight (e.g. 22 → 2).
+type HourOfDayRange struct {
+	From     int     `json:"from"`
+	To       int     `json:"to"`
+	TimeZone *string `json:"timeZone,omitempty"`
+}
+
 // EventTypeFilter allows per-type overrides for severity, magnitude, and radius.
 type EventTypeFilter struct {
 	EventType    EventType  `json:"eventType"`
@@ -218,17 +283,26 @@ type EventTypeFilter struct {
 
 // StormReportFilter specifies time range, event, location, sorting, and pagination criteria.
 type StormReportFilter struct {
-	TimeRange TimeRange        `json:"timeRange"`
-	Near      *GeoRadiusFilter `json:"near,omitempty"`
-	States    []string         `json:"states,omitempty"`
-	Counties  []string         `json:"counties,omitempty"`
+	TimeRange             *TimeRange       `json:"timeRange,omitempty"`
+	HourOfDayRange        *HourOfDayRange  `json:"hourOfDayRange,omitempty"`
+	IngestedWithinMinutes *int             `json:"ingestedWithinMinutes,omitempty"`
+	IDPrefix              *string          `json:"idPrefix,omitempty"`
+	Near                  *GeoRadiusFilter `json:"near,omitempty"`
+	NearPlace             *string          `json:"nearPlace,omitempty"`
+	States                []string         `json:"states,omitempty"`
+	Counties              []string         `json:"counties,omitempty"`
+	ExcludeStates         []string         `json:"excludeStates,omitempty"`
+	ExcludeCounties       []string         `json:"excludeCounties,omitempty"`
+	Pipelines             []string         `json:"pipelines,omitempty"`
+	ImpactKeywords        []string         `json:"impactKeywords,omitempty"`
 
 	// Global defaults — apply to any type not overridden.
 	EventTypes   []EventType `json:"eventTypes,omitempty"`
 	Severity     []Severity  `json:"severity,omitempty"`
+	MinSeverity  *Severity   `json:"minSeverity,omitempty"`
 	MinMagnitude *float64    `json:"minMagnitude,omitempty"`
 
-	// Per-type overrides (max 3).
+	// Per-type overrides (max 3 by default).
 	EventTypeFilters []*EventTypeFilter `json:"eventTypeFilters,omitempty"`
 
 	// Sorting & pagination.
@@ -236,6 +310,17 @@ type StormReportFilter struct {
 	SortOrder *SortOrder `json:"sortOrder,omitempty"`
 	Limit     *int       `json:"limit,omitempty"`
 	Offset    *int       `json:"offset,omitempty"`
+
+	// SampleAggregations estimates aggregations from a table sample.
+	SampleAggregations *bool `json:"sampleAggregations,omitempty"`
+
+	// MagnitudeBucketEdges are the ascending range boundaries for
+	// byMagnitudeBucket.
+	MagnitudeBucketEdges []float64 `json:"magnitudeBucketEdges,omitempty"`
+
+	// MaxAggregationRows caps the combined event type, state, county and
+	// time-bucket groups returned.
+	MaxAggregationRows *int `json:"maxAggregationRows,omitempty"`
 }
 
 // ─── Result envelope ────────────────────────────────────────
@@ -249,18 +334,52 @@ type StormReportsResult struct {
 	Meta         *QueryMeta         `json:"meta"`
 }
 
-// StormAggregations groups aggregation results by event type, state, and hour.
+// StormAggregations groups aggregation results by event type, state, hour,
+// severity, day of week, and magnitude range.
 type StormAggregations struct {
-	TotalCount  int               `json:"totalCount"`
-	ByEventType []*EventTypeGroup `json:"byEventType"`
-	ByState     []*StateGroup     `json:"byState"`
-	ByHour      []*TimeGroup      `json:"byHour"`
+	TotalCount        int                     `json:"totalCount"`
+	ByEventType       []*EventTypeGroup       `json:"byEventType"`
+	ByState           []*StateGroup           `json:"byState"`
+	ByHour            []*TimeGroup            `json:"byHour"`
+	ByHourGranularity TimeGranularity         `json:"byHourGranularity"`
+	BySeverity        []*SeverityGroup        `json:"bySeverity"`
+	ByDayOfWeek       []*DayOfWeekGroup       `json:"byDayOfWeek"`
+	ByMagnitudeBucket []*MagnitudeBucketGroup `json:"byMagnitudeBucket"`
+	Sampled           bool                    `json:"sampled"`
+	SampleFraction    *float64                `json:"sampleFraction,omitempty"`
+	Truncated         bool                    `json:"truncated"`
 }
 
 // QueryMeta provides metadata about the query result.
 type QueryMeta struct {
 	LastUpdated    *time.Time `json:"lastUpdated,omitempty"`
 	DataLagMinutes *int       `json:"dataLagMinutes,omitempty"`
+	Centroid       *Geo       `json:"centroid,omitempty"`
+	Density        *float64   `json:"density,omitempty"`
+	// AggregationsTimedOut is set when the aggregation query hit the database
+	// statement timeout and the aggregation groups were left empty.
+	AggregationsTimedOut bool `json:"aggregationsTimedOut"`
+}
+
+// DataTimeExtent is the range of event times covered by stored reports.
+type DataTimeExtent struct {
+	Earliest time.Time `json:"earliest"`
+	Latest   time.Time `json:"latest"`
+}
+
+// IngestionGap is the pause between two consecutive processed_at times.
+type IngestionGap struct {
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+	Minutes int       `json:"minutes"`
+}
+
+// GeoBounds is the bounding box enclosing a set of storm reports.
+type GeoBounds struct {
+	MinLat float64 `json:"minLat"`
+	MaxLat float64 `json:"maxLat"`
+	MinLon float64 `json:"minLon"`
+	MaxLon float64 `json:"maxLon"`
 }
 
 // ─── Aggregation types ──────────────────────────────────────
@@ -274,18 +393,45 @@ type EventTypeGroup struct {
 
 // StateGroup aggregates storm reports by state, with county breakdowns.
 type StateGroup struct {
-	State    string         `json:"state"`
-	Count    int            `json:"count"`
-	Counties []*CountyGroup `json:"counties"`
+	State        string         `json:"state"`
+	Count        int            `json:"count"`
+	Counties     []*CountyGroup `json:"counties"`
+	NearestMiles *float64       `json:"nearestMiles,omitempty"`
 }
 
 // CountyGroup aggregates storm reports by county within a state.
 type CountyGroup struct {
-	County string `json:"county"`
-	Count  int    `json:"count"`
+	County       string   `json:"county"`
+	Count        int      `json:"count"`
+	NearestMiles *float64 `json:"nearestMiles,omitempty"`
+}
+
+// SeverityGroup aggregates storm reports by severity. Severity is nil for
+// reports without a classification.
+type SeverityGroup struct {
+	Severity *string `json:"severity,omitempty"`
+	Count    int     `json:"count"`
+}
+
+// MagnitudeBucketGroup aggregates one event type's reports within a
+// magnitude range [Min, Max). Min is nil for the range below the first edge
+// and Max is nil for the range from the last edge up.
+type MagnitudeBucketGroup struct {
+	EventType string   `json:"eventType"`
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Count     int      `json:"count"`
+}
+
+// DayOfWeekGroup aggregates storm reports by local day of the week.
+// DayOfWeek follows time.Weekday: 0 is Sunday.
+type DayOfWeekGroup struct {
+	DayOfWeek int    `json:"dayOfWeek"`
+	Name      string `json:"name"`
+	Count     int    `json:"count"`
 }
 
-// TimeGroup aggregates storm reports by hourly time bucket.
+// TimeGroup aggregates storm reports by time bucket (hourly or daily).
 type TimeGroup struct {
 	Bucket time.Time `json:"bucket"`
 	Count  int       `json:"count"`
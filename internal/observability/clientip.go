@@ -0,0 +1,78 @@
+package observability
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+type clientIPContextKey struct{}
+
+// ClientIP resolves the caller's address and stores it in the request context
+// (see ClientIPFromContext). X-Forwarded-For is honoured only when the direct
+// peer is a trusted proxy; the header is then walked right to left, skipping
+// trusted hops, so a client cannot spoof its address by sending the header
+// itself. The resolved address also replaces r.RemoteAddr so request logs show
+// the client rather than the load balancer.
+func ClientIP(trusted []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if ip := resolveClientIP(r, trusted); ip.IsValid() {
+				r = r.WithContext(context.WithValue(r.Context(), clientIPContextKey{}, ip.String()))
+				r.RemoteAddr = ip.String()
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ClientIPFromContext returns the address resolved by ClientIP, or "" if the
+// middleware did not run or the peer address could not be parsed.
+func ClientIPFromContext(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPContextKey{}).(string)
+	return ip
+}
+
+func resolveClientIP(r *http.Request, trusted []netip.Prefix) netip.Addr {
+	peer := parseAddr(r.RemoteAddr)
+	if !peer.IsValid() || !isTrusted(peer, trusted) {
+		return peer
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	client := peer
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := parseAddr(strings.TrimSpace(hops[i]))
+		if !hop.IsValid() {
+			break
+		}
+		client = hop
+		if !isTrusted(hop, trusted) {
+			break
+		}
+	}
+	return client
+}
+
+// parseAddr accepts a bare IP or host:port and unmaps IPv4-in-IPv6 addresses
+// so they match IPv4 prefixes.
+func parseAddr(s string) netip.Addr {
+	if host, _, err := net.SplitHostPort(s); err == nil {
+		s = host
+	}
+	addr, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}
+	}
+	return addr.Unmap()
+}
+
+func isTrusted(addr netip.Addr, trusted []netip.Prefix) bool {
+	for _, p := range trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
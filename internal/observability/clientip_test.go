@@ -0,0 +1,55 @@
+package observability
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		xff        []string
+		want       string
+	}{
+		{"direct client", "203.0.113.7:5123", nil, "203.0.113.7"},
+		{"untrusted peer ignores header", "203.0.113.7:5123", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"trusted proxy", "10.0.0.5:443", []string{"198.51.100.1"}, "198.51.100.1"},
+		{"spoofed leftmost hop", "10.0.0.5:443", []string{"1.2.3.4, 198.51.100.1"}, "198.51.100.1"},
+		{"chained trusted proxies", "10.0.0.5:443", []string{"198.51.100.1, 10.1.2.3"}, "198.51.100.1"},
+		{"multiple headers", "10.0.0.5:443", []string{"198.51.100.1", "10.1.2.3"}, "198.51.100.1"},
+		{"trusted proxy without header", "10.0.0.5:443", nil, "10.0.0.5"},
+		{"garbage hop stops the walk", "10.0.0.5:443", []string{"198.51.100.1, junk"}, "10.0.0.5"},
+		{"ipv4-mapped peer", "[::ffff:10.0.0.5]:443", []string{"198.51.100.1"}, "198.51.100.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotCtx, gotRemote string
+			h := ClientIP(trusted)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				gotCtx = ClientIPFromContext(r.Context())
+				gotRemote = r.RemoteAddr
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/query", nil)
+			req.RemoteAddr = tt.remoteAddr
+			for _, v := range tt.xff {
+				req.Header.Add("X-Forwarded-For", v)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, gotCtx)
+			assert.Equal(t, tt.want, gotRemote)
+		})
+	}
+}
+
+func TestClientIPFromContext_Unset(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/query", nil)
+	assert.Empty(t, ClientIPFromContext(req.Context()))
+}
@@ -1,7 +1,10 @@
 package observability
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"time"
 
 	sharedobs "github.com/couchcryptid/storm-data-shared/observability"
 )
@@ -11,7 +14,68 @@ func LivenessHandler() http.HandlerFunc {
 	return sharedobs.LivenessHandler()
 }
 
+// readinessTimeout matches the shared ReadinessHandler's check deadline.
+const readinessTimeout = 2 * time.Second
+
+// Component is an optional readiness dependency reported under its own name
+// in the /readyz body, e.g. the Kafka consumer.
+type Component struct {
+	Name    string
+	Checker ReadinessChecker
+}
+
 // ReadinessHandler checks downstream dependencies and returns 200 or 503.
-func ReadinessHandler(checker ReadinessChecker) http.HandlerFunc {
-	return sharedobs.ReadinessHandler(checker)
+// Components also gate readiness, and each one's status is added to the
+// body under "components" so operators can see which dependency is holding
+// the instance back.
+func ReadinessHandler(checker ReadinessChecker, components ...Component) http.HandlerFunc {
+	if len(components) == 0 {
+		return sharedobs.ReadinessHandler(checker)
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+
+		status := http.StatusOK
+		body := map[string]any{"status": "ready"}
+		notReady := func(err error) {
+			if status == http.StatusOK {
+				status = http.StatusServiceUnavailable
+				body["status"] = "not ready"
+				body["error"] = err.Error()
+			}
+		}
+		if err := checker.CheckReadiness(ctx); err != nil {
+			notReady(err)
+		}
+
+		statuses := make(map[string]map[string]string, len(components))
+		for _, c := range components {
+			if err := c.Checker.CheckReadiness(ctx); err != nil {
+				statuses[c.Name] = map[string]string{"status": "not ready", "error": err.Error()}
+				notReady(fmt.Errorf("%s: %w", c.Name, err))
+				continue
+			}
+			statuses[c.Name] = map[string]string{"status": "ready"}
+		}
+		body["components"] = statuses
+		sharedobs.WriteJSON(w, status, body)
+	}
+}
+
+// AllReady combines checkers into one that reports the first failure, so a
+// single readiness endpoint can cover several dependencies.
+func AllReady(checkers ...ReadinessChecker) ReadinessChecker {
+	return allReady(checkers)
+}
+
+type allReady []ReadinessChecker
+
+func (a allReady) CheckReadiness(ctx context.Context) error {
+	for _, c := range a {
+		if err := c.CheckReadiness(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
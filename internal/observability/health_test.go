@@ -63,3 +63,44 @@ func TestReadinessHandler_NotReady(t *testing.T) {
 	assert.Equal(t, "not ready", body["status"])
 	assert.Equal(t, "db not connected", body["error"])
 }
+
+func TestReadinessHandler_Components(t *testing.T) {
+	kafka := &mockChecker{err: errors.New("no successful fetch from storms yet")}
+	handler := ReadinessHandler(&mockChecker{}, Component{Name: "kafka", Checker: kafka})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{
+		"status": "not ready",
+		"error": "kafka: no successful fetch from storms yet",
+		"components": {"kafka": {"status": "not ready", "error": "no successful fetch from storms yet"}}
+	}`, rec.Body.String())
+
+	kafka.err = nil
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status": "ready", "components": {"kafka": {"status": "ready"}}}`, rec.Body.String())
+}
+
+func TestReadinessHandler_ComponentsReportPrimaryFailureFirst(t *testing.T) {
+	handler := ReadinessHandler(&mockChecker{err: errors.New("db not connected")},
+		Component{Name: "kafka", Checker: &mockChecker{}})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.JSONEq(t, `{
+		"status": "not ready",
+		"error": "db not connected",
+		"components": {"kafka": {"status": "ready"}}
+	}`, rec.Body.String())
+}
+
+func TestAllReady(t *testing.T) {
+	down := errors.New("inserts failing")
+	assert.NoError(t, AllReady(&mockChecker{}, &mockChecker{}).CheckReadiness(context.Background()))
+	assert.ErrorIs(t, AllReady(&mockChecker{}, &mockChecker{err: down}).CheckReadiness(context.Background()), down)
+	assert.NoError(t, AllReady().CheckReadiness(context.Background()))
+}
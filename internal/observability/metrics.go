@@ -2,12 +2,15 @@ package observability
 
 import (
 	"context"
+	"sync"
 
+	"github.com/couchcryptid/storm-data-api/internal/config"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
-const namespace = "storm_api"
+// defaultNamespace prefixes metric names when METRICS_NAMESPACE is unset.
+const defaultNamespace = "storm_api"
 
 // ReadinessChecker reports whether a dependency is ready to serve traffic.
 type ReadinessChecker interface {
@@ -20,64 +23,149 @@ type Metrics struct {
 	HTTPRequestsTotal   *prometheus.CounterVec
 	HTTPRequestDuration *prometheus.HistogramVec
 
+	// GraphQL
+	GraphQLLimitCapped          *prometheus.CounterVec
+	GraphQLValidationRejections *prometheus.CounterVec
+
 	// Kafka
 	KafkaMessagesConsumed *prometheus.CounterVec
 	KafkaConsumerErrors   *prometheus.CounterVec
+	KafkaCommitErrors     *prometheus.CounterVec
+	KafkaBatchDuplicates  *prometheus.CounterVec
 	KafkaConsumerRunning  *prometheus.GaugeVec
 	KafkaBatchSize        *prometheus.HistogramVec
 	KafkaBatchDuration    *prometheus.HistogramVec
+	PipelineLatency       *prometheus.HistogramVec
+
+	// Live stream
+	StreamSubscribers   prometheus.Gauge
+	StreamDroppedEvents prometheus.Counter
 
 	// Database
-	DBQueryDuration   *prometheus.HistogramVec
-	DBPoolConnections *prometheus.GaugeVec
+	DBQueryDuration        *prometheus.HistogramVec
+	DBPoolConnections      *prometheus.GaugeVec
+	DBPoolAcquireWait      prometheus.Gauge
+	DBPoolEmptyAcquires    prometheus.Gauge
+	DBPoolCanceledAcquires prometheus.Gauge
+	DBCircuitBreakerState  *prometheus.GaugeVec
+	DBLockConflicts        *prometheus.CounterVec
+
+	// Result caches
+	CacheHits      *prometheus.CounterVec
+	CacheMisses    *prometheus.CounterVec
+	CacheEvictions *prometheus.CounterVec
+
+	// exemplars attaches trace IDs to histogram observations (see Observe).
+	exemplars bool
 }
 
-// NewMetrics creates and registers all application metrics with the default registry.
-func NewMetrics() *Metrics {
-	return newMetrics(promauto.With(prometheus.DefaultRegisterer))
+var (
+	defaultMetricsOnce sync.Once
+	defaultMetrics     *Metrics
+)
+
+// NewMetrics returns the application metrics registered with the default
+// registry. They are created on the first call; later calls return the same
+// Metrics and ignore cfg, since the default registry can hold each collector
+// only once. Safe for concurrent use.
+func NewMetrics(cfg *config.Config) *Metrics {
+	defaultMetricsOnce.Do(func() {
+		defaultMetrics = NewMetricsWithRegistry(prometheus.DefaultRegisterer, cfg)
+	})
+	return defaultMetrics
+}
+
+// NewMetricsWithRegistry creates all application metrics and registers them
+// with reg, for services that embed this package and expose their own
+// registry. Metric names are prefixed with cfg.MetricsNamespace and, when
+// set, cfg.MetricsSubsystem; latency histogram buckets are taken from cfg.
+// Like promauto, it panics if reg already holds collectors with the same
+// names.
+func NewMetricsWithRegistry(reg prometheus.Registerer, cfg *config.Config) *Metrics {
+	namespace := cfg.MetricsNamespace
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	m := newMetrics(promauto.With(reg), namespace, cfg.MetricsSubsystem, cfg.HTTPDurationBuckets, cfg.DBDurationBuckets)
+	m.exemplars = cfg.MetricsExemplars
+	return m
 }
 
 // NewTestMetrics creates metrics backed by a throw-away registry.
 // Safe to call from multiple tests without duplicate-registration panics.
 func NewTestMetrics() *Metrics {
-	return newMetrics(promauto.With(prometheus.NewRegistry()))
+	return newMetrics(promauto.With(prometheus.NewRegistry()), defaultNamespace, "", config.DefaultDurationBuckets, config.DefaultDurationBuckets)
 }
 
-func newMetrics(factory promauto.Factory) *Metrics {
+func newMetrics(factory promauto.Factory, namespace, subsystem string, httpBuckets, dbBuckets []float64) *Metrics {
 	return &Metrics{
 		HTTPRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "http_requests_total",
 			Help:      "Total HTTP requests processed.",
 		}, []string{"method", "path", "status"}),
 
 		HTTPRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "http_request_duration_seconds",
 			Help:      "HTTP request duration in seconds.",
-			Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+			Buckets:   httpBuckets,
 		}, []string{"method", "path"}),
 
+		GraphQLLimitCapped: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "graphql_limit_capped_total",
+			Help:      "stormReports requests whose limit was defaulted to, or rejected for exceeding, the page-size cap.",
+		}, []string{"reason"}),
+
+		GraphQLValidationRejections: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "graphql_validation_rejections_total",
+			Help:      "Queries rejected by filter validation, by the rule that failed.",
+		}, []string{"rule"}),
+
 		KafkaMessagesConsumed: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "kafka_messages_consumed_total",
 			Help:      "Total Kafka messages consumed.",
-		}, []string{"topic"}),
+		}, []string{"topic", "mode"}),
 
 		KafkaConsumerErrors: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "kafka_consumer_errors_total",
 			Help:      "Total Kafka consumer errors.",
-		}, []string{"topic", "error_type"}),
+		}, []string{"topic", "mode", "error_type"}),
+
+		KafkaCommitErrors: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "kafka_commit_errors_total",
+			Help:      "Total failed Kafka offset commits.",
+		}, []string{"topic", "mode"}),
+
+		KafkaBatchDuplicates: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "kafka_batch_duplicate_reports_total",
+			Help:      "Reports dropped from a batch because an earlier message in it carried the same ID.",
+		}, []string{"topic"}),
 
 		KafkaConsumerRunning: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "kafka_consumer_running",
 			Help:      "Whether the Kafka consumer is running (1) or stopped (0).",
-		}, []string{"topic"}),
+		}, []string{"topic", "mode"}),
 
 		KafkaBatchSize: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "kafka_batch_size",
 			Help:      "Number of messages in each consumed batch.",
 			Buckets:   []float64{1, 5, 10, 25, 50, 100, 250, 500},
@@ -85,22 +173,104 @@ func newMetrics(factory promauto.Factory) *Metrics {
 
 		KafkaBatchDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "kafka_batch_duration_seconds",
 			Help:      "Duration of batch operations.",
 			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
 		}, []string{"topic", "operation"}),
 
+		PipelineLatency: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "pipeline_latency_seconds",
+			Help:      "Time from a report's event_time to its processed_at, observed when it is persisted.",
+			// Reports reach SPC minutes to days after the event.
+			Buckets: []float64{60, 300, 900, 1800, 3600, 2 * 3600, 6 * 3600, 12 * 3600, 24 * 3600, 48 * 3600, 7 * 24 * 3600},
+		}, []string{"event_type"}),
+
+		StreamSubscribers: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "stream_subscribers",
+			Help:      "Number of live stream subscribers attached to the hub.",
+		}),
+
+		StreamDroppedEvents: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "stream_dropped_events_total",
+			Help:      "Live stream events dropped because a subscriber's buffer was full.",
+		}),
+
 		DBQueryDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "db_query_duration_seconds",
 			Help:      "Database query duration in seconds.",
-			Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+			Buckets:   dbBuckets,
 		}, []string{"operation"}),
 
 		DBPoolConnections: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
+			Subsystem: subsystem,
 			Name:      "db_pool_connections",
 			Help:      "Database connection pool statistics.",
 		}, []string{"state"}),
+
+		DBPoolAcquireWait: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "db_pool_acquire_wait_seconds",
+			Help:      "Cumulative time spent acquiring database connections from the pool.",
+		}),
+
+		DBPoolEmptyAcquires: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "db_pool_empty_acquires",
+			Help:      "Cumulative pool acquires that had to wait because no idle connection was available.",
+		}),
+
+		DBPoolCanceledAcquires: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "db_pool_canceled_acquires",
+			Help:      "Cumulative pool acquires canceled by their context before a connection was available.",
+		}),
+
+		DBCircuitBreakerState: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "db_circuit_breaker_state",
+			Help:      "Database circuit breaker state: 0 closed, 1 half-open, 2 open.",
+		}, []string{"breaker"}),
+
+		DBLockConflicts: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "db_lock_conflicts_total",
+			Help:      "Batch writes that hit a deadlock, serialization failure or lock timeout, including those later retried successfully.",
+		}, []string{"operation"}),
+
+		CacheHits: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_hits_total",
+			Help:      "Lookups answered from an in-process result cache without querying the database.",
+		}, []string{"cache"}),
+
+		CacheMisses: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_misses_total",
+			Help:      "Lookups that found no fresh entry in an in-process result cache and queried the database.",
+		}, []string{"cache"}),
+
+		CacheEvictions: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "cache_evictions_total",
+			Help:      "Entries dropped from an in-process result cache to make room for new ones.",
+		}, []string{"cache"}),
 	}
 }
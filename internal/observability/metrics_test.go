@@ -0,0 +1,69 @@
+package observability
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/config"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testConfig() *config.Config {
+	return &config.Config{
+		HTTPDurationBuckets: config.DefaultDurationBuckets,
+		DBDurationBuckets:   config.DefaultDurationBuckets,
+	}
+}
+
+func TestNewMetrics_ReturnsSingleton(t *testing.T) {
+	var wg sync.WaitGroup
+	got := make([]*Metrics, 8)
+	for i := range got {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got[i] = NewMetrics(testConfig())
+		}()
+	}
+	wg.Wait()
+
+	require.NotNil(t, got[0])
+	for _, m := range got {
+		assert.Same(t, got[0], m, "repeated calls must not re-register with the default registry")
+	}
+}
+
+func TestNewMetricsWithRegistry(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cfg := testConfig()
+	cfg.MetricsExemplars = true
+	m := NewMetricsWithRegistry(reg, cfg)
+	assert.True(t, m.exemplars)
+
+	m.KafkaCommitErrors.WithLabelValues("topic", "batch").Inc()
+	n, err := testutil.GatherAndCount(reg, "storm_api_kafka_commit_errors_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// A second registry is independent of the first.
+	other := NewMetricsWithRegistry(prometheus.NewRegistry(), testConfig())
+	assert.Zero(t, testutil.ToFloat64(other.KafkaCommitErrors.WithLabelValues("topic", "batch")))
+
+	assert.Panics(t, func() { NewMetricsWithRegistry(reg, testConfig()) }, "duplicate registration on one registry")
+}
+
+func TestNewMetricsWithRegistry_NamespaceAndSubsystem(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	cfg := testConfig()
+	cfg.MetricsNamespace = "storm"
+	cfg.MetricsSubsystem = "graphql_api"
+	m := NewMetricsWithRegistry(reg, cfg)
+
+	m.HTTPRequestsTotal.WithLabelValues("POST", "/query", "200").Inc()
+	n, err := testutil.GatherAndCount(reg, "storm_graphql_api_http_requests_total")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
@@ -2,31 +2,55 @@ package observability
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 )
 
-// MetricsMiddleware records HTTP request duration and count.
-func MetricsMiddleware(m *Metrics) func(http.Handler) http.Handler {
+// unmatchedRoute is the path label for requests that matched no route, so
+// scanners probing random paths can't grow the label set.
+const unmatchedRoute = "unmatched"
+
+// MetricsExclusions lists requests MetricsMiddleware does not record, such as
+// probe and CORS preflight traffic that would otherwise dominate request
+// rates.
+type MetricsExclusions struct {
+	// Methods are HTTP methods, e.g. OPTIONS or HEAD.
+	Methods []string
+	// Paths are chi route patterns, including any route prefix, e.g.
+	// /healthz.
+	Paths []string
+}
+
+// MetricsMiddleware records HTTP request duration and count, except for
+// requests matching exclude.
+func MetricsMiddleware(m *Metrics, exclude MetricsExclusions) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if slices.Contains(exclude.Methods, r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
 			start := time.Now()
 			ww := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 			next.ServeHTTP(ww, r)
 
 			// Use chi's route pattern to avoid unbounded label cardinality
-			// from dynamic path parameters.
-			path := r.URL.Path
+			// from dynamic path parameters and unknown paths.
+			path := unmatchedRoute
 			if rctx := chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
 				path = rctx.RoutePattern()
 			}
+			if slices.Contains(exclude.Paths, path) {
+				return
+			}
 			method := r.Method
 			status := strconv.Itoa(ww.statusCode)
 
-			m.HTTPRequestDuration.WithLabelValues(method, path).Observe(time.Since(start).Seconds())
+			m.Observe(r.Context(), m.HTTPRequestDuration.WithLabelValues(method, path), time.Since(start).Seconds())
 			m.HTTPRequestsTotal.WithLabelValues(method, path, status).Inc()
 		})
 	}
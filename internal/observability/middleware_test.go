@@ -5,12 +5,14 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMetricsMiddleware_RecordsMetrics(t *testing.T) {
 	metrics := NewTestMetrics()
-	middleware := MetricsMiddleware(metrics)
+	middleware := MetricsMiddleware(metrics, MetricsExclusions{})
 
 	inner := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -24,6 +26,38 @@ func TestMetricsMiddleware_RecordsMetrics(t *testing.T) {
 	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
+func TestMetricsMiddleware_UnmatchedRouteLabel(t *testing.T) {
+	metrics := NewTestMetrics()
+	r := chi.NewRouter()
+	r.Use(MetricsMiddleware(metrics, MetricsExclusions{}))
+	r.Get("/query", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, path := range []string{"/wp-login.php", "/.env", "/admin/config.json"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	assert.InDelta(t, 3, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "unmatched", "404")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.HTTPRequestsTotal), "probed paths must not become labels")
+}
+
+func TestMetricsMiddleware_RoutePrefixLabel(t *testing.T) {
+	metrics := NewTestMetrics()
+	r := chi.NewRouter()
+	r.Use(MetricsMiddleware(metrics, MetricsExclusions{}))
+	r.Route("/storm-api", func(r chi.Router) {
+		r.Get("/query", func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/storm-api/query", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/storm-api/query", "200")), 0)
+}
+
 func TestResponseWriter_WriteHeader(t *testing.T) {
 	rec := httptest.NewRecorder()
 	rw := &responseWriter{ResponseWriter: rec, statusCode: http.StatusOK}
@@ -74,3 +108,32 @@ type nonFlusher struct{ http.ResponseWriter }
 func (nonFlusher) Header() http.Header         { return http.Header{} }
 func (nonFlusher) Write(b []byte) (int, error) { return len(b), nil }
 func (nonFlusher) WriteHeader(_ int)           { /* no-op */ }
+
+func TestMetricsMiddleware_Exclusions(t *testing.T) {
+	metrics := NewTestMetrics()
+	r := chi.NewRouter()
+	r.Use(MetricsMiddleware(metrics, MetricsExclusions{
+		Methods: []string{http.MethodOptions},
+		Paths:   []string{"/storm-api/healthz"},
+	}))
+	ok := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) }
+	r.Route("/storm-api", func(r chi.Router) {
+		r.Get("/healthz", ok)
+		r.Get("/query", ok)
+		r.Options("/query", ok)
+	})
+
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/storm-api/healthz", nil),
+		httptest.NewRequest(http.MethodOptions, "/storm-api/query", nil),
+		httptest.NewRequest(http.MethodGet, "/storm-api/query", nil),
+	} {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Code, "excluded requests are still served")
+	}
+
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.HTTPRequestsTotal))
+	assert.InDelta(t, 1, testutil.ToFloat64(metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/storm-api/query", "200")), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+}
@@ -0,0 +1,72 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type traceIDKey struct{}
+
+// WithTraceID returns a context carrying the given trace ID.
+func WithTraceID(ctx context.Context, traceID string) context.Context {
+	return context.WithValue(ctx, traceIDKey{}, traceID)
+}
+
+// TraceIDFromContext returns the trace ID stored by WithTraceID or
+// TraceContext, or "" when the request is not part of a trace.
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(traceIDKey{}).(string)
+	return id
+}
+
+// TraceContext stores the trace ID from an incoming W3C traceparent header
+// in the request context, so metrics can link observations to the trace an
+// upstream proxy or client started. Malformed headers are ignored.
+func TraceContext(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if id := parseTraceparent(r.Header.Get("traceparent")); id != "" {
+			r = r.WithContext(WithTraceID(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// parseTraceparent extracts the trace ID from a traceparent header of the
+// form version-traceid-parentid-flags.
+func parseTraceparent(header string) string {
+	parts := strings.Split(strings.TrimSpace(header), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" {
+		return ""
+	}
+	id := parts[1]
+	if len(id) != 32 || !isLowerHex(id) || strings.Trim(id, "0") == "" {
+		return ""
+	}
+	return id
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Observe records v on obs. When exemplars are enabled and ctx carries a
+// trace ID, the observation is attached to that trace as an exemplar.
+func (m *Metrics) Observe(ctx context.Context, obs prometheus.Observer, v float64) {
+	if m.exemplars {
+		if id := TraceIDFromContext(ctx); id != "" {
+			if eo, ok := obs.(prometheus.ExemplarObserver); ok {
+				eo.ObserveWithExemplar(v, prometheus.Labels{"trace_id": id})
+				return
+			}
+		}
+	}
+	obs.Observe(v)
+}
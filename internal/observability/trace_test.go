@@ -0,0 +1,84 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+
+func TestParseTraceparent(t *testing.T) {
+	tests := map[string]string{
+		"00-" + testTraceID + "-00f067aa0ba902b7-01": testTraceID,
+		"":        "",
+		"garbage": "",
+		"00-" + "4BF92F3577B34DA6A3CE929D0E0E4736" + "-00f067aa0ba902b7-01": "",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01":           "",
+		"ff-" + testTraceID + "-00f067aa0ba902b7-01":                        "",
+		"00-abc-00f067aa0ba902b7-01":                                        "",
+	}
+	for header, want := range tests {
+		assert.Equal(t, want, parseTraceparent(header), header)
+	}
+}
+
+func TestTraceContext_StoresTraceID(t *testing.T) {
+	var got string
+	handler := TraceContext(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = TraceIDFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/query", http.NoBody)
+	req.Header.Set("traceparent", "00-"+testTraceID+"-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, testTraceID, got)
+
+	got = "unset"
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/query", http.NoBody))
+	assert.Empty(t, got)
+}
+
+func observedExemplar(t *testing.T, ctx context.Context, exemplars bool) *string {
+	t.Helper()
+	reg := prometheus.NewRegistry()
+	h := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_seconds", Buckets: []float64{1}})
+	reg.MustRegister(h)
+
+	m := &Metrics{exemplars: exemplars}
+	m.Observe(ctx, h, 0.5)
+
+	families, err := reg.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+	hist := families[0].GetMetric()[0].GetHistogram()
+	assert.Equal(t, uint64(1), hist.GetSampleCount())
+
+	ex := hist.GetBucket()[0].GetExemplar()
+	if ex == nil {
+		return nil
+	}
+	for _, l := range ex.GetLabel() {
+		if l.GetName() == "trace_id" {
+			return l.Value
+		}
+	}
+	return nil
+}
+
+func TestObserve_AttachesExemplar(t *testing.T) {
+	ctx := WithTraceID(context.Background(), testTraceID)
+	id := observedExemplar(t, ctx, true)
+	require.NotNil(t, id)
+	assert.Equal(t, testTraceID, *id)
+}
+
+func TestObserve_NoExemplarWhenDisabledOrUntraced(t *testing.T) {
+	assert.Nil(t, observedExemplar(t, WithTraceID(context.Background(), testTraceID), false))
+	assert.Nil(t, observedExemplar(t, context.Background(), true))
+}
@@ -1,8 +1,13 @@
 package store
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/couchcryptid/storm-data-api/internal/model"
@@ -13,55 +18,181 @@ type AggResult struct {
 	ByEventType []*model.EventTypeGroup
 	ByState     []*model.StateGroup
 	ByHour      []*model.TimeGroup
+	BySeverity  []*model.SeverityGroup
+	ByDayOfWeek []*model.DayOfWeekGroup
+
+	ByMagnitudeBucket []*model.MagnitudeBucketGroup
+
+	// SampleFraction is the share of table pages scanned when the filter
+	// asked for sampled aggregations, or 0 for an exact result.
+	SampleFraction float64
+}
+
+// defaultMagnitudeBucketEdges split byMagnitudeBucket into below 1, 1–2, 2–3
+// and 3 and up when the filter gives no edges.
+var defaultMagnitudeBucketEdges = []float64{1, 2, 3}
+
+// magnitudeBucketEdges returns the byMagnitudeBucket edges for filter.
+func magnitudeBucketEdges(filter *model.StormReportFilter) []float64 {
+	if len(filter.MagnitudeBucketEdges) > 0 {
+		return filter.MagnitudeBucketEdges
+	}
+	return defaultMagnitudeBucketEdges
+}
+
+// newMagnitudeBucketGroup returns the group for the width_bucket index bucket
+// over edges: 0 is below edges[0], len(edges) is edges[len-1] and up.
+func newMagnitudeBucketGroup(eventType string, bucket int, edges []float64) *model.MagnitudeBucketGroup {
+	g := &model.MagnitudeBucketGroup{EventType: eventType}
+	if bucket > 0 {
+		g.Min = &edges[bucket-1]
+	}
+	if bucket < len(edges) {
+		g.Max = &edges[bucket]
+	}
+	return g
+}
+
+// defaultUnits maps stored event types to their measurement unit. Entries
+// passed to SetUnits take precedence, so new event types can be added through
+// configuration without touching this table.
+var defaultUnits = map[string]string{
+	"hail":    "in",
+	"wind":    "mph",
+	"tornado": "f_scale",
 }
 
-// unitForEventType returns the measurement unit for a given event type.
+// unitForEventType returns the default measurement unit for a given event type.
 func unitForEventType(et string) string {
-	switch et {
-	case "hail":
-		return "in"
-	case "wind":
-		return "mph"
-	case "tornado":
-		return "f_scale"
-	default:
-		return ""
+	return defaultUnits[et]
+}
+
+// SetUnits overrides or extends the event type to measurement unit table used
+// for aggregation results. Keys are stored (lowercase) event type names.
+func (s *Store) SetUnits(units map[string]string) {
+	s.units = units
+}
+
+// unitFor returns the measurement unit for et, preferring configured units.
+func (s *Store) unitFor(et string) string {
+	if u, ok := s.units[et]; ok {
+		return u
 	}
+	return unitForEventType(et)
 }
 
-// Aggregations returns event type, state, and hourly aggregations in a single query.
-// Uses a CTE with UNION ALL to compute all three aggregation types in one database
-// round-trip. The "agg" discriminator column routes each row to the appropriate
-// result slice during scanning.
-func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilter) (*AggResult, error) {
-	defer s.observeQuery("aggregations", time.Now())
-	where, args, _ := buildWhereClause(filter)
+// SetAggregationSample sets the percentage (0, 100] of storm_reports pages
+// scanned when a filter sets SampleAggregations. The default lives in config
+// (AGGREGATION_SAMPLE_PERCENT); until this is called, aggregations are exact.
+func (s *Store) SetAggregationSample(percent float64) {
+	s.samplePercent = percent
+}
+
+// aggregationSample returns the TABLESAMPLE percentage for filter, or 0 when
+// aggregations should be exact.
+func (s *Store) aggregationSample(filter *model.StormReportFilter) float64 {
+	if filter.SampleAggregations == nil || !*filter.SampleAggregations {
+		return 0
+	}
+	return s.samplePercent
+}
+
+// scaleCount extrapolates a count taken from a sample of fraction of the
+// table. A fraction of 0 means the count is exact.
+func scaleCount(count int, fraction float64) int {
+	if fraction <= 0 {
+		return count
+	}
+	return int(math.Round(float64(count) / fraction))
+}
+
+// Aggregations returns event type, state, time-bucket, severity, day-of-week,
+// and magnitude-range aggregations in a single query. ByHour buckets are
+// truncated to granularity (UTC), so long windows can be summarized per day.
+// Days of the week are local to the filter's hourOfDayRange time zone,
+// defaulting to UTC. Magnitude ranges come from width_bucket over the
+// filter's edges and are counted per event type, since units differ between
+// types. With a near filter each state and county group also carries the
+// distance to its closest report. Uses a CTE with UNION ALL to compute every
+// aggregation type in one database round-trip. The "agg" discriminator column
+// routes each row to the appropriate result slice during scanning. Rows are
+// explicitly ordered so scanning, and therefore the assembled result, doesn't
+// depend on the plan Postgres picks.
+//
+// When the filter sets SampleAggregations the base set is read through
+// TABLESAMPLE SYSTEM, skipping most table pages, and every count is scaled
+// back up by the sample fraction. REPEATABLE keeps the sample stable between
+// identical queries while the table is unchanged. Max magnitudes are taken
+// from the sample as is.
+func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilter, granularity model.TimeGranularity) (_ *AggResult, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "aggregations", time.Now())
+	where, args, idx := buildWhereClause(filter)
 	whereSQL := buildWhereSQL(where)
+	edges := magnitudeBucketEdges(filter)
+	args = append(args, truncUnit(granularity), filterTimeZone(filter), edges)
+
+	next := idx + 3
+
+	distance := "NULL::float8"
+	if filter.Near != nil {
+		distance = haversineDistance(next)
+		args = append(args, filter.Near.Lat, filter.Near.Lon, filter.Near.Lat)
+		next += nearDistanceParams
+	}
+
+	from := "storm_reports"
+	var fraction float64
+	if pct := s.aggregationSample(filter); pct > 0 {
+		from += fmt.Sprintf(" TABLESAMPLE SYSTEM ($%d) REPEATABLE (0)", next)
+		args = append(args, pct)
+		fraction = pct / 100
+	}
 
 	query := `WITH base AS (
 			SELECT event_type, location_state, location_county,
-				   measurement_magnitude, measurement_severity, time_bucket
-			FROM storm_reports` + whereSQL + `
+				   measurement_magnitude, measurement_severity,
+				   ` + fmt.Sprintf("date_trunc($%d, time_bucket, 'UTC')", idx) + ` AS time_bucket,
+				   ` + fmt.Sprintf("EXTRACT(dow FROM event_time AT TIME ZONE $%d)::int", idx+1) + ` AS dow,
+				   ` + distance + ` AS distance
+			FROM ` + from + whereSQL + `
 		)
 		SELECT 'type' AS agg, event_type AS key1, NULL AS key2,
 			   COUNT(*) AS count, MAX(measurement_magnitude) AS max_mag, NULL AS max_sev, NULL::timestamptz AS bucket
 		FROM base GROUP BY event_type
 		UNION ALL
 		SELECT 'state', location_state, location_county,
-			   COUNT(*), NULL, NULL, NULL
+			   COUNT(*), MIN(distance), NULL, NULL
 		FROM base GROUP BY location_state, location_county
 		UNION ALL
 		SELECT 'hour', NULL, NULL,
 			   COUNT(*), NULL, NULL, time_bucket
-		FROM base GROUP BY time_bucket`
+		FROM base GROUP BY time_bucket
+		UNION ALL
+		SELECT 'severity', measurement_severity, NULL,
+			   COUNT(*), NULL, NULL, NULL
+		FROM base GROUP BY measurement_severity
+		UNION ALL
+		SELECT 'dow', dow::text, NULL,
+			   COUNT(*), NULL, NULL, NULL
+		FROM base GROUP BY dow
+		UNION ALL
+		SELECT 'magnitude', event_type, ` + fmt.Sprintf("width_bucket(measurement_magnitude, $%d::float8[])::text", idx+2) + `,
+			   COUNT(*), NULL, NULL, NULL
+		FROM base WHERE measurement_magnitude IS NOT NULL GROUP BY 2, 3
+		ORDER BY agg, key1, key2, bucket`
 
 	rows, err := s.pool.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("aggregations: %w", err)
+		return nil, queryError("aggregations", err)
 	}
 	defer rows.Close()
 
-	result := &AggResult{}
+	result := &AggResult{ByDayOfWeek: newDayOfWeekGroups(), SampleFraction: fraction}
 	stateMap := make(map[string]*model.StateGroup)
 	var stateOrder []string
 
@@ -74,8 +205,9 @@ func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilte
 		var bucket *time.Time
 
 		if err := rows.Scan(&agg, &key1, &key2, &count, &maxMag, &maxSev, &bucket); err != nil {
-			return nil, fmt.Errorf("scan aggregation row: %w", err)
+			return nil, queryError("scan aggregation row", err)
 		}
+		count = scaleCount(count, fraction)
 
 		switch agg {
 		case "type":
@@ -86,7 +218,7 @@ func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilte
 			if maxMag != nil {
 				etg.MaxMeasurement = &model.Measurement{
 					Magnitude: *maxMag,
-					Unit:      unitForEventType(stringOrEmpty(key1)),
+					Unit:      s.unitFor(stringOrEmpty(key1)),
 				}
 			}
 			result.ByEventType = append(result.ByEventType, etg)
@@ -100,10 +232,15 @@ func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilte
 				stateOrder = append(stateOrder, state)
 			}
 			sg.Count += count
+			// max_mag carries the nearest report's distance for state rows.
 			sg.Counties = append(sg.Counties, &model.CountyGroup{
-				County: county,
-				Count:  count,
+				County:       county,
+				Count:        count,
+				NearestMiles: maxMag,
 			})
+			if maxMag != nil && (sg.NearestMiles == nil || *maxMag < *sg.NearestMiles) {
+				sg.NearestMiles = maxMag
+			}
 		case "hour":
 			if bucket != nil {
 				result.ByHour = append(result.ByHour, &model.TimeGroup{
@@ -111,6 +248,21 @@ func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilte
 					Count:  count,
 				})
 			}
+		case "severity":
+			result.BySeverity = append(result.BySeverity, &model.SeverityGroup{
+				Severity: key1,
+				Count:    count,
+			})
+		case "dow":
+			if dow, err := strconv.Atoi(stringOrEmpty(key1)); err == nil && dow >= 0 && dow < len(result.ByDayOfWeek) {
+				result.ByDayOfWeek[dow].Count = count
+			}
+		case "magnitude":
+			if bucket, err := strconv.Atoi(stringOrEmpty(key2)); err == nil && bucket >= 0 && bucket <= len(edges) {
+				g := newMagnitudeBucketGroup(stringOrEmpty(key1), bucket, edges)
+				g.Count = count
+				result.ByMagnitudeBucket = append(result.ByMagnitudeBucket, g)
+			}
 		}
 	}
 	if err := rows.Err(); err != nil {
@@ -120,10 +272,153 @@ func (s *Store) Aggregations(ctx context.Context, filter *model.StormReportFilte
 	for _, st := range stateOrder {
 		result.ByState = append(result.ByState, stateMap[st])
 	}
+	sortAggregations(result)
 
 	return result, nil
 }
 
+// groupColumns whitelists the dimensions groupCount may aggregate by, mapping
+// each to the storm_reports columns that identify a group. County names repeat
+// across states, so a county is keyed by state and county. Only these names
+// are ever interpolated into SQL.
+var groupColumns = map[string][]string{
+	"state":         {"location_state"},
+	"county":        {"location_state", "location_county"},
+	"direction":     {"location_direction"},
+	"event_type":    {"event_type"},
+	"source_office": {"source_office"},
+}
+
+// keyCount is a single group from groupCount. Keys holds one value per
+// grouped column, e.g. state then county.
+type keyCount struct {
+	Keys  []string
+	Count int
+}
+
+// groupCount counts reports matching filter grouped by column, one of the
+// groupColumns names. Groups are ordered by count descending, then key;
+// NULL values are grouped under "". It shares buildWhereClause with the other
+// read queries so new aggregation dimensions only need schema wiring.
+func (s *Store) groupCount(ctx context.Context, filter *model.StormReportFilter, column string) (_ []keyCount, err error) {
+	cols, ok := groupColumns[column]
+	if !ok {
+		return nil, fmt.Errorf("group count: unsupported column %q", column)
+	}
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "group_count", time.Now())
+	where, args, _ := buildWhereClause(filter)
+
+	list := strings.Join(cols, ", ")
+	query := "SELECT " + list + ", COUNT(*) FROM storm_reports" + buildWhereSQL(where) +
+		" GROUP BY " + list + " ORDER BY COUNT(*) DESC, " + list
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, queryError("group count by "+column, err)
+	}
+	defer rows.Close()
+
+	var groups []keyCount
+	keys := make([]*string, len(cols))
+	dest := make([]any, len(cols)+1)
+	for i := range keys {
+		dest[i] = &keys[i]
+	}
+	for rows.Next() {
+		var count int
+		dest[len(cols)] = &count
+		if err := rows.Scan(dest...); err != nil {
+			return nil, queryError("scan group count row", err)
+		}
+		g := keyCount{Keys: make([]string, len(cols)), Count: count}
+		for i, k := range keys {
+			g.Keys[i] = stringOrEmpty(k)
+		}
+		groups = append(groups, g)
+	}
+	return groups, rows.Err()
+}
+
+// newDayOfWeekGroups returns zero-count groups for Sunday through Saturday,
+// so days without reports still appear.
+func newDayOfWeekGroups() []*model.DayOfWeekGroup {
+	groups := make([]*model.DayOfWeekGroup, 7)
+	for d := range groups {
+		groups[d] = &model.DayOfWeekGroup{DayOfWeek: d, Name: time.Weekday(d).String()}
+	}
+	return groups
+}
+
+// truncUnit maps a granularity to its date_trunc unit, defaulting to hourly.
+func truncUnit(g model.TimeGranularity) string {
+	if g == model.TimeGranularityDay {
+		return "day"
+	}
+	return "hour"
+}
+
+// sortAggregations orders event type, state, and county groups by count
+// descending, breaking ties by name, so "top areas" can be rendered directly
+// and identical queries return identical ordering. Hourly buckets are
+// chronological, severities run from minor to extreme, unclassified last, and
+// magnitude ranges run low to high within each event type.
+func sortAggregations(result *AggResult) {
+	slices.SortFunc(result.ByMagnitudeBucket, func(a, b *model.MagnitudeBucketGroup) int {
+		if c := cmp.Compare(a.EventType, b.EventType); c != 0 {
+			return c
+		}
+		return cmp.Compare(magnitudeBucketFloor(a), magnitudeBucketFloor(b))
+	})
+	slices.SortFunc(result.BySeverity, func(a, b *model.SeverityGroup) int {
+		return cmp.Compare(severityRank(a.Severity), severityRank(b.Severity))
+	})
+	slices.SortFunc(result.ByHour, func(a, b *model.TimeGroup) int {
+		return a.Bucket.Compare(b.Bucket)
+	})
+	slices.SortFunc(result.ByEventType, func(a, b *model.EventTypeGroup) int {
+		return byCountThenKey(a.Count, b.Count, a.EventType, b.EventType)
+	})
+	slices.SortFunc(result.ByState, func(a, b *model.StateGroup) int {
+		return byCountThenKey(a.Count, b.Count, a.State, b.State)
+	})
+	for _, sg := range result.ByState {
+		slices.SortFunc(sg.Counties, func(a, b *model.CountyGroup) int {
+			return byCountThenKey(a.Count, b.Count, a.County, b.County)
+		})
+	}
+}
+
+// magnitudeBucketFloor returns g's lower edge, or -Inf for the open range
+// below the first edge.
+func magnitudeBucketFloor(g *model.MagnitudeBucketGroup) float64 {
+	if g.Min == nil {
+		return math.Inf(-1)
+	}
+	return *g.Min
+}
+
+func byCountThenKey(countA, countB int, keyA, keyB string) int {
+	if c := cmp.Compare(countB, countA); c != 0 {
+		return c
+	}
+	return cmp.Compare(keyA, keyB)
+}
+
+// severityRank orders a stored severity by its ordinal, placing unclassified
+// and unrecognised values after every known level.
+func severityRank(sev *string) int {
+	if sev != nil {
+		if o := model.Severity(strings.ToUpper(*sev)).Ordinal(); o > 0 {
+			return o
+		}
+	}
+	return len(model.AllSeverities) + 1
+}
+
 func stringOrEmpty(s *string) string {
 	if s == nil {
 		return ""
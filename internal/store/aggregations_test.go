@@ -1,9 +1,15 @@
 package store
 
 import (
+	"context"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestUnitForEventType(t *testing.T) {
@@ -12,3 +18,189 @@ func TestUnitForEventType(t *testing.T) {
 	assert.Equal(t, "f_scale", unitForEventType("tornado"))
 	assert.Empty(t, unitForEventType("unknown"))
 }
+
+func TestStoreUnitFor(t *testing.T) {
+	s := &Store{}
+	s.SetUnits(map[string]string{"flood": "ft", "hail": "mm"})
+
+	assert.Equal(t, "ft", s.unitFor("flood"))
+	assert.Equal(t, "mm", s.unitFor("hail"), "configured units override defaults")
+	assert.Equal(t, "mph", s.unitFor("wind"), "unconfigured types fall back to defaults")
+	assert.Empty(t, s.unitFor("unknown"))
+}
+
+func TestStoreAggregationSample(t *testing.T) {
+	s := &Store{}
+	yes, no := true, false
+
+	assert.Zero(t, s.aggregationSample(&model.StormReportFilter{}), "exact by default")
+	assert.Zero(t, s.aggregationSample(&model.StormReportFilter{SampleAggregations: &no}))
+	assert.Zero(t, s.aggregationSample(&model.StormReportFilter{SampleAggregations: &yes}), "exact until configured")
+
+	s.SetAggregationSample(2.5)
+	assert.InDelta(t, 2.5, s.aggregationSample(&model.StormReportFilter{SampleAggregations: &yes}), 0)
+}
+
+func TestScaleCount(t *testing.T) {
+	assert.Equal(t, 7, scaleCount(7, 0), "exact counts are unchanged")
+	assert.Equal(t, 70, scaleCount(7, 0.1))
+	assert.Equal(t, 3, scaleCount(2, 0.75), "rounded to the nearest report")
+	assert.Equal(t, 7, scaleCount(7, 1))
+}
+
+func TestGroupCount_Whitelist(t *testing.T) {
+	s := New(nil, observability.NewTestMetrics())
+	s.SetQueryBreaker(1, time.Minute)
+	s.breaker.record(false, assert.AnError)
+
+	// Whitelisted columns get as far as the (open) breaker; anything else is
+	// rejected before any SQL is built.
+	for column := range groupColumns {
+		_, err := s.groupCount(context.Background(), &model.StormReportFilter{}, column)
+		require.ErrorIs(t, err, ErrUnavailable, column)
+	}
+	for _, column := range []string{"location_state", "comments", "state; DROP TABLE storm_reports", ""} {
+		_, err := s.groupCount(context.Background(), &model.StormReportFilter{}, column)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported column", column)
+	}
+}
+
+func TestNewDayOfWeekGroups(t *testing.T) {
+	groups := newDayOfWeekGroups()
+	assert.Len(t, groups, 7)
+	for d, g := range groups {
+		assert.Equal(t, d, g.DayOfWeek)
+		assert.Zero(t, g.Count)
+	}
+	assert.Equal(t, "Sunday", groups[0].Name)
+	assert.Equal(t, "Saturday", groups[6].Name)
+}
+
+func TestNewMagnitudeBucketGroup(t *testing.T) {
+	edges := []float64{1, 2, 3}
+
+	below := newMagnitudeBucketGroup("hail", 0, edges)
+	assert.Equal(t, "hail", below.EventType)
+	assert.Nil(t, below.Min)
+	assert.InDelta(t, 1, *below.Max, 0)
+
+	middle := newMagnitudeBucketGroup("hail", 2, edges)
+	assert.InDelta(t, 2, *middle.Min, 0)
+	assert.InDelta(t, 3, *middle.Max, 0)
+
+	top := newMagnitudeBucketGroup("hail", 3, edges)
+	assert.InDelta(t, 3, *top.Min, 0)
+	assert.Nil(t, top.Max)
+}
+
+func TestMagnitudeBucketEdges(t *testing.T) {
+	assert.Equal(t, defaultMagnitudeBucketEdges, magnitudeBucketEdges(&model.StormReportFilter{}))
+	edges := []float64{0.5, 1.75}
+	assert.Equal(t, edges, magnitudeBucketEdges(&model.StormReportFilter{MagnitudeBucketEdges: edges}))
+}
+
+func TestFilterTimeZone(t *testing.T) {
+	tz := "America/Chicago"
+	assert.Equal(t, "UTC", filterTimeZone(&model.StormReportFilter{}))
+	assert.Equal(t, "UTC", filterTimeZone(&model.StormReportFilter{HourOfDayRange: &model.HourOfDayRange{}}))
+	assert.Equal(t, tz, filterTimeZone(&model.StormReportFilter{HourOfDayRange: &model.HourOfDayRange{TimeZone: &tz}}))
+}
+
+func TestSortAggregations(t *testing.T) {
+	result := &AggResult{
+		ByEventType: []*model.EventTypeGroup{
+			{EventType: "wind", Count: 5},
+			{EventType: "tornado", Count: 9},
+			{EventType: "hail", Count: 5},
+		},
+		ByState: []*model.StateGroup{
+			{State: "OK", Count: 3, Counties: []*model.CountyGroup{
+				{County: "Tulsa", Count: 1},
+				{County: "Creek", Count: 1},
+				{County: "Osage", Count: 1},
+			}},
+			{State: "TX", Count: 7, Counties: []*model.CountyGroup{
+				{County: "Tarrant", Count: 2},
+				{County: "Dallas", Count: 5},
+			}},
+			{State: "KS", Count: 3},
+		},
+	}
+
+	sortAggregations(result)
+
+	var types []string
+	for _, g := range result.ByEventType {
+		types = append(types, g.EventType)
+	}
+	assert.Equal(t, []string{"tornado", "hail", "wind"}, types)
+
+	var states []string
+	for _, g := range result.ByState {
+		states = append(states, g.State)
+	}
+	assert.Equal(t, []string{"TX", "KS", "OK"}, states)
+
+	assert.Equal(t, "Dallas", result.ByState[0].Counties[0].County)
+	var okCounties []string
+	for _, c := range result.ByState[2].Counties {
+		okCounties = append(okCounties, c.County)
+	}
+	assert.Equal(t, []string{"Creek", "Osage", "Tulsa"}, okCounties)
+}
+
+func TestSortAggregations_HoursChronological(t *testing.T) {
+	t0 := time.Date(2024, 4, 26, 18, 0, 0, 0, time.UTC)
+	result := &AggResult{ByHour: []*model.TimeGroup{
+		{Bucket: t0.Add(2 * time.Hour), Count: 1},
+		{Bucket: t0, Count: 9},
+		{Bucket: t0.Add(time.Hour), Count: 4},
+	}}
+
+	sortAggregations(result)
+
+	for i, want := range []time.Time{t0, t0.Add(time.Hour), t0.Add(2 * time.Hour)} {
+		assert.Equal(t, want, result.ByHour[i].Bucket)
+	}
+}
+
+func TestSortAggregations_SeverityByRank(t *testing.T) {
+	sev := func(s string) *string { return &s }
+	result := &AggResult{BySeverity: []*model.SeverityGroup{
+		{Severity: nil, Count: 30},
+		{Severity: sev("severe"), Count: 5},
+		{Severity: sev("minor"), Count: 2},
+		{Severity: sev("extreme"), Count: 1},
+	}}
+
+	sortAggregations(result)
+
+	var got []string
+	for _, g := range result.BySeverity {
+		got = append(got, stringOrEmpty(g.Severity))
+	}
+	assert.Equal(t, []string{"minor", "severe", "extreme", ""}, got)
+}
+
+func TestSortAggregations_MagnitudeBucketsByTypeThenRange(t *testing.T) {
+	edges := []float64{1, 2}
+	result := &AggResult{ByMagnitudeBucket: []*model.MagnitudeBucketGroup{
+		newMagnitudeBucketGroup("wind", 2, edges),
+		newMagnitudeBucketGroup("hail", 1, edges),
+		newMagnitudeBucketGroup("hail", 2, edges),
+		newMagnitudeBucketGroup("hail", 0, edges),
+	}}
+
+	sortAggregations(result)
+
+	var got []string
+	for _, g := range result.ByMagnitudeBucket {
+		floor := "-"
+		if g.Min != nil {
+			floor = strconv.FormatFloat(*g.Min, 'g', -1, 64)
+		}
+		got = append(got, g.EventType+":"+floor)
+	}
+	assert.Equal(t, []string{"hail:-", "hail:1", "hail:2", "wind:2"}, got)
+}
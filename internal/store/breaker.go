@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is returned by query methods while the circuit breaker is
+// open, instead of sending another query to an overloaded database.
+var ErrUnavailable = errors.New("storm data temporarily unavailable; retry shortly")
+
+// BreakerState is the state of a circuit breaker, exported as the value of
+// the db_circuit_breaker_state gauge.
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // queries flow normally
+	BreakerHalfOpen                     // one probe query is allowed through
+	BreakerOpen                         // queries fail fast with ErrUnavailable
+)
+
+// breaker trips after threshold consecutive query failures and rejects
+// queries for cooldown. After the cooldown a single probe is let through:
+// success closes the breaker, failure reopens it for another cooldown. Only
+// the probe moves the breaker out of open or half-open; queries that started
+// before it tripped may still finish, and their results are ignored. A nil
+// *breaker allows everything.
+type breaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+	onChange  func(BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func newBreaker(threshold int, cooldown time.Duration, onChange func(BreakerState)) *breaker {
+	return &breaker{threshold: threshold, cooldown: cooldown, now: time.Now, onChange: onChange}
+}
+
+// allow returns ErrUnavailable if the query should not be attempted, and
+// whether the query is the half-open probe. The caller passes probe back to
+// record.
+func (b *breaker) allow() (probe bool, err error) {
+	if b == nil {
+		return false, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen {
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false, ErrUnavailable
+		}
+		b.setState(BreakerHalfOpen)
+	}
+	if b.state == BreakerHalfOpen {
+		if b.probing {
+			return false, ErrUnavailable
+		}
+		b.probing = true
+		return true, nil
+	}
+	return false, nil
+}
+
+// record reports the outcome of a query that allow let through.
+func (b *breaker) record(probe bool, err error) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if probe {
+		b.probing = false
+	} else if b.state != BreakerClosed {
+		// Started before the breaker tripped; only the probe decides.
+		return
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		// The caller gave up, which says nothing about the database. A
+		// cancelled probe frees the slot for the next one.
+	case err == nil:
+		b.failures = 0
+		b.setState(BreakerClosed)
+	default:
+		b.failures++
+		if probe || b.failures >= b.threshold {
+			b.openedAt = b.now()
+			b.setState(BreakerOpen)
+		}
+	}
+}
+
+func (b *breaker) setState(s BreakerState) {
+	if b.state == s {
+		return
+	}
+	b.state = s
+	if b.onChange != nil {
+		b.onChange(s)
+	}
+}
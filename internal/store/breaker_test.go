@@ -0,0 +1,124 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Now()
+	var states []BreakerState
+	b := newBreaker(3, 30*time.Second, func(s BreakerState) { states = append(states, s) })
+	b.now = func() time.Time { return now }
+	dbErr := errors.New("statement timeout")
+	allow := func() bool {
+		t.Helper()
+		probe, err := b.allow()
+		require.NoError(t, err)
+		return probe
+	}
+	rejected := func(msg string) {
+		t.Helper()
+		_, err := b.allow()
+		assert.ErrorIs(t, err, ErrUnavailable, msg)
+	}
+
+	// Failures below the threshold, or interrupted by a success, keep it closed.
+	for range 2 {
+		b.record(allow(), dbErr)
+	}
+	b.record(allow(), nil)
+	for range 2 {
+		b.record(allow(), dbErr)
+	}
+	assert.False(t, allow(), "no probe while closed")
+
+	// Client cancellations don't count.
+	b.record(false, context.Canceled)
+	b.record(allow(), dbErr)
+	rejected("third consecutive failure trips the breaker")
+
+	// After the cooldown a single probe is let through; a failed probe reopens.
+	now = now.Add(30 * time.Second)
+	require.True(t, allow())
+	rejected("only one probe at a time")
+	b.record(true, dbErr)
+	rejected("failed probe reopens")
+
+	// A successful probe closes it again.
+	now = now.Add(30 * time.Second)
+	b.record(allow(), nil)
+	assert.False(t, allow())
+	assert.False(t, allow())
+
+	assert.Equal(t, []BreakerState{BreakerOpen, BreakerHalfOpen, BreakerOpen, BreakerHalfOpen, BreakerClosed}, states)
+}
+
+func TestBreaker_OnlyProbeLeavesOpen(t *testing.T) {
+	now := time.Now()
+	b := newBreaker(1, 30*time.Second, nil)
+	b.now = func() time.Time { return now }
+
+	// A slow query starts while closed, then another trips the breaker.
+	slow, err := b.allow()
+	require.NoError(t, err)
+	probe, err := b.allow()
+	require.NoError(t, err)
+	b.record(probe, errors.New("connection refused"))
+	require.Equal(t, BreakerOpen, b.state)
+
+	// The slow query's success doesn't close it.
+	b.record(slow, nil)
+	assert.Equal(t, BreakerOpen, b.state)
+
+	// Nor does it count once the breaker is half-open, or clear the probe.
+	now = now.Add(30 * time.Second)
+	probe, err = b.allow()
+	require.NoError(t, err)
+	require.True(t, probe)
+	b.record(false, nil)
+	assert.Equal(t, BreakerHalfOpen, b.state)
+	_, err = b.allow()
+	require.ErrorIs(t, err, ErrUnavailable, "the probe is still in flight")
+
+	b.record(probe, nil)
+	assert.Equal(t, BreakerClosed, b.state)
+}
+
+func TestBreaker_Nil(t *testing.T) {
+	var b *breaker
+	probe, err := b.allow()
+	require.NoError(t, err)
+	assert.False(t, probe)
+	b.record(probe, errors.New("ignored"))
+}
+
+func TestStore_QueryBreakerFailsFast(t *testing.T) {
+	m := observability.NewTestMetrics()
+	s := New(nil, m)
+	s.SetQueryBreaker(1, time.Minute)
+	probe, err := s.breaker.allow()
+	require.NoError(t, err)
+	s.breaker.record(probe, errors.New("connection refused"))
+	assert.InDelta(t, float64(BreakerOpen), testutil.ToFloat64(m.DBCircuitBreakerState.WithLabelValues("query")), 0)
+
+	// An open breaker never reaches the (nil) pool.
+	_, _, err = s.ListStormReports(context.Background(), &model.StormReportFilter{})
+	require.ErrorIs(t, err, ErrUnavailable)
+	_, err = s.Aggregations(context.Background(), &model.StormReportFilter{}, model.TimeGranularityHour)
+	require.ErrorIs(t, err, ErrUnavailable)
+	_, err = s.Extent(context.Background(), &model.StormReportFilter{})
+	require.ErrorIs(t, err, ErrUnavailable)
+	_, err = s.LastUpdated(context.Background())
+	require.ErrorIs(t, err, ErrUnavailable)
+	_, err = s.EventTimeExtent(context.Background())
+	require.ErrorIs(t, err, ErrUnavailable)
+}
@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Sentinel errors returned, wrapped, by Store methods so callers can branch
+// with errors.Is instead of matching messages. The underlying pgx error stays
+// in the chain.
+var (
+	// ErrTimeout means a query ran past its context deadline or was
+	// cancelled by Postgres' statement_timeout. Retrying may succeed.
+	ErrTimeout = errors.New("query timed out")
+	// ErrConstraint means a write violated a table constraint. Retrying the
+	// same data will fail again.
+	ErrConstraint = errors.New("constraint violation")
+	// ErrLockConflict means a write lost a race with a concurrent
+	// transaction: a deadlock, serialization failure or lock wait that gave
+	// up. Retrying usually succeeds.
+	ErrLockConflict = errors.New("lock conflict")
+)
+
+// pgIntegrityConstraintClass is the SQLSTATE class for integrity constraint
+// violations (not null, foreign key, unique, check, exclusion).
+const pgIntegrityConstraintClass = "23"
+
+// SQLSTATEs for transient conflicts between concurrent transactions.
+const (
+	pgSerializationFailure = "40001"
+	pgDeadlockDetected     = "40P01"
+	pgLockNotAvailable     = "55P03"
+)
+
+// classify returns the sentinel describing err, or nil if none applies. Row
+// lookups report a missing row as a nil result, not an error, so there is no
+// not-found sentinel.
+func classify(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) || IsStatementTimeout(err) {
+		return ErrTimeout
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(pgErr.Code, pgIntegrityConstraintClass):
+		return ErrConstraint
+	case pgErr.Code == pgSerializationFailure, pgErr.Code == pgDeadlockDetected, pgErr.Code == pgLockNotAvailable:
+		return ErrLockConflict
+	}
+	return nil
+}
+
+// queryError wraps err with the operation that failed and, when classify
+// recognises it, the matching sentinel.
+func queryError(op string, err error) error {
+	if sentinel := classify(err); sentinel != nil {
+		return fmt.Errorf("%s: %w: %w", op, sentinel, err)
+	}
+	return fmt.Errorf("%s: %w", op, err)
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryError_Classifies(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"context deadline", fmt.Errorf("timeout: %w", context.DeadlineExceeded), ErrTimeout},
+		{"statement timeout", &pgconn.PgError{Code: pgQueryCanceled, Message: "canceling statement due to statement timeout"}, ErrTimeout},
+		{"not null violation", &pgconn.PgError{Code: "23502"}, ErrConstraint},
+		{"unique violation", &pgconn.PgError{Code: "23505"}, ErrConstraint},
+		{"deadlock", &pgconn.PgError{Code: pgDeadlockDetected}, ErrLockConflict},
+		{"serialization failure", &pgconn.PgError{Code: pgSerializationFailure}, ErrLockConflict},
+		{"lock timeout", &pgconn.PgError{Code: pgLockNotAvailable}, ErrLockConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := queryError("list", tt.err)
+			assert.ErrorIs(t, err, tt.want)
+			assert.ErrorIs(t, err, tt.err, "the original error stays in the chain")
+			assert.Contains(t, err.Error(), "list: "+tt.want.Error())
+		})
+	}
+}
+
+func TestQueryError_Unclassified(t *testing.T) {
+	cause := &pgconn.PgError{Code: "42P01"} // undefined_table
+	err := queryError("list", cause)
+	assert.ErrorIs(t, err, cause)
+	for _, sentinel := range []error{ErrTimeout, ErrConstraint, ErrLockConflict} {
+		assert.NotErrorIs(t, err, sentinel)
+	}
+
+	err = queryError("list", context.Canceled)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.NotErrorIs(t, err, ErrTimeout, "a cancelled caller is not a timeout")
+
+	// pgx cancels the statement when the caller's context ends, which
+	// Postgres reports with the same SQLSTATE as statement_timeout.
+	userCancel := &pgconn.PgError{Code: pgQueryCanceled, Message: "canceling statement due to user request"}
+	assert.NotErrorIs(t, queryError("list", userCancel), ErrTimeout)
+}
+
+func TestRetryLockConflicts(t *testing.T) {
+	deadlock := queryError("batch insert", &pgconn.PgError{Code: pgDeadlockDetected})
+
+	t.Run("succeeds after conflicts", func(t *testing.T) {
+		m := observability.NewTestMetrics()
+		s := New(nil, m)
+		calls := 0
+		err := s.retryLockConflicts(context.Background(), "batch_insert", func() error {
+			calls++
+			if calls < 3 {
+				return deadlock
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.InDelta(t, 2, testutil.ToFloat64(m.DBLockConflicts.WithLabelValues("batch_insert")), 0)
+	})
+
+	t.Run("gives up after the retry budget", func(t *testing.T) {
+		s := New(nil, observability.NewTestMetrics())
+		calls := 0
+		err := s.retryLockConflicts(context.Background(), "batch_insert", func() error {
+			calls++
+			return deadlock
+		})
+		require.ErrorIs(t, err, ErrLockConflict)
+		assert.Equal(t, lockConflictRetries+1, calls)
+	})
+
+	t.Run("other errors are not retried", func(t *testing.T) {
+		s := New(nil, observability.NewTestMetrics())
+		calls := 0
+		err := s.retryLockConflicts(context.Background(), "batch_insert", func() error {
+			calls++
+			return queryError("batch insert", &pgconn.PgError{Code: "23502"})
+		})
+		require.ErrorIs(t, err, ErrConstraint)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("stops when the context ends", func(t *testing.T) {
+		s := New(nil, observability.NewTestMetrics())
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		calls := 0
+		err := s.retryLockConflicts(ctx, "batch_insert", func() error {
+			calls++
+			return deadlock
+		})
+		require.ErrorIs(t, err, ErrLockConflict)
+		assert.Equal(t, 1, calls)
+	})
+}
@@ -0,0 +1,54 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// maxPlaceCandidates caps how many states PlaceCandidates returns for a name
+// shared by several places.
+const maxPlaceCandidates = 10
+
+// Place is a named location resolved from stored reports.
+type Place struct {
+	Name  string
+	State string
+	Lat   float64
+	Lon   float64
+}
+
+// PlaceCandidates resolves a place name to coordinates from reports already
+// stored for it, so clients can search by name without an external geocoder.
+// Names match location_name case-insensitively; a non-empty state restricts
+// the search to that state. One candidate is returned per state, ordered by
+// state, carrying the coordinates of the most recent report naming the place.
+// More than one candidate means the name is ambiguous.
+func (s *Store) PlaceCandidates(ctx context.Context, name, state string) (_ []Place, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "place_candidates", time.Now())
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT DISTINCT ON (location_state) location_name, location_state, geo_lat, geo_lon
+		FROM storm_reports
+		WHERE lower(location_name) = lower($1) AND ($2 = '' OR location_state = $2)
+		ORDER BY location_state, event_time DESC
+		LIMIT $3`, name, state, maxPlaceCandidates)
+	if err != nil {
+		return nil, queryError("place candidates", err)
+	}
+	defer rows.Close()
+
+	var places []Place
+	for rows.Next() {
+		var p Place
+		if err := rows.Scan(&p.Name, &p.State, &p.Lat, &p.Lon); err != nil {
+			return nil, queryError("scan place candidate", err)
+		}
+		places = append(places, p)
+	}
+	return places, rows.Err()
+}
@@ -0,0 +1,116 @@
+package store
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/jackc/pgx/v5"
+)
+
+// reportColumn maps a storm_reports column to the StormReport field it scans
+// into and the GraphQL StormReport field that needs it.
+type reportColumn struct {
+	name   string
+	field  string
+	target func(r *model.StormReport) any
+}
+
+// reportColumns lists every storm_reports column in table order. It is the
+// single source for both the column lists in SQL and the scan targets, so
+// the two cannot drift apart. Nested
+// GraphQL types (geo, measurement, location) are selected as a whole, since
+// their field resolvers may read any of the type's columns (parsedLocation
+// falls back to raw when distance and direction are missing).
+var reportColumns = []reportColumn{
+	{"id", "id", func(r *model.StormReport) any { return &r.ID }},
+	{"event_type", "eventType", func(r *model.StormReport) any { return &r.EventType }},
+	{"geo_lat", "geo", func(r *model.StormReport) any { return &r.Geo.Lat }},
+	{"geo_lon", "geo", func(r *model.StormReport) any { return &r.Geo.Lon }},
+	{"measurement_magnitude", "measurement", func(r *model.StormReport) any { return &r.Measurement.Magnitude }},
+	{"measurement_unit", "measurement", func(r *model.StormReport) any { return &r.Measurement.Unit }},
+	{"event_time", "eventTime", func(r *model.StormReport) any { return &r.EventTime }},
+	{"location_raw", "location", func(r *model.StormReport) any { return &r.Location.Raw }},
+	{"location_name", "location", func(r *model.StormReport) any { return &r.Location.Name }},
+	{"location_distance", "location", func(r *model.StormReport) any { return &r.Location.Distance }},
+	{"location_direction", "location", func(r *model.StormReport) any { return &r.Location.Direction }},
+	{"location_state", "location", func(r *model.StormReport) any { return &r.Location.State }},
+	{"location_county", "location", func(r *model.StormReport) any { return &r.Location.County }},
+	{"comments", "comments", func(r *model.StormReport) any { return &r.Comments }},
+	{"measurement_severity", "measurement", func(r *model.StormReport) any { return &r.Measurement.Severity }},
+	{"source_office", "sourceOffice", func(r *model.StormReport) any { return &r.SourceOffice }},
+	{"time_bucket", "timeBucket", func(r *model.StormReport) any { return &r.TimeBucket }},
+	{"processed_at", "processedAt", func(r *model.StormReport) any { return &r.ProcessedAt }},
+	{"pipeline", "pipeline", func(r *model.StormReport) any { return &r.Pipeline }},
+}
+
+// projection is the subset of reportColumns a query selects.
+type projection []reportColumn
+
+// allColumns selects every column; it is the default whenever the caller's
+// field selection is unknown.
+var allColumns = projection(reportColumns)
+
+// columns is the full column list, in the order InsertStormReport binds its
+// arguments.
+var columns = allColumns.sql()
+
+// projectReportFields returns the columns backing the given GraphQL
+// StormReport field names. id is always selected so every row stays
+// identifiable; with no fields at all, every column is selected.
+func projectReportFields(fields []string) projection {
+	if len(fields) == 0 {
+		return allColumns
+	}
+	want := map[string]bool{"id": true}
+	for _, f := range fields {
+		want[f] = true
+	}
+	var p projection
+	for _, c := range reportColumns {
+		if want[c.field] {
+			p = append(p, c)
+		}
+	}
+	return p
+}
+
+// sql returns the comma-separated column list for a SELECT.
+func (p projection) sql() string {
+	names := make([]string, len(p))
+	for i, c := range p {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// scan reads one row selected with p.sql() into a new report. Columns outside
+// the projection are left at their zero values.
+func (p projection) scan(row scannable) (*model.StormReport, error) {
+	var r model.StormReport
+	dest := make([]any, len(p))
+	for i, c := range p {
+		dest[i] = c.target(&r)
+	}
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+type scannable interface {
+	Scan(dest ...any) error
+}
+
+// scanStormReport reads a row selected with every column, returning nil when
+// there is no row.
+func scanStormReport(row scannable) (*model.StormReport, error) {
+	r, err := allColumns.scan(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, queryError("scan storm report", err)
+	}
+	return r, nil
+}
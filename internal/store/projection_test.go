@@ -0,0 +1,104 @@
+package store
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumns_MatchInsertPlaceholders(t *testing.T) {
+	names := strings.Split(columns, ", ")
+	assert.Len(t, names, strings.Count(insertSQL, "$"), "one column per insert argument")
+
+	seen := make(map[string]bool)
+	for _, n := range names {
+		assert.False(t, seen[n], "duplicate column %s", n)
+		seen[n] = true
+	}
+}
+
+// fakeRow fills scan targets with fixed values, standing in for pgx rows.
+type fakeRow struct{}
+
+func (fakeRow) Scan(dest ...any) error {
+	for _, d := range dest {
+		switch p := d.(type) {
+		case *string:
+			*p = "value"
+		case **string:
+			v := "value"
+			*p = &v
+		case *float64:
+			*p = 1.5
+		case **float64:
+			v := 1.5
+			*p = &v
+		case *time.Time:
+			*p = time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC)
+		default:
+			return fmt.Errorf("unexpected scan target %T", d)
+		}
+	}
+	return nil
+}
+
+func TestScanStormReport_FillsEveryField(t *testing.T) {
+	r, err := scanStormReport(fakeRow{})
+	require.NoError(t, err)
+	assert.Equal(t, "value", r.ID)
+	assert.Equal(t, "value", r.Location.County)
+	require.NotNil(t, r.Location.Distance)
+	require.NotNil(t, r.Measurement.Severity)
+	assert.False(t, r.ProcessedAt.IsZero())
+}
+
+func TestProjectionScan_LeavesUnselectedZero(t *testing.T) {
+	r, err := projectReportFields([]string{"eventTime"}).scan(fakeRow{})
+	require.NoError(t, err)
+	assert.Equal(t, "value", r.ID)
+	assert.False(t, r.EventTime.IsZero())
+	assert.Empty(t, r.Comments)
+	assert.Nil(t, r.Location.Distance)
+}
+
+func BenchmarkScan_AllColumns(b *testing.B) {
+	for b.Loop() {
+		if _, err := allColumns.scan(fakeRow{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScan_IDOnly(b *testing.B) {
+	p := projectReportFields([]string{"id"})
+	for b.Loop() {
+		if _, err := p.scan(fakeRow{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestProjectReportFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{"no fields selects everything", nil, columns},
+		{"id is always selected", []string{"eventTime"}, "id, event_time"},
+		{"nested types select all their columns", []string{"id", "geo", "measurement"},
+			"id, geo_lat, geo_lon, measurement_magnitude, measurement_unit, measurement_severity"},
+		{"location", []string{"location"},
+			"id, location_raw, location_name, location_distance, location_direction, location_state, location_county"},
+		{"unknown fields are ignored", []string{"__typename"}, "id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, projectReportFields(tt.fields).sql())
+		})
+	}
+}
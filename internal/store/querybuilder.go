@@ -3,6 +3,7 @@ package store
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/couchcryptid/storm-data-api/internal/model"
@@ -15,8 +16,33 @@ const (
 	// milesPerDegreeLat approximates the miles-per-degree latitude (~69 mi).
 	// Used by bounding-box pre-filtering for B-tree index utilization.
 	milesPerDegreeLat = 69.0
+
+	// typeConditionSize is roughly the longest single event type condition:
+	// type, severity, magnitude and haversine predicates.
+	typeConditionSize = 320
+
+	// queryExtraParams is the most parameters a query adds after the
+	// filter's WHERE clause: the aggregation bucket unit, time zone,
+	// magnitude bucket edges and sample percentage.
+	queryExtraParams = 4
+
+	// nearDistanceParams is the near center the aggregation query binds
+	// again to measure each group's nearest report.
+	nearDistanceParams = 3
 )
 
+// QueryParamCount returns how many positional parameters the largest query
+// built from filter will bind. PostgreSQL rejects statements with more than
+// 65535, so callers can turn away oversized filters before they reach pgx.
+func QueryParamCount(filter *model.StormReportFilter) int {
+	_, args, _ := buildWhereClause(filter)
+	n := len(args) + queryExtraParams
+	if filter.Near != nil {
+		n += nearDistanceParams
+	}
+	return n
+}
+
 // buildWhereSQL joins the clauses into a WHERE fragment (empty string if no clauses).
 func buildWhereSQL(clauses []string) string {
 	if len(clauses) == 0 {
@@ -25,34 +51,132 @@ func buildWhereSQL(clauses []string) string {
 	return " WHERE " + strings.Join(clauses, " AND ")
 }
 
+// whereCapacity returns upper bounds on the clauses and args
+// buildWhereClause produces for filter, so both are allocated once.
+func whereCapacity(filter *model.StormReportFilter) (clauses, args int) {
+	clauses, args = 2, 2 // time bounds
+	if filter.HourOfDayRange != nil {
+		clauses, args = clauses+1, args+3
+	}
+	for _, set := range []bool{
+		filter.IngestedWithinMinutes != nil,
+		filter.IDPrefix != nil,
+		len(filter.States) > 0,
+		len(filter.Counties) > 0,
+		len(filter.ExcludeStates) > 0,
+		len(filter.ExcludeCounties) > 0,
+		len(filter.Pipelines) > 0,
+		filter.MinSeverity != nil,
+	} {
+		if set {
+			clauses, args = clauses+1, args+1
+		}
+	}
+	if len(filter.ImpactKeywords) > 0 {
+		clauses, args = clauses+1, args+len(filter.ImpactKeywords)
+	}
+	if len(filter.EventTypeFilters) > 0 {
+		// Bounding box plus one OR clause; each type binds at most its type,
+		// severity, magnitude and haversine args.
+		conditions := len(filter.EventTypeFilters) + len(filter.EventTypes)
+		return clauses + 2, args + 4 + conditions*7
+	}
+	// Event types, severity, magnitude, bounding box and haversine.
+	return clauses + 5, args + 11
+}
+
 // buildWhereClause constructs the WHERE clause and args from a filter.
 // Returns the clauses, args, and the next parameter index.
 // idx tracks the PostgreSQL positional parameter number ($1, $2, …).
 func buildWhereClause(filter *model.StormReportFilter) ([]string, []any, int) {
-	var where []string
-	var args []any
+	clauseCap, argCap := whereCapacity(filter)
+	where := make([]string, 0, clauseCap)
+	args := make([]any, 0, argCap)
 	idx := 1
 
 	// Time bounds (always present — required by schema)
-	where = append(where, fmt.Sprintf("event_time >= $%d", idx))
+	where = append(where, "event_time >= $"+strconv.Itoa(idx))
 	args = append(args, filter.TimeRange.From)
 	idx++
 
-	where = append(where, fmt.Sprintf("event_time <= $%d", idx))
+	where = append(where, "event_time <= $"+strconv.Itoa(idx))
 	args = append(args, filter.TimeRange.To)
 	idx++
 
+	if filter.HourOfDayRange != nil {
+		clause, hourArgs, hourIdx := buildHourOfDayClause(filter.HourOfDayRange, idx)
+		where = append(where, clause)
+		args = append(args, hourArgs...)
+		idx = hourIdx
+	}
+
+	if filter.IngestedWithinMinutes != nil {
+		where = append(where, "processed_at > NOW() - make_interval(mins => $"+strconv.Itoa(idx)+")")
+		args = append(args, *filter.IngestedWithinMinutes)
+		idx++
+	}
+
+	if filter.IDPrefix != nil {
+		where = append(where, "id LIKE $"+strconv.Itoa(idx)+" || '%'")
+		args = append(args, escapeLike(*filter.IDPrefix))
+		idx++
+	}
+
 	// Administrative location filters
 	if len(filter.States) > 0 {
-		where = append(where, fmt.Sprintf("location_state = ANY($%d)", idx))
+		where = append(where, "location_state = ANY($"+strconv.Itoa(idx)+")")
 		args = append(args, filter.States)
 		idx++
 	}
 	if len(filter.Counties) > 0 {
-		where = append(where, fmt.Sprintf("location_county = ANY($%d)", idx))
+		where = append(where, "location_county = ANY($"+strconv.Itoa(idx)+")")
 		args = append(args, filter.Counties)
 		idx++
 	}
+	if len(filter.ExcludeStates) > 0 {
+		where = append(where, "location_state <> ALL($"+strconv.Itoa(idx)+")")
+		args = append(args, filter.ExcludeStates)
+		idx++
+	}
+	if len(filter.ExcludeCounties) > 0 {
+		where = append(where, "location_county <> ALL($"+strconv.Itoa(idx)+")")
+		args = append(args, filter.ExcludeCounties)
+		idx++
+	}
+
+	if len(filter.Pipelines) > 0 {
+		where = append(where, "pipeline = ANY($"+strconv.Itoa(idx)+")")
+		args = append(args, filter.Pipelines)
+		idx++
+	}
+
+	// Impact keywords: one ILIKE per keyword rather than ILIKE ANY, which the
+	// trigram index can't serve
+	if len(filter.ImpactKeywords) > 0 {
+		const match = "comments ILIKE '%' || $ || '%'"
+		var b strings.Builder
+		b.Grow(2 + len(filter.ImpactKeywords)*(len(match)+len(" OR ")+5))
+		b.WriteByte('(')
+		for i, keyword := range filter.ImpactKeywords {
+			if i > 0 {
+				b.WriteString(" OR ")
+			}
+			b.WriteString("comments ILIKE '%' || $")
+			b.WriteString(strconv.Itoa(idx))
+			b.WriteString(" || '%'")
+			args = append(args, escapeLike(keyword))
+			idx++
+		}
+		b.WriteByte(')')
+		where = append(where, b.String())
+	}
+
+	// Severity floor applies across event types in both filtering modes
+	if filter.MinSeverity != nil {
+		where = append(where, "severity_ordinal >= $"+strconv.Itoa(idx))
+		args = append(args, filter.MinSeverity.Ordinal())
+		idx++
+	}
 
 	if len(filter.EventTypeFilters) > 0 {
 		// Per-type OR filtering: each event type can have its own severity/magnitude/radius
@@ -63,17 +187,17 @@ func buildWhereClause(filter *model.StormReportFilter) ([]string, []any, int) {
 	} else {
 		// Simple AND filtering: global filters apply uniformly to all event types
 		if len(filter.EventTypes) > 0 {
-			where = append(where, fmt.Sprintf("event_type = ANY($%d)", idx))
+			where = append(where, "event_type = ANY($"+strconv.Itoa(idx)+")")
 			args = append(args, eventTypeDBValues(filter.EventTypes))
 			idx++
 		}
 		if len(filter.Severity) > 0 {
-			where = append(where, fmt.Sprintf("measurement_severity = ANY($%d)", idx))
+			where = append(where, "measurement_severity = ANY($"+strconv.Itoa(idx)+")")
 			args = append(args, severityDBValues(filter.Severity))
 			idx++
 		}
 		if filter.MinMagnitude != nil {
-			where = append(where, fmt.Sprintf("measurement_magnitude >= $%d", idx))
+			where = append(where, "measurement_magnitude >= $"+strconv.Itoa(idx))
 			args = append(args, *filter.MinMagnitude)
 			idx++
 		}
@@ -88,6 +212,42 @@ func buildWhereClause(filter *model.StormReportFilter) ([]string, []any, int) {
 	return where, args, idx
 }
 
+// likeEscaper escapes LIKE wildcards so a pattern matches literally, using
+// Postgres's default escape character.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike returns s with LIKE wildcards escaped.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// filterTimeZone returns the time zone for local-time groupings: the
+// hourOfDayRange zone when one is given, otherwise UTC.
+func filterTimeZone(filter *model.StormReportFilter) string {
+	if filter.HourOfDayRange != nil && filter.HourOfDayRange.TimeZone != nil {
+		return *filter.HourOfDayRange.TimeZone
+	}
+	return "UTC"
+}
+
+// buildHourOfDayClause restricts the local hour of event_time to an inclusive
+// range. A range with From > To wraps past midnight, so 22→2 matches hours
+// 22, 23, 0, 1, and 2. The time zone defaults to UTC.
+func buildHourOfDayClause(hr *model.HourOfDayRange, idx int) (string, []any, int) {
+	tz := "UTC"
+	if hr.TimeZone != nil {
+		tz = *hr.TimeZone
+	}
+	hour := fmt.Sprintf("EXTRACT(hour FROM event_time AT TIME ZONE $%d)", idx)
+	var clause string
+	if hr.From <= hr.To {
+		clause = fmt.Sprintf("%s BETWEEN $%d AND $%d", hour, idx+1, idx+2)
+	} else {
+		clause = fmt.Sprintf("(%s >= $%d OR %s <= $%d)", hour, idx+1, hour, idx+2)
+	}
+	return clause, []any{tz, hr.From, hr.To}, idx + 3
+}
+
 type typeCondition struct {
 	eventType   model.EventType
 	severity    []model.Severity
@@ -153,41 +313,45 @@ func maxRadius(conditions []typeCondition) float64 {
 	return largest
 }
 
-// buildSingleTypeCondition builds the AND-joined predicate for one event type condition.
-// Returns the parenthesized clause, its args, and the next parameter index.
-func buildSingleTypeCondition(tc typeCondition, near *model.GeoRadiusFilter, idx int) (string, []any, int) {
-	var parts []string
-	var args []any
-
-	parts = append(parts, fmt.Sprintf("event_type = $%d", idx))
+// writeSingleTypeCondition writes the AND-joined predicate for one event
+// type condition to b, appending its args. Returns the args and the next
+// parameter index.
+func writeSingleTypeCondition(b *strings.Builder, tc typeCondition, near *model.GeoRadiusFilter, args []any, idx int) ([]any, int) {
+	b.WriteString("(event_type = $")
+	b.WriteString(strconv.Itoa(idx))
 	args = append(args, tc.eventType.DBValue())
 	idx++
 
 	if len(tc.severity) > 0 {
-		parts = append(parts, fmt.Sprintf("measurement_severity = ANY($%d)", idx))
+		b.WriteString(" AND measurement_severity = ANY($")
+		b.WriteString(strconv.Itoa(idx))
+		b.WriteByte(')')
 		args = append(args, severityDBValues(tc.severity))
 		idx++
 	}
 	if tc.minMag != nil {
-		parts = append(parts, fmt.Sprintf("measurement_magnitude >= $%d", idx))
+		b.WriteString(" AND measurement_magnitude >= $")
+		b.WriteString(strconv.Itoa(idx))
 		args = append(args, *tc.minMag)
 		idx++
 	}
 	if near != nil && tc.radiusMiles != nil {
 		hav := buildHaversine(near.Lat, near.Lon, *tc.radiusMiles, idx)
-		parts = append(parts, hav.clause)
+		b.WriteString(" AND ")
+		b.WriteString(hav.clause)
 		args = append(args, hav.args...)
 		idx = hav.nextIdx
 	}
 
-	return "(" + strings.Join(parts, " AND ") + ")", args, idx
+	b.WriteByte(')')
+	return args, idx
 }
 
 // buildEventTypeConditions builds bounding-box and per-type OR clauses for eventTypeFilters.
 // Returns additional WHERE clauses, updated args, and the next parameter index.
 func buildEventTypeConditions(filter *model.StormReportFilter, args []any, idx int) ([]string, []any, int) {
 	conditions := collectTypeConditions(filter)
-	var clauses []string
+	clauses := make([]string, 0, 2)
 
 	// Bounding box using the max radius across all conditions (for index usage)
 	if filter.Near != nil {
@@ -199,15 +363,19 @@ func buildEventTypeConditions(filter *model.StormReportFilter, args []any, idx i
 		}
 	}
 
-	// Per-type OR clauses
-	orParts := make([]string, 0, len(conditions))
-	for _, tc := range conditions {
-		clause, tcArgs, nextIdx := buildSingleTypeCondition(tc, filter.Near, idx)
-		orParts = append(orParts, clause)
-		args = append(args, tcArgs...)
-		idx = nextIdx
+	// Per-type OR clauses, written into one builder sized for a haversine
+	// per type so the clause is allocated once.
+	var b strings.Builder
+	b.Grow(2 + len(conditions)*typeConditionSize)
+	b.WriteByte('(')
+	for i, tc := range conditions {
+		if i > 0 {
+			b.WriteString(" OR ")
+		}
+		args, idx = writeSingleTypeCondition(&b, tc, filter.Near, args, idx)
 	}
-	clauses = append(clauses, "("+strings.Join(orParts, " OR ")+")")
+	b.WriteByte(')')
+	clauses = append(clauses, b.String())
 
 	return clauses, args, idx
 }
@@ -259,15 +427,21 @@ type haversineResult struct {
 	nextIdx int
 }
 
-// buildHaversine builds a haversine great-circle distance clause.
-func buildHaversine(lat, lon, radiusMiles float64, idx int) haversineResult {
-	clause := fmt.Sprintf(`(
+// haversineDistance returns the great-circle distance in miles from the point
+// bound at $idx (lat), $idx+1 (lon) and $idx+2 (lat again) to each report.
+func haversineDistance(idx int) string {
+	return fmt.Sprintf(`(
 		%v * acos(
 			cos(radians($%d)) * cos(radians(geo_lat)) *
 			cos(radians(geo_lon) - radians($%d)) +
 			sin(radians($%d)) * sin(radians(geo_lat))
 		)
-	) <= $%d`, earthRadiusMiles, idx, idx+1, idx+2, idx+3)
+	)`, earthRadiusMiles, idx, idx+1, idx+2)
+}
+
+// buildHaversine builds a haversine great-circle distance clause.
+func buildHaversine(lat, lon, radiusMiles float64, idx int) haversineResult {
+	clause := haversineDistance(idx) + " <= $" + strconv.Itoa(idx+3)
 	return haversineResult{
 		clause:  clause,
 		args:    []any{lat, lon, lat, radiusMiles},
@@ -304,6 +478,8 @@ func sortColumn(sf model.SortField) string {
 		return "location_state"
 	case model.SortFieldEventType:
 		return "event_type"
+	case model.SortFieldProcessedAt:
+		return "processed_at"
 	default:
 		return "event_time"
 	}
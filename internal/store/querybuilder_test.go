@@ -10,7 +10,7 @@ import (
 
 func TestBuildWhereClause_TimeOnly(t *testing.T) {
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -27,7 +27,7 @@ func TestBuildWhereClause_TimeOnly(t *testing.T) {
 
 func TestBuildWhereClause_WithEventTypes(t *testing.T) {
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -47,7 +47,7 @@ func TestBuildWhereClause_WithEventTypes(t *testing.T) {
 func TestBuildWhereClause_AllSimpleFilters(t *testing.T) {
 	mag := 1.5
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -66,10 +66,85 @@ func TestBuildWhereClause_AllSimpleFilters(t *testing.T) {
 	assert.Equal(t, 8, nextIdx)
 }
 
+func TestBuildWhereClause_ExcludeLocations(t *testing.T) {
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		States:          []string{"TX"},
+		ExcludeCounties: []string{"Dallas", "Tarrant"},
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 4)
+	assert.Equal(t, "location_state = ANY($3)", where[2])
+	assert.Equal(t, "location_county <> ALL($4)", where[3])
+	assert.Equal(t, []string{"Dallas", "Tarrant"}, args[3])
+	assert.Equal(t, 5, nextIdx)
+
+	filter.States, filter.ExcludeStates = nil, []string{"TX", "OK", "KS"}
+	where, _, _ = buildWhereClause(filter)
+	assert.Equal(t, "location_state <> ALL($3)", where[2])
+}
+
+func TestBuildWhereClause_Pipelines(t *testing.T) {
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		Pipelines: []string{"etl-v1", "etl-v2"},
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 3)
+	assert.Equal(t, "pipeline = ANY($3)", where[2])
+	assert.Equal(t, []string{"etl-v1", "etl-v2"}, args[2])
+	assert.Equal(t, 4, nextIdx)
+}
+
+func TestBuildWhereClause_ImpactKeywords(t *testing.T) {
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		ImpactKeywords: []string{"roof damage", "100%"},
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 3)
+	assert.Equal(t, "(comments ILIKE '%' || $3 || '%' OR comments ILIKE '%' || $4 || '%')", where[2])
+	assert.Equal(t, []any{"roof damage", `100\%`}, args[2:])
+	assert.Equal(t, 5, nextIdx)
+}
+
+func TestBuildWhereClause_IDPrefix(t *testing.T) {
+	prefix := "hail_5d%9\\"
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		IDPrefix: &prefix,
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 3)
+	assert.Equal(t, "id LIKE $3 || '%'", where[2])
+	assert.Equal(t, `hail\_5d\%9\\`, args[2], "wildcards must match literally")
+	assert.Equal(t, 4, nextIdx)
+}
+
 func TestBuildWhereClause_NearRadiusFilter(t *testing.T) {
 	radius := 50.0
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -89,12 +164,25 @@ func TestBuildWhereClause_NearRadiusFilter(t *testing.T) {
 	assert.Equal(t, 11, nextIdx)
 }
 
+func TestHaversineDistance(t *testing.T) {
+	expr := haversineDistance(7)
+	assert.Contains(t, expr, "cos(radians($7))")
+	assert.Contains(t, expr, "radians($8)")
+	assert.Contains(t, expr, "sin(radians($9))")
+	assert.NotContains(t, expr, "<=")
+
+	// The radius check compares the same expression against the next parameter.
+	h := buildHaversine(32.7, -96.8, 25, 7)
+	assert.Equal(t, expr+" <= $10", h.clause)
+	assert.Equal(t, 11, h.nextIdx)
+}
+
 func TestBuildWhereClause_EventTypeFilters(t *testing.T) {
 	hailRadius := 20.0
 	tornadoRadius := 50.0
 	minMag := 1.0
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -128,7 +216,7 @@ func TestBuildWhereClause_EventTypeFiltersWithGlobalDefaults(t *testing.T) {
 	hailRadius := 30.0
 	globalMag := 0.5
 	filter := &model.StormReportFilter{
-		TimeRange: model.TimeRange{
+		TimeRange: &model.TimeRange{
 			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
 			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
 		},
@@ -150,6 +238,96 @@ func TestBuildWhereClause_EventTypeFiltersWithGlobalDefaults(t *testing.T) {
 	assert.Contains(t, orClause, "OR")
 }
 
+func TestBuildWhereClause_HourOfDayRange(t *testing.T) {
+	tz := "America/Chicago"
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		HourOfDayRange: &model.HourOfDayRange{From: 18, To: 23, TimeZone: &tz},
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 3)
+	assert.Equal(t, "EXTRACT(hour FROM event_time AT TIME ZONE $3) BETWEEN $4 AND $5", where[2])
+	assert.Equal(t, []any{"America/Chicago", 18, 23}, args[2:])
+	assert.Equal(t, 6, nextIdx)
+}
+
+func TestBuildWhereClause_IngestedWithinMinutes(t *testing.T) {
+	minutes := 15
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		IngestedWithinMinutes: &minutes,
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	assert.Len(t, where, 3)
+	assert.Equal(t, "processed_at > NOW() - make_interval(mins => $3)", where[2])
+	assert.Equal(t, 15, args[2])
+	assert.Equal(t, 4, nextIdx)
+}
+
+func TestBuildWhereClause_MinSeverity(t *testing.T) {
+	minSeverity := model.SeveritySevere
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		MinSeverity: &minSeverity,
+		EventTypeFilters: []*model.EventTypeFilter{
+			{EventType: model.EventTypeHail},
+		},
+	}
+
+	where, args, nextIdx := buildWhereClause(filter)
+
+	// 2 time + minSeverity + per-type OR group
+	assert.Len(t, where, 4)
+	assert.Equal(t, "severity_ordinal >= $3", where[2])
+	assert.Equal(t, 3, args[2])
+	assert.Equal(t, "((event_type = $4))", where[3])
+	assert.Equal(t, 5, nextIdx)
+}
+
+func TestBuildHourOfDayClause_WrapAround(t *testing.T) {
+	clause, args, nextIdx := buildHourOfDayClause(&model.HourOfDayRange{From: 22, To: 2}, 3)
+
+	assert.Equal(t,
+		"(EXTRACT(hour FROM event_time AT TIME ZONE $3) >= $4 OR EXTRACT(hour FROM event_time AT TIME ZONE $3) <= $5)",
+		clause)
+	assert.Equal(t, []any{"UTC", 22, 2}, args)
+	assert.Equal(t, 6, nextIdx)
+}
+
+func TestQueryParamCount(t *testing.T) {
+	radius := 25.0
+	filter := &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	assert.Equal(t, 6, QueryParamCount(filter), "time bounds plus the aggregation query's extras")
+
+	// Per-type mode: bounding box, then event type and haversine per type.
+	filter.Near = &model.GeoRadiusFilter{Lat: 35.0, Lon: -97.0, RadiusMiles: &radius}
+	filter.EventTypeFilters = []*model.EventTypeFilter{
+		{EventType: model.EventTypeHail},
+		{EventType: model.EventTypeTornado},
+	}
+	_, args, _ := buildWhereClause(filter)
+	assert.Equal(t, 2+4+2*(1+4), len(args))
+	assert.Equal(t, len(args)+4+3, QueryParamCount(filter), "plus the near center for group distances")
+}
+
 func TestSortColumn(t *testing.T) {
 	tests := []struct {
 		input model.SortField
@@ -159,6 +337,7 @@ func TestSortColumn(t *testing.T) {
 		{model.SortFieldMagnitude, "measurement_magnitude"},
 		{model.SortFieldLocationState, "location_state"},
 		{model.SortFieldEventType, "event_type"},
+		{model.SortFieldProcessedAt, "processed_at"},
 		{model.SortField("UNKNOWN"), "event_time"},
 	}
 
@@ -178,3 +357,35 @@ func TestSeverityDBValues(t *testing.T) {
 	vals := severityDBValues([]model.Severity{model.SeverityMinor, model.SeverityExtreme})
 	assert.Equal(t, []string{"minor", "extreme"}, vals)
 }
+
+func benchmarkFilter() *model.StormReportFilter {
+	radius, hailRadius, minMag := 40.0, 20.0, 1.0
+	return &model.StormReportFilter{
+		TimeRange: &model.TimeRange{
+			From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+			To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+		},
+		States:         []string{"TX", "OK"},
+		ImpactKeywords: []string{"roof damage", "power lines"},
+		EventTypes:     []model.EventType{model.EventTypeHail, model.EventTypeWind, model.EventTypeTornado},
+		Near:           &model.GeoRadiusFilter{Lat: 35.0, Lon: -97.0, RadiusMiles: &radius},
+		EventTypeFilters: []*model.EventTypeFilter{
+			{EventType: model.EventTypeHail, MinMagnitude: &minMag, RadiusMiles: &hailRadius},
+		},
+	}
+}
+
+func BenchmarkBuildWhereClause_Simple(b *testing.B) {
+	filter := benchmarkFilter()
+	filter.EventTypeFilters = nil
+	for b.Loop() {
+		buildWhereClause(filter)
+	}
+}
+
+func BenchmarkBuildWhereClause_EventTypeFilters(b *testing.B) {
+	filter := benchmarkFilter()
+	for b.Loop() {
+		buildWhereClause(filter)
+	}
+}
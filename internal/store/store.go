@@ -4,24 +4,34 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/couchcryptid/storm-data-api/internal/model"
 	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/couchcryptid/storm-data-shared/retry"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/sync/singleflight"
 )
 
-const columns = `id, event_type, geo_lat, geo_lon, measurement_magnitude, measurement_unit,
-	event_time,
-	location_raw, location_name, location_distance, location_direction,
-	location_state, location_county,
-	comments, measurement_severity, source_office, time_bucket, processed_at`
-
 // Store provides persistence operations for storm reports backed by PostgreSQL.
 type Store struct {
 	pool    *pgxpool.Pool
 	metrics *observability.Metrics
+	units   map[string]string
+	breaker *breaker
+
+	// samplePercent is set from config; zero keeps sampled aggregations exact.
+	samplePercent float64
+
+	timeExtent cachedTimeExtent
+	eventTypes cachedEventTypes
 }
 
 // New creates a Store with the given connection pool and metrics.
@@ -29,8 +39,57 @@ func New(pool *pgxpool.Pool, m *observability.Metrics) *Store {
 	return &Store{pool: pool, metrics: m}
 }
 
-func (s *Store) observeQuery(operation string, start time.Time) {
-	s.metrics.DBQueryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+// SetQueryBreaker puts a circuit breaker in front of the read queries behind
+// the GraphQL API (ListStormReports, Aggregations, Extent, LastUpdated). After
+// threshold consecutive failures they return ErrUnavailable for cooldown
+// rather than piling more work onto a struggling database. Inserts from the
+// Kafka consumer are not guarded; the consumer already backs off on errors.
+func (s *Store) SetQueryBreaker(threshold int, cooldown time.Duration) {
+	s.breaker = newBreaker(threshold, cooldown, func(state BreakerState) {
+		s.metrics.DBCircuitBreakerState.WithLabelValues("query").Set(float64(state))
+	})
+}
+
+// lastUpdatedTimeout bounds the freshness lookups behind QueryMeta and
+// dataTimeExtent. Each is a single index-backed MIN/MAX, so anything slower
+// means the database is wedged; cancelling frees the pooled connection instead
+// of holding it for the caller.
+const lastUpdatedTimeout = 2 * time.Second
+
+// pgQueryCanceled is the SQLSTATE Postgres reports when a statement is
+// cancelled, including by statement_timeout.
+const pgQueryCanceled = "57014"
+
+// IsStatementTimeout reports whether err came from Postgres cancelling a query
+// because it ran past statement_timeout. The same SQLSTATE also covers
+// cancellations pgx sends when the caller's context ends, which only the
+// message tells apart.
+func IsStatementTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled &&
+		strings.Contains(pgErr.Message, "statement timeout")
+}
+
+func (s *Store) observeQuery(ctx context.Context, operation string, start time.Time) {
+	s.metrics.Observe(ctx, s.metrics.DBQueryDuration.WithLabelValues(operation), time.Since(start).Seconds())
+}
+
+// Result cache names, reported as the cache label.
+const (
+	cacheEventTimeExtent    = "event_time_extent"
+	cacheDistinctEventTypes = "distinct_event_types"
+)
+
+// observeCache counts a lookup in the named result cache as a hit or miss.
+func (s *Store) observeCache(cache string, hit bool) {
+	if s.metrics == nil {
+		return
+	}
+	if hit {
+		s.metrics.CacheHits.WithLabelValues(cache).Inc()
+	} else {
+		s.metrics.CacheMisses.WithLabelValues(cache).Inc()
+	}
 }
 
 // InsertStormReport upserts a storm report into the database.
@@ -38,10 +97,10 @@ func (s *Store) observeQuery(operation string, start time.Time) {
 // events always produce the same ID. ON CONFLICT DO NOTHING makes inserts
 // idempotent, which is safe for Kafka's at-least-once delivery.
 func (s *Store) InsertStormReport(ctx context.Context, report *model.StormReport) error {
-	defer s.observeQuery("insert", time.Now())
+	defer s.observeQuery(ctx, "insert", time.Now())
 	_, err := s.pool.Exec(ctx, `
 		INSERT INTO storm_reports (`+columns+`)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
 		ON CONFLICT (id) DO NOTHING`,
 		report.ID, report.EventType, report.Geo.Lat, report.Geo.Lon,
 		report.Measurement.Magnitude, report.Measurement.Unit,
@@ -50,13 +109,16 @@ func (s *Store) InsertStormReport(ctx context.Context, report *model.StormReport
 		report.Location.Distance, report.Location.Direction,
 		report.Location.State, report.Location.County,
 		report.Comments, report.Measurement.Severity, report.SourceOffice,
-		report.TimeBucket, report.ProcessedAt,
+		report.TimeBucket, report.ProcessedAt, report.Pipeline,
 	)
-	return err
+	if err != nil {
+		return queryError("insert storm report", err)
+	}
+	return nil
 }
 
-const insertSQL = `INSERT INTO storm_reports (` + columns + `)
-	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18)
+var insertSQL = `INSERT INTO storm_reports (` + columns + `)
+	VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19)
 	ON CONFLICT (id) DO NOTHING`
 
 // InsertStormReports batch-inserts multiple storm reports using pgx.Batch.
@@ -64,9 +126,116 @@ func (s *Store) InsertStormReports(ctx context.Context, reports []*model.StormRe
 	if len(reports) == 0 {
 		return nil
 	}
-	defer s.observeQuery("batch_insert", time.Now())
+	defer s.observeQuery(ctx, "batch_insert", time.Now())
+	return s.retryLockConflicts(ctx, "batch_insert", func() error {
+		return s.insertBatch(ctx, reports)
+	})
+}
 
+func (s *Store) insertBatch(ctx context.Context, reports []*model.StormReport) error {
 	batch := &pgx.Batch{}
+	queueInserts(batch, reports)
+
+	batchResults := s.pool.SendBatch(ctx, batch)
+	defer batchResults.Close()
+
+	for range reports {
+		if _, err := batchResults.Exec(); err != nil {
+			return queryError("batch insert", err)
+		}
+	}
+
+	return nil
+}
+
+const upsertOffsetSQL = `INSERT INTO kafka_offsets (topic, partition, last_offset, updated_at)
+	VALUES ($1, $2, $3, NOW())
+	ON CONFLICT (topic, partition) DO UPDATE
+	SET last_offset = GREATEST(kafka_offsets.last_offset, EXCLUDED.last_offset), updated_at = NOW()`
+
+// InsertStormReportsWithOffsets batch-inserts reports and records the highest
+// offset processed for each partition in a single transaction. A crash after
+// the commit but before the Kafka offset commit leaves a durable record that
+// the redelivered messages were already written.
+func (s *Store) InsertStormReportsWithOffsets(ctx context.Context, topic string, reports []*model.StormReport, offsets map[int]int64) error {
+	defer s.observeQuery(ctx, "batch_insert_offsets", time.Now())
+	return s.retryLockConflicts(ctx, "batch_insert_offsets", func() error {
+		return s.insertWithOffsets(ctx, topic, reports, offsets)
+	})
+}
+
+func (s *Store) insertWithOffsets(ctx context.Context, topic string, reports []*model.StormReport, offsets map[int]int64) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return queryError("begin transaction", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after Commit
+
+	batch := &pgx.Batch{}
+	queueInserts(batch, reports)
+	for partition, offset := range offsets {
+		batch.Queue(upsertOffsetSQL, topic, partition, offset)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return queryError("batch insert with offsets", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return queryError("commit transaction", err)
+	}
+	return nil
+}
+
+// ProcessedOffsets returns the highest recorded offset for each partition of
+// the topic.
+func (s *Store) ProcessedOffsets(ctx context.Context, topic string) (map[int]int64, error) {
+	defer s.observeQuery(ctx, "processed_offsets", time.Now())
+	rows, err := s.pool.Query(ctx, "SELECT partition, last_offset FROM kafka_offsets WHERE topic = $1", topic)
+	if err != nil {
+		return nil, queryError("query processed offsets", err)
+	}
+	defer rows.Close()
+
+	offsets := make(map[int]int64)
+	for rows.Next() {
+		var partition int
+		var offset int64
+		if err := rows.Scan(&partition, &offset); err != nil {
+			return nil, queryError("scan processed offset", err)
+		}
+		offsets[partition] = offset
+	}
+	return offsets, rows.Err()
+}
+
+// Lock conflict retries for batch writes. Concurrent batches touching the
+// same IDs or offset rows can deadlock or time out waiting on each other's
+// locks; ON CONFLICT DO NOTHING and GREATEST make a repeated write harmless,
+// so the batch is retried here rather than failed back to the consumer,
+// where redelivery would just collide again.
+const (
+	lockConflictRetries = 3
+	lockConflictBackoff = 50 * time.Millisecond
+)
+
+// retryLockConflicts runs write, retrying with jittered, doubling backoff while
+// it fails with ErrLockConflict. Every conflict is counted in DBLockConflicts.
+func (s *Store) retryLockConflicts(ctx context.Context, operation string, write func() error) error {
+	backoff := lockConflictBackoff
+	for attempt := 0; ; attempt++ {
+		err := write()
+		if !errors.Is(err, ErrLockConflict) {
+			return err
+		}
+		s.metrics.DBLockConflicts.WithLabelValues(operation).Inc()
+		if attempt == lockConflictRetries || !retry.SleepWithContext(ctx, backoff/2+rand.N(backoff/2+1)) {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+func queueInserts(batch *pgx.Batch, reports []*model.StormReport) {
 	for _, r := range reports {
 		batch.Queue(insertSQL,
 			r.ID, r.EventType, r.Geo.Lat, r.Geo.Lon,
@@ -76,25 +245,22 @@ func (s *Store) InsertStormReports(ctx context.Context, reports []*model.StormRe
 			r.Location.Distance, r.Location.Direction,
 			r.Location.State, r.Location.County,
 			r.Comments, r.Measurement.Severity, r.SourceOffice,
-			r.TimeBucket, r.ProcessedAt,
+			r.TimeBucket, r.ProcessedAt, r.Pipeline,
 		)
 	}
-
-	batchResults := s.pool.SendBatch(ctx, batch)
-	defer batchResults.Close()
-
-	for range reports {
-		if _, err := batchResults.Exec(); err != nil {
-			return fmt.Errorf("batch insert: %w", err)
-		}
-	}
-
-	return nil
 }
 
 // ListStormReports returns filtered, sorted, paginated reports and the total count.
-func (s *Store) ListStormReports(ctx context.Context, filter *model.StormReportFilter) ([]*model.StormReport, int, error) {
-	defer s.observeQuery("list", time.Now())
+// fields names the GraphQL StormReport fields the caller needs; only their
+// columns are selected, so slim list views don't read comments or location
+// detail. With no fields, every column is selected.
+func (s *Store) ListStormReports(ctx context.Context, filter *model.StormReportFilter, fields ...string) (_ []*model.StormReport, _ int, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "list", time.Now())
 	where, baseArgs, idx := buildWhereClause(filter)
 
 	whereSQL := buildWhereSQL(where)
@@ -103,7 +269,7 @@ func (s *Store) ListStormReports(ctx context.Context, filter *model.StormReportF
 	countQuery := "SELECT COUNT(*) FROM storm_reports" + whereSQL
 	var totalCount int
 	if err := s.pool.QueryRow(ctx, countQuery, baseArgs...).Scan(&totalCount); err != nil {
-		return nil, 0, fmt.Errorf("count storm reports: %w", err)
+		return nil, 0, queryError("count storm reports", err)
 	}
 
 	// Build data query with sorting and pagination
@@ -119,7 +285,8 @@ func (s *Store) ListStormReports(ctx context.Context, filter *model.StormReportF
 	dataArgs := make([]any, len(baseArgs))
 	copy(dataArgs, baseArgs)
 
-	query := "SELECT " + columns + " FROM storm_reports" + whereSQL +
+	proj := projectReportFields(fields)
+	query := "SELECT " + proj.sql() + " FROM storm_reports" + whereSQL +
 		fmt.Sprintf(" ORDER BY %s %s", orderCol, orderDir)
 
 	if filter.Limit != nil {
@@ -134,53 +301,390 @@ func (s *Store) ListStormReports(ctx context.Context, filter *model.StormReportF
 
 	rows, err := s.pool.Query(ctx, query, dataArgs...)
 	if err != nil {
-		return nil, 0, fmt.Errorf("query storm reports: %w", err)
+		return nil, 0, queryError("query storm reports", err)
 	}
 	defer rows.Close()
 
 	var reports []*model.StormReport
 	for rows.Next() {
-		r, err := scanStormReport(rows)
+		r, err := proj.scan(rows)
 		if err != nil {
-			return nil, 0, err
+			return nil, 0, queryError("scan storm report", err)
 		}
 		reports = append(reports, r)
 	}
 	return reports, totalCount, rows.Err()
 }
 
+// StormReportByID returns the report with the given ID, or nil if there is
+// none.
+func (s *Store) StormReportByID(ctx context.Context, id string) (_ *model.StormReport, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "by_id", time.Now())
+	return scanStormReport(s.pool.QueryRow(ctx, "SELECT "+columns+" FROM storm_reports WHERE id = $1", id))
+}
+
 // LastUpdated returns the most recent processed_at timestamp.
-func (s *Store) LastUpdated(ctx context.Context) (*time.Time, error) {
-	defer s.observeQuery("last_updated", time.Now())
+func (s *Store) LastUpdated(ctx context.Context) (_ *time.Time, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "last_updated", time.Now())
+	ctx, cancel := context.WithTimeout(ctx, lastUpdatedTimeout)
+	defer cancel()
 	var t *time.Time
-	err := s.pool.QueryRow(ctx, "SELECT MAX(processed_at) FROM storm_reports").Scan(&t)
+	err = s.pool.QueryRow(ctx, "SELECT MAX(processed_at) FROM storm_reports").Scan(&t)
 	if err != nil {
-		return nil, fmt.Errorf("last updated: %w", err)
+		return nil, queryError("last updated", err)
 	}
 	return t, nil
 }
 
-type scannable interface {
-	Scan(dest ...any) error
+// eventTimeExtentTTL is how long EventTimeExtent reuses its last result. The
+// range only moves as reports arrive, so clients loading date pickers don't
+// need a query each.
+const eventTimeExtentTTL = time.Minute
+
+// cachedTimeExtent holds the last EventTimeExtent result until it expires.
+// mu only guards the fields; the query runs outside it, and flight collapses
+// concurrent misses into one query.
+type cachedTimeExtent struct {
+	mu      sync.Mutex
+	extent  *model.DataTimeExtent
+	expires time.Time
+
+	flight singleflight.Group
+}
+
+// get returns the cached extent, if fresh.
+func (c *cachedTimeExtent) get(now time.Time) (*model.DataTimeExtent, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.extent, now.Before(c.expires)
 }
 
-func scanStormReport(row scannable) (*model.StormReport, error) {
-	var r model.StormReport
-	err := row.Scan(
-		&r.ID, &r.EventType, &r.Geo.Lat, &r.Geo.Lon,
-		&r.Measurement.Magnitude, &r.Measurement.Unit,
-		&r.EventTime,
-		&r.Location.Raw, &r.Location.Name,
-		&r.Location.Distance, &r.Location.Direction,
-		&r.Location.State, &r.Location.County,
-		&r.Comments, &r.Measurement.Severity, &r.SourceOffice,
-		&r.TimeBucket, &r.ProcessedAt,
-	)
+// put caches extent for eventTimeExtentTTL from now.
+func (c *cachedTimeExtent) put(extent *model.DataTimeExtent, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.extent = extent
+	c.expires = now.Add(eventTimeExtentTTL)
+}
+
+// EventTimeExtent returns the earliest and latest event_time across all
+// reports, or nil if the table is empty. Results are cached for
+// eventTimeExtentTTL; errors are not. Concurrent callers on a miss share one
+// query, and each stops waiting when its own ctx is done.
+func (s *Store) EventTimeExtent(ctx context.Context) (*model.DataTimeExtent, error) {
+	if extent, ok := s.timeExtent.get(time.Now()); ok {
+		s.observeCache(cacheEventTimeExtent, true)
+		return extent, nil
+	}
+	s.observeCache(cacheEventTimeExtent, false)
+
+	// The shared query is detached from the first caller's cancellation so
+	// it can't fail the others; lastUpdatedTimeout still bounds it.
+	ch := s.timeExtent.flight.DoChan("", func() (any, error) {
+		return s.queryEventTimeExtent(context.WithoutCancel(ctx))
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*model.DataTimeExtent), nil
+	}
+}
+
+func (s *Store) queryEventTimeExtent(ctx context.Context) (_ *model.DataTimeExtent, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "event_time_extent", time.Now())
+	ctx, cancel := context.WithTimeout(ctx, lastUpdatedTimeout)
+	defer cancel()
+	var earliest, latest *time.Time
+	err = s.pool.QueryRow(ctx, "SELECT MIN(event_time), MAX(event_time) FROM storm_reports").Scan(&earliest, &latest)
+	if err != nil {
+		return nil, queryError("event time extent", err)
+	}
+
+	var extent *model.DataTimeExtent
+	if earliest != nil && latest != nil {
+		extent = &model.DataTimeExtent{Earliest: *earliest, Latest: *latest}
+	}
+	s.timeExtent.put(extent, time.Now())
+	return extent, nil
+}
+
+// LargestIngestionGap returns the longest pause between consecutive distinct
+// processed_at values at or after since, or nil when fewer than two fall in
+// the window. Ties go to the most recent gap. Uses the processed_at index and
+// a LAG window over the window's rows.
+func (s *Store) LargestIngestionGap(ctx context.Context, since time.Time) (_ *model.IngestionGap, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "ingestion_gap", time.Now())
+	var gap model.IngestionGap
+	err = s.pool.QueryRow(ctx, `
+		SELECT prev, processed_at FROM (
+			SELECT processed_at, LAG(processed_at) OVER (ORDER BY processed_at) AS prev
+			FROM (SELECT DISTINCT processed_at FROM storm_reports WHERE processed_at >= $1) t
+		) g
+		WHERE prev IS NOT NULL
+		ORDER BY processed_at - prev DESC, processed_at DESC
+		LIMIT 1`, since).Scan(&gap.From, &gap.To)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("scan storm report: %w", err)
+		return nil, queryError("ingestion gap", err)
+	}
+	gap.Minutes = int(gap.To.Sub(gap.From).Minutes())
+	return &gap, nil
+}
+
+// distinctEventTypesTTL is how long DistinctEventTypes reuses a result for
+// the same window. Dashboards ask for the types present before every
+// query, and they only change as reports arrive.
+const distinctEventTypesTTL = time.Minute
+
+// distinctEventTypesCacheSize caps the windows DistinctEventTypes keeps
+// cached; the cache is cleared when it fills with unexpired entries.
+const distinctEventTypesCacheSize = 256
+
+// cachedEventTypes holds DistinctEventTypes results per time window.
+type cachedEventTypes struct {
+	mu      sync.Mutex
+	entries map[eventTypesKey]eventTypesEntry
+}
+
+// eventTypesKey identifies a window by its instants. time.Time carries a
+// location and monotonic reading, so the same window sent with different
+// offsets would otherwise be cached twice.
+type eventTypesKey struct {
+	from, to int64
+}
+
+func newEventTypesKey(tr model.TimeRange) eventTypesKey {
+	return eventTypesKey{from: tr.From.UTC().UnixNano(), to: tr.To.UTC().UnixNano()}
+}
+
+type eventTypesEntry struct {
+	types   []model.EventType
+	expires time.Time
+}
+
+// get returns the cached types for tr, if fresh.
+func (c *cachedEventTypes) get(tr model.TimeRange, now time.Time) ([]model.EventType, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[newEventTypesKey(tr)]
+	if !ok || !now.Before(e.expires) {
+		return nil, false
+	}
+	return e.types, true
+}
+
+// put caches types for tr, evicting expired entries when the cache is full.
+// It returns the number of entries evicted.
+func (c *cachedEventTypes) put(tr model.TimeRange, types []model.EventType, now time.Time) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var evicted int
+	if n := len(c.entries); n >= distinctEventTypesCacheSize {
+		maps.DeleteFunc(c.entries, func(_ eventTypesKey, e eventTypesEntry) bool {
+			return !now.Before(e.expires)
+		})
+		if len(c.entries) >= distinctEventTypesCacheSize {
+			clear(c.entries)
+		}
+		evicted = n - len(c.entries)
+	}
+	if c.entries == nil {
+		c.entries = make(map[eventTypesKey]eventTypesEntry)
+	}
+	c.entries[newEventTypesKey(tr)] = eventTypesEntry{types: types, expires: now.Add(distinctEventTypesTTL)}
+	return evicted
+}
+
+// DistinctEventTypes returns the event types with at least one report in
+// tr, in AllEventTypes order. It is much cheaper than the aggregation query
+// for clients that only need to know which types have data. Results are
+// cached per window for distinctEventTypesTTL; errors are not.
+func (s *Store) DistinctEventTypes(ctx context.Context, tr model.TimeRange) ([]model.EventType, error) {
+	if types, ok := s.eventTypes.get(tr, time.Now()); ok {
+		s.observeCache(cacheDistinctEventTypes, true)
+		return types, nil
+	}
+	s.observeCache(cacheDistinctEventTypes, false)
+
+	groups, err := s.groupCount(ctx, &model.StormReportFilter{TimeRange: &tr}, "event_type")
+	if err != nil {
+		return nil, err
+	}
+	values := make([]string, len(groups))
+	for i, g := range groups {
+		values[i] = g.Keys[0]
+	}
+
+	types := eventTypesPresent(values)
+	if evicted := s.eventTypes.put(tr, types, time.Now()); evicted > 0 && s.metrics != nil {
+		s.metrics.CacheEvictions.WithLabelValues(cacheDistinctEventTypes).Add(float64(evicted))
+	}
+	return types, nil
+}
+
+// eventTypesPresent maps DB event_type values to known EventTypes in
+// AllEventTypes order. Values that match no EventType are dropped.
+func eventTypesPresent(values []string) []model.EventType {
+	types := make([]model.EventType, 0, len(values))
+	for _, et := range model.AllEventTypes {
+		if slices.Contains(values, et.DBValue()) {
+			types = append(types, et)
+		}
+	}
+	return types
+}
+
+// Extent holds the bounding box and centroid of a filtered set of reports.
+type Extent struct {
+	Bounds   *model.GeoBounds
+	Centroid *model.Geo
+}
+
+// Extent returns the bounding box and mean position of all reports matching
+// the filter in a single aggregate query. Every report contributes equally to
+// the centroid, so it leans toward dense clusters rather than the box center.
+// Both fields are nil when nothing matches. Sort and pagination fields are
+// ignored.
+func (s *Store) Extent(ctx context.Context, filter *model.StormReportFilter) (_ *Extent, err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { s.breaker.record(probe, err) }()
+	defer s.observeQuery(ctx, "extent", time.Now())
+	where, args, _ := buildWhereClause(filter)
+
+	var minLat, maxLat, minLon, maxLon, avgLat, avgLon *float64
+	err = s.pool.QueryRow(ctx, `SELECT MIN(geo_lat), MAX(geo_lat), MIN(geo_lon), MAX(geo_lon),
+			AVG(geo_lat), AVG(geo_lon)
+		FROM storm_reports`+buildWhereSQL(where), args...).
+		Scan(&minLat, &maxLat, &minLon, &maxLon, &avgLat, &avgLon)
+	if err != nil {
+		return nil, queryError("extent", err)
+	}
+	if minLat == nil {
+		return &Extent{}, nil
+	}
+	return &Extent{
+		Bounds:   &model.GeoBounds{MinLat: *minLat, MaxLat: *maxLat, MinLon: *minLon, MaxLon: *maxLon},
+		Centroid: &model.Geo{Lat: *avgLat, Lon: *avgLon},
+	}, nil
+}
+
+// replayFetchSize is how many rows StreamStormReports pulls per cursor FETCH.
+const replayFetchSize = 500
+
+// StreamStormReports calls fn for every report with event_time in [from, to],
+// ordered by event_time then id. Rows are read through a server-side cursor in
+// chunks, so arbitrarily large ranges stream without being held in memory.
+// Iteration stops at the first error returned by fn.
+func (s *Store) StreamStormReports(ctx context.Context, from, to time.Time, fn func(*model.StormReport) error) error {
+	return s.streamStormReports(ctx, from, to, nil, fn)
+}
+
+// StreamCountedStormReports is StreamStormReports, but first calls start with
+// the number of reports that will follow. The count and the cursor read the
+// same snapshot, so exactly that many calls to fn follow a nil return from
+// start. Encoders that need a length prefix use it to stream.
+func (s *Store) StreamCountedStormReports(ctx context.Context, from, to time.Time, start func(total int) error, fn func(*model.StormReport) error) error {
+	return s.streamStormReports(ctx, from, to, start, fn)
+}
+
+func (s *Store) streamStormReports(ctx context.Context, from, to time.Time, start func(int) error, fn func(*model.StormReport) error) (err error) {
+	probe, err := s.breaker.allow()
+	if err != nil {
+		return err
+	}
+	// Errors from start and fn are the caller's (typically a slow or gone
+	// client), not the database's, so they don't count against the breaker.
+	var callerErr error
+	defer func() {
+		if callerErr != nil {
+			s.breaker.record(probe, nil)
+			return
+		}
+		s.breaker.record(probe, err)
+	}()
+	defer s.observeQuery(ctx, "stream", time.Now())
+
+	// Cursors only live inside a transaction; repeatable read keeps the count
+	// and the cursor on one snapshot.
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly, IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		return queryError("begin transaction", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // read-only, nothing to keep
+
+	if start != nil {
+		var total int
+		err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM storm_reports
+			WHERE event_time >= $1 AND event_time <= $2`, from, to).Scan(&total)
+		if err != nil {
+			return queryError("count replay rows", err)
+		}
+		if callerErr = start(total); callerErr != nil {
+			return callerErr
+		}
+	}
+
+	_, err = tx.Exec(ctx, "DECLARE replay_cursor NO SCROLL CURSOR FOR SELECT "+columns+` FROM storm_reports
+		WHERE event_time >= $1 AND event_time <= $2
+		ORDER BY event_time, id`, from, to)
+	if err != nil {
+		return queryError("declare replay cursor", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM replay_cursor", replayFetchSize)
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return queryError("fetch replay cursor", err)
+		}
+		n := 0
+		for rows.Next() {
+			r, err := scanStormReport(rows)
+			if err != nil {
+				rows.Close()
+				return err
+			}
+			n++
+			if callerErr = fn(r); callerErr != nil {
+				rows.Close()
+				return callerErr
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return queryError("fetch replay cursor", err)
+		}
+		if n < replayFetchSize {
+			return nil
+		}
 	}
-	return &r, nil
 }
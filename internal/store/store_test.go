@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsStatementTimeout(t *testing.T) {
+	canceled := &pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"}
+	assert.True(t, IsStatementTimeout(canceled))
+	assert.True(t, IsStatementTimeout(fmt.Errorf("aggregations: %w", canceled)))
+	assert.False(t, IsStatementTimeout(&pgconn.PgError{Code: "57014", Message: "canceling statement due to user request"}))
+	assert.False(t, IsStatementTimeout(&pgconn.PgError{Code: "42P01"}))
+	assert.False(t, IsStatementTimeout(errors.New("connection refused")))
+	assert.False(t, IsStatementTimeout(nil))
+}
+
+func TestEventTimeExtent_Cached(t *testing.T) {
+	s := New(nil, nil)
+	cached := &model.DataTimeExtent{
+		Earliest: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+		Latest:   time.Date(2024, 4, 26, 23, 59, 0, 0, time.UTC),
+	}
+	s.timeExtent.extent = cached
+	s.timeExtent.expires = time.Now().Add(time.Minute)
+
+	// A fresh cached result never reaches the (nil) pool.
+	got, err := s.EventTimeExtent(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, cached, got)
+}
+
+func TestEventTimeExtent_MissDoesNotCacheErrors(t *testing.T) {
+	s := New(nil, observability.NewTestMetrics())
+	s.SetQueryBreaker(1, time.Minute)
+	s.breaker.record(false, assert.AnError)
+
+	_, err := s.EventTimeExtent(context.Background())
+	require.ErrorIs(t, err, ErrUnavailable)
+	_, ok := s.timeExtent.get(time.Now())
+	assert.False(t, ok, "errors are not cached")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.EventTimeExtent(ctx)
+	require.Error(t, err, "a done caller stops waiting")
+}
+
+func TestDistinctEventTypes_Cached(t *testing.T) {
+	m := observability.NewTestMetrics()
+	s := New(nil, m)
+	tr := model.TimeRange{
+		From: time.Date(2024, 4, 26, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2024, 4, 27, 0, 0, 0, 0, time.UTC),
+	}
+	cached := []model.EventType{model.EventTypeHail}
+	s.eventTypes.put(tr, cached, time.Now())
+
+	// A fresh cached result never reaches the (nil) pool.
+	got, err := s.DistinctEventTypes(context.Background(), tr)
+	require.NoError(t, err)
+	assert.Equal(t, cached, got)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.CacheHits.WithLabelValues(cacheDistinctEventTypes)), 0)
+	assert.Zero(t, testutil.ToFloat64(m.CacheMisses.WithLabelValues(cacheDistinctEventTypes)))
+}
+
+func TestCachedEventTypes_Expiry(t *testing.T) {
+	var c cachedEventTypes
+	now := time.Date(2024, 4, 27, 12, 0, 0, 0, time.UTC)
+	tr := model.TimeRange{From: now.Add(-time.Hour), To: now}
+	c.put(tr, []model.EventType{model.EventTypeWind}, now)
+
+	_, ok := c.get(tr, now.Add(distinctEventTypesTTL-time.Second))
+	assert.True(t, ok)
+	_, ok = c.get(tr, now.Add(distinctEventTypesTTL))
+	assert.False(t, ok, "expired")
+	_, ok = c.get(model.TimeRange{From: tr.From, To: now.Add(time.Minute)}, now)
+	assert.False(t, ok, "different window")
+	cst := time.FixedZone("CST", -6*60*60)
+	_, ok = c.get(model.TimeRange{From: tr.From.In(cst), To: tr.To.In(cst)}, now)
+	assert.True(t, ok, "same instants in another zone")
+
+	// Filling the cache evicts expired entries first.
+	for i := range distinctEventTypesCacheSize - 1 {
+		assert.Zero(t, c.put(model.TimeRange{From: now, To: now.Add(time.Duration(i+1) * time.Second)}, nil, now.Add(2*distinctEventTypesTTL)))
+	}
+	evicted := c.put(model.TimeRange{From: now, To: now.Add(time.Hour)}, nil, now.Add(2*distinctEventTypesTTL))
+	assert.Equal(t, 1, evicted)
+	assert.Len(t, c.entries, distinctEventTypesCacheSize)
+	_, ok = c.get(tr, now)
+	assert.False(t, ok, "expired entry evicted")
+}
+
+func TestEventTypesPresent(t *testing.T) {
+	assert.Equal(t, []model.EventType{model.EventTypeHail, model.EventTypeTornado},
+		eventTypesPresent([]string{"tornado", "hail", "flood"}))
+	assert.Empty(t, eventTypesPresent(nil))
+}
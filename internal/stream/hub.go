@@ -0,0 +1,79 @@
+// Package stream fans newly ingested storm reports out to live subscribers.
+package stream
+
+import (
+	"sync"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+)
+
+// DefaultBuffer is the per-subscriber channel capacity used when NewHub is
+// given a non-positive size.
+const DefaultBuffer = 64
+
+// Hub broadcasts reports from the Kafka consumer to every subscriber.
+//
+// Publish never blocks: each subscriber has its own bounded channel, and when
+// that channel is full the event is dropped for that subscriber only. A slow
+// client therefore loses events rather than stalling ingestion for everyone.
+type Hub struct {
+	buffer  int
+	metrics *observability.Metrics
+
+	mu   sync.RWMutex
+	subs map[chan *model.StormReport]struct{}
+}
+
+// NewHub creates a hub whose subscribers each buffer up to buffer reports.
+func NewHub(buffer int, m *observability.Metrics) *Hub {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+	return &Hub{
+		buffer:  buffer,
+		metrics: m,
+		subs:    make(map[chan *model.StormReport]struct{}),
+	}
+}
+
+// Subscribe registers a subscriber and returns its channel along with a
+// function that unregisters it and closes the channel. The cancel function
+// is safe to call more than once.
+func (h *Hub) Subscribe() (<-chan *model.StormReport, func()) {
+	ch := make(chan *model.StormReport, h.buffer)
+	h.mu.Lock()
+	h.subs[ch] = struct{}{}
+	h.mu.Unlock()
+	h.metrics.StreamSubscribers.Inc()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subs, ch)
+			close(ch)
+			h.mu.Unlock()
+			h.metrics.StreamSubscribers.Dec()
+		})
+	}
+}
+
+// Publish delivers reports to every subscriber without blocking. A nil hub
+// is a no-op so consumers can publish unconditionally.
+func (h *Hub) Publish(reports ...*model.StormReport) {
+	if h == nil {
+		return
+	}
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for ch := range h.subs {
+		for _, r := range reports {
+			select {
+			case ch <- r:
+			default:
+				h.metrics.StreamDroppedEvents.Inc()
+			}
+		}
+	}
+}
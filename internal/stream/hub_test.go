@@ -0,0 +1,97 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/couchcryptid/storm-data-api/internal/model"
+	"github.com/couchcryptid/storm-data-api/internal/observability"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_DeliversToEverySubscriber(t *testing.T) {
+	h := NewHub(4, observability.NewTestMetrics())
+	a, cancelA := h.Subscribe()
+	defer cancelA()
+	b, cancelB := h.Subscribe()
+	defer cancelB()
+
+	r := &model.StormReport{ID: "r1"}
+	h.Publish(r)
+
+	assert.Same(t, r, <-a)
+	assert.Same(t, r, <-b)
+}
+
+func TestHub_StalledSubscriberNeverBlocksPublisher(t *testing.T) {
+	m := observability.NewTestMetrics()
+	h := NewHub(2, m)
+
+	// Never read from stalled.
+	_, cancelStalled := h.Subscribe()
+	defer cancelStalled()
+	live, cancelLive := h.Subscribe()
+	defer cancelLive()
+
+	const published = 100
+	received := make(chan int)
+	go func() {
+		n := 0
+		for range live {
+			n++
+		}
+		received <- n
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		for i := range published {
+			h.Publish(&model.StormReport{ID: string(rune('a' + i%26))})
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Publish blocked on a stalled subscriber")
+	}
+
+	// The stalled subscriber kept its buffer and dropped the rest.
+	dropped := testutil.ToFloat64(m.StreamDroppedEvents)
+	assert.GreaterOrEqual(t, dropped, float64(published-2))
+
+	cancelLive()
+	n := <-received
+	assert.Equal(t, float64(2*published), float64(n)+dropped+2,
+		"every event was either delivered, dropped, or buffered for the stalled subscriber")
+}
+
+func TestHub_CancelUnsubscribes(t *testing.T) {
+	m := observability.NewTestMetrics()
+	h := NewHub(1, m)
+
+	ch, cancel := h.Subscribe()
+	assert.InDelta(t, 1, testutil.ToFloat64(m.StreamSubscribers), 0)
+
+	cancel()
+	cancel() // idempotent
+	_, ok := <-ch
+	assert.False(t, ok, "channel is closed on cancel")
+	assert.InDelta(t, 0, testutil.ToFloat64(m.StreamSubscribers), 0)
+
+	h.Publish(&model.StormReport{ID: "after"})
+	assert.InDelta(t, 0, testutil.ToFloat64(m.StreamDroppedEvents), 0)
+}
+
+func TestHub_NilPublishIsNoop(t *testing.T) {
+	var h *Hub
+	require.NotPanics(t, func() { h.Publish(&model.StormReport{}) })
+}
+
+func TestNewHub_DefaultBuffer(t *testing.T) {
+	h := NewHub(0, observability.NewTestMetrics())
+	assert.Equal(t, DefaultBuffer, h.buffer)
+}